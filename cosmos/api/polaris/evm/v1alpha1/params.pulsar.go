@@ -59,11 +59,109 @@ func (x *_Params_2_list) IsValid() bool {
 	return x.list != nil
 }
 
+var _ protoreflect.List = (*_Params_8_list)(nil)
+
+type _Params_8_list struct {
+	list *[]string
+}
+
+func (x *_Params_8_list) Len() int {
+	if x.list == nil {
+		return 0
+	}
+	return len(*x.list)
+}
+
+func (x *_Params_8_list) Get(i int) protoreflect.Value {
+	return protoreflect.ValueOfString((*x.list)[i])
+}
+
+func (x *_Params_8_list) Set(i int, value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	(*x.list)[i] = concreteValue
+}
+
+func (x *_Params_8_list) Append(value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	*x.list = append(*x.list, concreteValue)
+}
+
+func (x *_Params_8_list) AppendMutable() protoreflect.Value {
+	panic(fmt.Errorf("AppendMutable can not be called on message Params at list field PrecompileAllowlist as it is not of Message kind"))
+}
+
+func (x *_Params_8_list) Truncate(n int) {
+	*x.list = (*x.list)[:n]
+}
+
+func (x *_Params_8_list) NewElement() protoreflect.Value {
+	v := ""
+	return protoreflect.ValueOfString(v)
+}
+
+func (x *_Params_8_list) IsValid() bool {
+	return x.list != nil
+}
+
+var _ protoreflect.List = (*_Params_9_list)(nil)
+
+type _Params_9_list struct {
+	list *[]string
+}
+
+func (x *_Params_9_list) Len() int {
+	if x.list == nil {
+		return 0
+	}
+	return len(*x.list)
+}
+
+func (x *_Params_9_list) Get(i int) protoreflect.Value {
+	return protoreflect.ValueOfString((*x.list)[i])
+}
+
+func (x *_Params_9_list) Set(i int, value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	(*x.list)[i] = concreteValue
+}
+
+func (x *_Params_9_list) Append(value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	*x.list = append(*x.list, concreteValue)
+}
+
+func (x *_Params_9_list) AppendMutable() protoreflect.Value {
+	panic(fmt.Errorf("AppendMutable can not be called on message Params at list field PrecompileDenylist as it is not of Message kind"))
+}
+
+func (x *_Params_9_list) Truncate(n int) {
+	*x.list = (*x.list)[:n]
+}
+
+func (x *_Params_9_list) NewElement() protoreflect.Value {
+	v := ""
+	return protoreflect.ValueOfString(v)
+}
+
+func (x *_Params_9_list) IsValid() bool {
+	return x.list != nil
+}
+
 var (
-	md_Params              protoreflect.MessageDescriptor
-	fd_Params_evm_denom    protoreflect.FieldDescriptor
-	fd_Params_extra_eips   protoreflect.FieldDescriptor
-	fd_Params_chain_config protoreflect.FieldDescriptor
+	md_Params                             protoreflect.MessageDescriptor
+	fd_Params_evm_denom                   protoreflect.FieldDescriptor
+	fd_Params_extra_eips                  protoreflect.FieldDescriptor
+	fd_Params_chain_config                protoreflect.FieldDescriptor
+	fd_Params_base_fee_change_denominator protoreflect.FieldDescriptor
+	fd_Params_elasticity_multiplier       protoreflect.FieldDescriptor
+	fd_Params_min_base_fee                protoreflect.FieldDescriptor
+	fd_Params_base_fee_recipient          protoreflect.FieldDescriptor
+	fd_Params_precompile_allowlist protoreflect.FieldDescriptor
+	fd_Params_precompile_denylist protoreflect.FieldDescriptor
 )
 
 func init() {
@@ -72,6 +170,12 @@ func init() {
 	fd_Params_evm_denom = md_Params.Fields().ByName("evm_denom")
 	fd_Params_extra_eips = md_Params.Fields().ByName("extra_eips")
 	fd_Params_chain_config = md_Params.Fields().ByName("chain_config")
+	fd_Params_base_fee_change_denominator = md_Params.Fields().ByName("base_fee_change_denominator")
+	fd_Params_elasticity_multiplier = md_Params.Fields().ByName("elasticity_multiplier")
+	fd_Params_min_base_fee = md_Params.Fields().ByName("min_base_fee")
+	fd_Params_base_fee_recipient = md_Params.Fields().ByName("base_fee_recipient")
+	fd_Params_precompile_allowlist = md_Params.Fields().ByName("precompile_allowlist")
+	fd_Params_precompile_denylist = md_Params.Fields().ByName("precompile_denylist")
 }
 
 var _ protoreflect.Message = (*fastReflection_Params)(nil)
@@ -157,6 +261,42 @@ func (x *fastReflection_Params) Range(f func(protoreflect.FieldDescriptor, proto
 			return
 		}
 	}
+	if x.BaseFeeChangeDenominator != uint64(0) {
+		value := protoreflect.ValueOfUint64(x.BaseFeeChangeDenominator)
+		if !f(fd_Params_base_fee_change_denominator, value) {
+			return
+		}
+	}
+	if x.ElasticityMultiplier != uint64(0) {
+		value := protoreflect.ValueOfUint64(x.ElasticityMultiplier)
+		if !f(fd_Params_elasticity_multiplier, value) {
+			return
+		}
+	}
+	if x.MinBaseFee != uint64(0) {
+		value := protoreflect.ValueOfUint64(x.MinBaseFee)
+		if !f(fd_Params_min_base_fee, value) {
+			return
+		}
+	}
+	if x.BaseFeeRecipient != "" {
+		value := protoreflect.ValueOfString(x.BaseFeeRecipient)
+		if !f(fd_Params_base_fee_recipient, value) {
+			return
+		}
+	}
+	if len(x.PrecompileAllowlist) != 0 {
+		value := protoreflect.ValueOfList(&_Params_8_list{list: &x.PrecompileAllowlist})
+		if !f(fd_Params_precompile_allowlist, value) {
+			return
+		}
+	}
+	if len(x.PrecompileDenylist) != 0 {
+		value := protoreflect.ValueOfList(&_Params_9_list{list: &x.PrecompileDenylist})
+		if !f(fd_Params_precompile_denylist, value) {
+			return
+		}
+	}
 }
 
 // Has reports whether a field is populated.
@@ -178,6 +318,18 @@ func (x *fastReflection_Params) Has(fd protoreflect.FieldDescriptor) bool {
 		return len(x.ExtraEips) != 0
 	case "polaris.evm.v1alpha1.Params.chain_config":
 		return x.ChainConfig != ""
+	case "polaris.evm.v1alpha1.Params.base_fee_change_denominator":
+		return x.BaseFeeChangeDenominator != uint64(0)
+	case "polaris.evm.v1alpha1.Params.elasticity_multiplier":
+		return x.ElasticityMultiplier != uint64(0)
+	case "polaris.evm.v1alpha1.Params.min_base_fee":
+		return x.MinBaseFee != uint64(0)
+	case "polaris.evm.v1alpha1.Params.base_fee_recipient":
+		return x.BaseFeeRecipient != ""
+	case "polaris.evm.v1alpha1.Params.precompile_allowlist":
+		return len(x.PrecompileAllowlist) != 0
+	case "polaris.evm.v1alpha1.Params.precompile_denylist":
+		return len(x.PrecompileDenylist) != 0
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: polaris.evm.v1alpha1.Params"))
@@ -200,6 +352,18 @@ func (x *fastReflection_Params) Clear(fd protoreflect.FieldDescriptor) {
 		x.ExtraEips = nil
 	case "polaris.evm.v1alpha1.Params.chain_config":
 		x.ChainConfig = ""
+	case "polaris.evm.v1alpha1.Params.base_fee_change_denominator":
+		x.BaseFeeChangeDenominator = uint64(0)
+	case "polaris.evm.v1alpha1.Params.elasticity_multiplier":
+		x.ElasticityMultiplier = uint64(0)
+	case "polaris.evm.v1alpha1.Params.min_base_fee":
+		x.MinBaseFee = uint64(0)
+	case "polaris.evm.v1alpha1.Params.base_fee_recipient":
+		x.BaseFeeRecipient = ""
+	case "polaris.evm.v1alpha1.Params.precompile_allowlist":
+		x.PrecompileAllowlist = nil
+	case "polaris.evm.v1alpha1.Params.precompile_denylist":
+		x.PrecompileDenylist = nil
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: polaris.evm.v1alpha1.Params"))
@@ -228,6 +392,30 @@ func (x *fastReflection_Params) Get(descriptor protoreflect.FieldDescriptor) pro
 	case "polaris.evm.v1alpha1.Params.chain_config":
 		value := x.ChainConfig
 		return protoreflect.ValueOfString(value)
+	case "polaris.evm.v1alpha1.Params.base_fee_change_denominator":
+		value := x.BaseFeeChangeDenominator
+		return protoreflect.ValueOfUint64(value)
+	case "polaris.evm.v1alpha1.Params.elasticity_multiplier":
+		value := x.ElasticityMultiplier
+		return protoreflect.ValueOfUint64(value)
+	case "polaris.evm.v1alpha1.Params.min_base_fee":
+		value := x.MinBaseFee
+		return protoreflect.ValueOfUint64(value)
+	case "polaris.evm.v1alpha1.Params.base_fee_recipient":
+		value := x.BaseFeeRecipient
+		return protoreflect.ValueOfString(value)
+	case "polaris.evm.v1alpha1.Params.precompile_allowlist":
+		if len(x.PrecompileAllowlist) == 0 {
+			return protoreflect.ValueOfList(&_Params_8_list{})
+		}
+		listValue := &_Params_8_list{list: &x.PrecompileAllowlist}
+		return protoreflect.ValueOfList(listValue)
+	case "polaris.evm.v1alpha1.Params.precompile_denylist":
+		if len(x.PrecompileDenylist) == 0 {
+			return protoreflect.ValueOfList(&_Params_9_list{})
+		}
+		listValue := &_Params_9_list{list: &x.PrecompileDenylist}
+		return protoreflect.ValueOfList(listValue)
 	default:
 		if descriptor.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: polaris.evm.v1alpha1.Params"))
@@ -256,6 +444,22 @@ func (x *fastReflection_Params) Set(fd protoreflect.FieldDescriptor, value proto
 		x.ExtraEips = *clv.list
 	case "polaris.evm.v1alpha1.Params.chain_config":
 		x.ChainConfig = value.Interface().(string)
+	case "polaris.evm.v1alpha1.Params.base_fee_change_denominator":
+		x.BaseFeeChangeDenominator = value.Uint()
+	case "polaris.evm.v1alpha1.Params.elasticity_multiplier":
+		x.ElasticityMultiplier = value.Uint()
+	case "polaris.evm.v1alpha1.Params.min_base_fee":
+		x.MinBaseFee = value.Uint()
+	case "polaris.evm.v1alpha1.Params.base_fee_recipient":
+		x.BaseFeeRecipient = value.Interface().(string)
+	case "polaris.evm.v1alpha1.Params.precompile_allowlist":
+		lv := value.List()
+		clv := lv.(*_Params_8_list)
+		x.PrecompileAllowlist = *clv.list
+	case "polaris.evm.v1alpha1.Params.precompile_denylist":
+		lv := value.List()
+		clv := lv.(*_Params_9_list)
+		x.PrecompileDenylist = *clv.list
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: polaris.evm.v1alpha1.Params"))
@@ -282,10 +486,30 @@ func (x *fastReflection_Params) Mutable(fd protoreflect.FieldDescriptor) protore
 		}
 		value := &_Params_2_list{list: &x.ExtraEips}
 		return protoreflect.ValueOfList(value)
+	case "polaris.evm.v1alpha1.Params.precompile_allowlist":
+		if x.PrecompileAllowlist == nil {
+			x.PrecompileAllowlist = []string{}
+		}
+		value := &_Params_8_list{list: &x.PrecompileAllowlist}
+		return protoreflect.ValueOfList(value)
+	case "polaris.evm.v1alpha1.Params.precompile_denylist":
+		if x.PrecompileDenylist == nil {
+			x.PrecompileDenylist = []string{}
+		}
+		value := &_Params_9_list{list: &x.PrecompileDenylist}
+		return protoreflect.ValueOfList(value)
 	case "polaris.evm.v1alpha1.Params.evm_denom":
 		panic(fmt.Errorf("field evm_denom of message polaris.evm.v1alpha1.Params is not mutable"))
 	case "polaris.evm.v1alpha1.Params.chain_config":
 		panic(fmt.Errorf("field chain_config of message polaris.evm.v1alpha1.Params is not mutable"))
+	case "polaris.evm.v1alpha1.Params.base_fee_change_denominator":
+		panic(fmt.Errorf("field base_fee_change_denominator of message polaris.evm.v1alpha1.Params is not mutable"))
+	case "polaris.evm.v1alpha1.Params.elasticity_multiplier":
+		panic(fmt.Errorf("field elasticity_multiplier of message polaris.evm.v1alpha1.Params is not mutable"))
+	case "polaris.evm.v1alpha1.Params.min_base_fee":
+		panic(fmt.Errorf("field min_base_fee of message polaris.evm.v1alpha1.Params is not mutable"))
+	case "polaris.evm.v1alpha1.Params.base_fee_recipient":
+		panic(fmt.Errorf("field base_fee_recipient of message polaris.evm.v1alpha1.Params is not mutable"))
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: polaris.evm.v1alpha1.Params"))
@@ -306,6 +530,20 @@ func (x *fastReflection_Params) NewField(fd protoreflect.FieldDescriptor) protor
 		return protoreflect.ValueOfList(&_Params_2_list{list: &list})
 	case "polaris.evm.v1alpha1.Params.chain_config":
 		return protoreflect.ValueOfString("")
+	case "polaris.evm.v1alpha1.Params.base_fee_change_denominator":
+		return protoreflect.ValueOfUint64(uint64(0))
+	case "polaris.evm.v1alpha1.Params.elasticity_multiplier":
+		return protoreflect.ValueOfUint64(uint64(0))
+	case "polaris.evm.v1alpha1.Params.min_base_fee":
+		return protoreflect.ValueOfUint64(uint64(0))
+	case "polaris.evm.v1alpha1.Params.base_fee_recipient":
+		return protoreflect.ValueOfString("")
+	case "polaris.evm.v1alpha1.Params.precompile_allowlist":
+		list := []string{}
+		return protoreflect.ValueOfList(&_Params_8_list{list: &list})
+	case "polaris.evm.v1alpha1.Params.precompile_denylist":
+		list := []string{}
+		return protoreflect.ValueOfList(&_Params_9_list{list: &list})
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: polaris.evm.v1alpha1.Params"))
@@ -390,6 +628,31 @@ func (x *fastReflection_Params) ProtoMethods() *protoiface.Methods {
 		if l > 0 {
 			n += 1 + l + runtime.Sov(uint64(l))
 		}
+		if x.BaseFeeChangeDenominator != 0 {
+			n += 1 + runtime.Sov(uint64(x.BaseFeeChangeDenominator))
+		}
+		if x.ElasticityMultiplier != 0 {
+			n += 1 + runtime.Sov(uint64(x.ElasticityMultiplier))
+		}
+		if x.MinBaseFee != 0 {
+			n += 1 + runtime.Sov(uint64(x.MinBaseFee))
+		}
+		l = len(x.BaseFeeRecipient)
+		if l > 0 {
+			n += 1 + l + runtime.Sov(uint64(l))
+		}
+		if len(x.PrecompileAllowlist) > 0 {
+			for _, s := range x.PrecompileAllowlist {
+				l = len(s)
+				n += 1 + l + runtime.Sov(uint64(l))
+			}
+		}
+		if len(x.PrecompileDenylist) > 0 {
+			for _, s := range x.PrecompileDenylist {
+				l = len(s)
+				n += 1 + l + runtime.Sov(uint64(l))
+			}
+		}
 		if x.unknownFields != nil {
 			n += len(x.unknownFields)
 		}
@@ -419,6 +682,46 @@ func (x *fastReflection_Params) ProtoMethods() *protoiface.Methods {
 			i -= len(x.unknownFields)
 			copy(dAtA[i:], x.unknownFields)
 		}
+		if len(x.PrecompileDenylist) > 0 {
+			for iNdEx := len(x.PrecompileDenylist) - 1; iNdEx >= 0; iNdEx-- {
+				i -= len(x.PrecompileDenylist[iNdEx])
+				copy(dAtA[i:], x.PrecompileDenylist[iNdEx])
+				i = runtime.EncodeVarint(dAtA, i, uint64(len(x.PrecompileDenylist[iNdEx])))
+				i--
+				dAtA[i] = 0x4a
+			}
+		}
+		if len(x.PrecompileAllowlist) > 0 {
+			for iNdEx := len(x.PrecompileAllowlist) - 1; iNdEx >= 0; iNdEx-- {
+				i -= len(x.PrecompileAllowlist[iNdEx])
+				copy(dAtA[i:], x.PrecompileAllowlist[iNdEx])
+				i = runtime.EncodeVarint(dAtA, i, uint64(len(x.PrecompileAllowlist[iNdEx])))
+				i--
+				dAtA[i] = 0x42
+			}
+		}
+		if len(x.BaseFeeRecipient) > 0 {
+			i -= len(x.BaseFeeRecipient)
+			copy(dAtA[i:], x.BaseFeeRecipient)
+			i = runtime.EncodeVarint(dAtA, i, uint64(len(x.BaseFeeRecipient)))
+			i--
+			dAtA[i] = 0x3a
+		}
+		if x.MinBaseFee != 0 {
+			i = runtime.EncodeVarint(dAtA, i, uint64(x.MinBaseFee))
+			i--
+			dAtA[i] = 0x30
+		}
+		if x.ElasticityMultiplier != 0 {
+			i = runtime.EncodeVarint(dAtA, i, uint64(x.ElasticityMultiplier))
+			i--
+			dAtA[i] = 0x28
+		}
+		if x.BaseFeeChangeDenominator != 0 {
+			i = runtime.EncodeVarint(dAtA, i, uint64(x.BaseFeeChangeDenominator))
+			i--
+			dAtA[i] = 0x20
+		}
 		if len(x.ChainConfig) > 0 {
 			i -= len(x.ChainConfig)
 			copy(dAtA[i:], x.ChainConfig)
@@ -643,6 +946,159 @@ func (x *fastReflection_Params) ProtoMethods() *protoiface.Methods {
 				}
 				x.ChainConfig = string(dAtA[iNdEx:postIndex])
 				iNdEx = postIndex
+			case 4:
+				if wireType != 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field BaseFeeChangeDenominator", wireType)
+				}
+				x.BaseFeeChangeDenominator = 0
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					x.BaseFeeChangeDenominator |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+			case 5:
+				if wireType != 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field ElasticityMultiplier", wireType)
+				}
+				x.ElasticityMultiplier = 0
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					x.ElasticityMultiplier |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+			case 6:
+				if wireType != 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field MinBaseFee", wireType)
+				}
+				x.MinBaseFee = 0
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					x.MinBaseFee |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+			case 7:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field BaseFeeRecipient", wireType)
+				}
+				var stringLen uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLen |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLen := int(stringLen)
+				if intStringLen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + intStringLen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.BaseFeeRecipient = string(dAtA[iNdEx:postIndex])
+				iNdEx = postIndex
+			case 8:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field PrecompileAllowlist", wireType)
+				}
+				var stringLen uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLen |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLen := int(stringLen)
+				if intStringLen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + intStringLen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.PrecompileAllowlist = append(x.PrecompileAllowlist, string(dAtA[iNdEx:postIndex]))
+				iNdEx = postIndex
+			case 9:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field PrecompileDenylist", wireType)
+				}
+				var stringLen uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLen |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLen := int(stringLen)
+				if intStringLen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + intStringLen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.PrecompileDenylist = append(x.PrecompileDenylist, string(dAtA[iNdEx:postIndex]))
+				iNdEx = postIndex
 			default:
 				iNdEx = preIndex
 				skippy, err := runtime.Skip(dAtA[iNdEx:])
@@ -725,6 +1181,25 @@ type Params struct {
 	// `chain_config` represents the ethereum chain config for the polaris
 	// EVM
 	ChainConfig string `protobuf:"bytes,3,opt,name=chain_config,json=chainConfig,proto3" json:"chain_config,omitempty"`
+	// `base_fee_change_denominator` bounds the amount the base fee can change
+	// between blocks, as defined by EIP-1559.
+	BaseFeeChangeDenominator uint64 `protobuf:"varint,4,opt,name=base_fee_change_denominator,json=baseFeeChangeDenominator,proto3" json:"base_fee_change_denominator,omitempty"`
+	// `elasticity_multiplier` bounds the maximum gas limit a block may have
+	// relative to its gas target, as defined by EIP-1559.
+	ElasticityMultiplier uint64 `protobuf:"varint,5,opt,name=elasticity_multiplier,json=elasticityMultiplier,proto3" json:"elasticity_multiplier,omitempty"`
+	// `min_base_fee` is the minimum base fee (in wei) of a block.
+	MinBaseFee uint64 `protobuf:"varint,6,opt,name=min_base_fee,json=minBaseFee,proto3" json:"min_base_fee,omitempty"`
+	// `base_fee_recipient` is the name of the module account that receives the
+	// base fee portion of transaction fees. If empty, the base fee is burned.
+	BaseFeeRecipient string `protobuf:"bytes,7,opt,name=base_fee_recipient,json=baseFeeRecipient,proto3" json:"base_fee_recipient,omitempty"`
+	// `precompile_allowlist` restricts the callers of precompile methods. Each
+	// rule is formatted as `<precompile>[:<method selector>][@<caller>]`. Once a
+	// precompile method is matched by a rule, it may only be called by the
+	// callers that are allowed by the rules that match it.
+	PrecompileAllowlist []string `protobuf:"bytes,8,rep,name=precompile_allowlist,json=precompileAllowlist,proto3" json:"precompile_allowlist,omitempty"`
+	// `precompile_denylist` denies calls to precompile methods. It uses the same
+	// rule format as `precompile_allowlist`, and takes precedence over it.
+	PrecompileDenylist []string `protobuf:"bytes,9,rep,name=precompile_denylist,json=precompileDenylist,proto3" json:"precompile_denylist,omitempty"`
 }
 
 func (x *Params) Reset() {
@@ -768,6 +1243,48 @@ func (x *Params) GetChainConfig() string {
 	return ""
 }
 
+func (x *Params) GetBaseFeeChangeDenominator() uint64 {
+	if x != nil {
+		return x.BaseFeeChangeDenominator
+	}
+	return 0
+}
+
+func (x *Params) GetElasticityMultiplier() uint64 {
+	if x != nil {
+		return x.ElasticityMultiplier
+	}
+	return 0
+}
+
+func (x *Params) GetMinBaseFee() uint64 {
+	if x != nil {
+		return x.MinBaseFee
+	}
+	return 0
+}
+
+func (x *Params) GetBaseFeeRecipient() string {
+	if x != nil {
+		return x.BaseFeeRecipient
+	}
+	return ""
+}
+
+func (x *Params) GetPrecompileAllowlist() []string {
+	if x != nil {
+		return x.PrecompileAllowlist
+	}
+	return nil
+}
+
+func (x *Params) GetPrecompileDenylist() []string {
+	if x != nil {
+		return x.PrecompileDenylist
+	}
+	return nil
+}
+
 var File_polaris_evm_v1alpha1_params_proto protoreflect.FileDescriptor
 
 var file_polaris_evm_v1alpha1_params_proto_rawDesc = []byte{
@@ -776,7 +1293,7 @@ var file_polaris_evm_v1alpha1_params_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x12, 0x14, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x65, 0x76, 0x6d,
 	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x14, 0x67, 0x6f, 0x67, 0x6f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x67, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xba, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x65, 0x76,
+	0xa5, 0x05, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x65, 0x76,
 	0x6d, 0x5f, 0x64, 0x65, 0x6e, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x14, 0xf2,
 	0xde, 0x1f, 0x10, 0x79, 0x61, 0x6d, 0x6c, 0x3a, 0x22, 0x65, 0x76, 0x6d, 0x5f, 0x64, 0x65, 0x6e,
 	0x6f, 0x6d, 0x22, 0x52, 0x08, 0x65, 0x76, 0x6d, 0x44, 0x65, 0x6e, 0x6f, 0x6d, 0x12, 0x41, 0x0a,
@@ -787,21 +1304,51 @@ var file_polaris_evm_v1alpha1_params_proto_rawDesc = []byte{
 	0x12, 0x3a, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x17, 0xf2, 0xde, 0x1f, 0x13, 0x79, 0x61, 0x6d, 0x6c,
 	0x3a, 0x22, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x52,
-	0x0b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0xcc, 0x01, 0x0a,
-	0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x65, 0x76, 0x6d,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x42, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x31, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73,
-	0x73, 0x64, 0x6b, 0x2e, 0x69, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72,
-	0x69, 0x73, 0x2f, 0x65, 0x76, 0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b,
-	0x65, 0x76, 0x6d, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x50, 0x45,
-	0x58, 0xaa, 0x02, 0x14, 0x50, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x45, 0x76, 0x6d, 0x2e,
-	0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x14, 0x50, 0x6f, 0x6c, 0x61, 0x72,
-	0x69, 0x73, 0x5c, 0x45, 0x76, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2,
-	0x02, 0x20, 0x50, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x5c, 0x45, 0x76, 0x6d, 0x5c, 0x56, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0xea, 0x02, 0x16, 0x50, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x3a, 0x3a, 0x45, 0x76,
-	0x6d, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x0b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x65, 0x0a, 0x1b,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x64, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x42, 0x26, 0xf2, 0xde, 0x1f, 0x22, 0x79, 0x61, 0x6d, 0x6c, 0x3a, 0x22, 0x62, 0x61, 0x73,
+	0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x6e,
+	0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x52, 0x18, 0x62, 0x61, 0x73, 0x65, 0x46,
+	0x65, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x55, 0x0a, 0x15, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x69, 0x63, 0x69, 0x74,
+	0x79, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x42, 0x20, 0xf2, 0xde, 0x1f, 0x1c, 0x79, 0x61, 0x6d, 0x6c, 0x3a, 0x22, 0x65, 0x6c,
+	0x61, 0x73, 0x74, 0x69, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c,
+	0x69, 0x65, 0x72, 0x22, 0x52, 0x14, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x69, 0x63, 0x69, 0x74, 0x79,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x0c, 0x6d, 0x69,
+	0x6e, 0x5f, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x42, 0x17, 0xf2, 0xde, 0x1f, 0x13, 0x79, 0x61, 0x6d, 0x6c, 0x3a, 0x22, 0x6d, 0x69, 0x6e, 0x5f,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x22, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x42, 0x61,
+	0x73, 0x65, 0x46, 0x65, 0x65, 0x12, 0x4b, 0x0a, 0x12, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x66, 0x65,
+	0x65, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x1d, 0xf2, 0xde, 0x1f, 0x19, 0x79, 0x61, 0x6d, 0x6c, 0x3a, 0x22, 0x62, 0x61, 0x73,
+	0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x22,
+	0x52, 0x10, 0x62, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x12, 0x52, 0x0a, 0x14, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65,
+	0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
+	0x42, 0x1f, 0xf2, 0xde, 0x1f, 0x1b, 0x79, 0x61, 0x6d, 0x6c, 0x3a, 0x22, 0x70, 0x72, 0x65, 0x63,
+	0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74,
+	0x22, 0x52, 0x13, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x41, 0x6c, 0x6c,
+	0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x4f, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6d,
+	0x70, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x65, 0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x09, 0x42, 0x1e, 0xf2, 0xde, 0x1f, 0x1a, 0x79, 0x61, 0x6d, 0x6c, 0x3a, 0x22, 0x70,
+	0x72, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x65, 0x6e, 0x79, 0x6c, 0x69,
+	0x73, 0x74, 0x22, 0x52, 0x12, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x44,
+	0x65, 0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x42, 0xcc, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x2e,
+	0x70, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x65, 0x76, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x42, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x31, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x73, 0x64, 0x6b, 0x2e, 0x69,
+	0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2f, 0x65, 0x76,
+	0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x65, 0x76, 0x6d, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x50, 0x45, 0x58, 0xaa, 0x02, 0x14, 0x50,
+	0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x45, 0x76, 0x6d, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0xca, 0x02, 0x14, 0x50, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x5c, 0x45, 0x76,
+	0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x20, 0x50, 0x6f, 0x6c,
+	0x61, 0x72, 0x69, 0x73, 0x5c, 0x45, 0x76, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x16,
+	0x50, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x3a, 0x3a, 0x45, 0x76, 0x6d, 0x3a, 0x3a, 0x56, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
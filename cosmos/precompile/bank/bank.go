@@ -35,6 +35,10 @@ import (
 	"pkg.berachain.dev/polaris/lib/utils"
 )
 
+// returnGasPerWord is the gas charged per word of output by the methods that return all of the
+// balances of an account, as their output grows with the number of denoms.
+const returnGasPerWord = 3
+
 // Contract is the precompile contract for the bank module.
 type Contract struct {
 	ethprecompile.BaseContract
@@ -63,16 +67,18 @@ func (c *Contract) PrecompileMethods() ethprecompile.Methods {
 			Execute: c.GetBalance,
 		},
 		{
-			AbiSig:  "getAllBalances(address)",
-			Execute: c.GetAllBalances,
+			AbiSig:      "getAllBalances(address)",
+			Execute:     c.GetAllBalances,
+			ReturnGasFn: ethprecompile.GasPerWord(returnGasPerWord),
 		},
 		{
 			AbiSig:  "getSpendableBalance(address,string)",
 			Execute: c.GetSpendableBalanceByDenom,
 		},
 		{
-			AbiSig:  "getAllSpendableBalances(address)",
-			Execute: c.GetSpendableBalances,
+			AbiSig:      "getAllSpendableBalances(address)",
+			Execute:     c.GetSpendableBalances,
+			ReturnGasFn: ethprecompile.GasPerWord(returnGasPerWord),
 		},
 		{
 			AbiSig:  "getSupply(string)",
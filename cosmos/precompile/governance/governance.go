@@ -40,6 +40,10 @@ import (
 	"pkg.berachain.dev/polaris/lib/utils"
 )
 
+// returnGasPerWord is the gas charged per word of output by `getProposals`, as its output grows
+// with the number of proposals.
+const returnGasPerWord = 3
+
 // Contract is the precompile contract for the governance module.
 type Contract struct {
 	ethprecompile.BaseContract
@@ -85,8 +89,9 @@ func (c *Contract) PrecompileMethods() ethprecompile.Methods {
 			Execute: c.GetProposal,
 		},
 		{
-			AbiSig:  "getProposals(int32)",
-			Execute: c.GetProposals,
+			AbiSig:      "getProposals(int32)",
+			Execute:     c.GetProposals,
+			ReturnGasFn: ethprecompile.GasPerWord(returnGasPerWord),
 		},
 	}
 }
@@ -109,7 +109,8 @@ func NewPolarisApp( //nolint:funlen // as defined by the sdk.
 	var (
 		app          = &PolarisApp{}
 		appBuilder   *runtime.AppBuilder
-		ethTxMempool mempool.Mempool = evmmempool.NewEthTxPoolFrom(
+		ethTxMempool mempool.Mempool = evmmempool.NewEthTxPool(
+			evmmempool.DefaultConfig(),
 			mempool.NewPriorityMempool(mempool.DefaultPriorityNonceMempoolConfig()),
 		)
 		appConfig = depinject.Configs(
@@ -170,18 +171,23 @@ func NewPolarisApp( //nolint:funlen // as defined by the sdk.
 	// setup evm keeper and all of its plugins.
 	app.EVMKeeper.Setup(
 		offchainKey,
+		app.UnsafeFindStoreKey(authtypes.StoreKey),
 		app.CreateQueryContext,
+		app.Query,
 		// TODO: clean this up.
 		homePath+"/config/polaris.toml",
 		homePath+"/data/polaris",
 	)
 
-	opt := ante.HandlerOptions{
-		AccountKeeper:   app.AccountKeeper,
-		BankKeeper:      app.BankKeeper,
-		SignModeHandler: app.TxConfig().SignModeHandler(),
-		FeegrantKeeper:  app.FeeGrantKeeper,
-		SigGasConsumer:  evmante.SigVerificationGasConsumer,
+	opt := evmante.HandlerOptions{
+		HandlerOptions: ante.HandlerOptions{
+			AccountKeeper:   app.AccountKeeper,
+			BankKeeper:      app.BankKeeper,
+			SignModeHandler: app.TxConfig().SignModeHandler(),
+			FeegrantKeeper:  app.FeeGrantKeeper,
+			SigGasConsumer:  evmante.SigVerificationGasConsumer,
+		},
+		EthKeeper: app.EVMKeeper,
 	}
 	ch, _ := evmante.NewAnteHandler(
 		opt,
@@ -189,6 +195,21 @@ func NewPolarisApp( //nolint:funlen // as defined by the sdk.
 	app.SetAnteHandler(
 		ch,
 	)
+	// The private transactions do not go through CheckTx, so the transaction pool validates them
+	// with the ante handler itself.
+	app.EVMKeeper.SetAnteHandler(ch)
+
+	// The block proposal is built with the Polaris block builder, and the Ethereum transactions of
+	// the accepted proposals are executed in parallel if enabled.
+	app.SetPrepareProposal(
+		app.EVMKeeper.PrepareProposalHandler(ethTxMempool, app.TxConfig().TxEncoder()),
+	)
+	app.SetProcessProposal(
+		app.EVMKeeper.ProcessProposalHandler(
+			baseapp.NewDefaultProposalHandler(ethTxMempool, app.BaseApp).ProcessProposalHandler(),
+			app.TxConfig().TxDecoder(),
+		),
+	)
 
 	// We must register the EthSecp256k1 signature type because it is not registered by default.
 	// TODO: remove once upstreamed to the SDK.
@@ -245,3 +266,9 @@ func NewPolarisApp( //nolint:funlen // as defined by the sdk.
 func (app *PolarisApp) SimulationManager() *module.SimulationManager {
 	return app.sm
 }
+
+// Close stops the background services of the EVM keeper, and then closes the application.
+func (app *PolarisApp) Close() error {
+	app.EVMKeeper.Close()
+	return app.App.Close()
+}
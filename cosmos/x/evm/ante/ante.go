@@ -28,10 +28,21 @@ import (
 	"pkg.berachain.dev/polaris/lib/errors"
 )
 
+// HandlerOptions are the options of the ante handler.
+type HandlerOptions struct {
+	ante.HandlerOptions
+	// EthKeeper provides the chain state that Ethereum transactions are checked against.
+	EthKeeper EthKeeper
+	// MaxNonceGap is the maximum distance between the nonce of an Ethereum transaction that is
+	// checked for the mempool and the state nonce of its sender. It defaults to
+	// DefaultMaxNonceGap.
+	MaxNonceGap uint64
+}
+
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
-// signer.
-func NewAnteHandler(options ante.HandlerOptions) (sdk.AnteHandler, error) {
+// signer. Ethereum transactions are checked by a dedicated chain of decorators.
+func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 	if options.AccountKeeper == nil {
 		return nil, errors.Wrap(sdkerrors.ErrLogic, "account keeper is required for ante builder")
 	}
@@ -44,46 +55,52 @@ func NewAnteHandler(options ante.HandlerOptions) (sdk.AnteHandler, error) {
 		return nil, errors.Wrap(sdkerrors.ErrLogic, "sign mode handler is required for ante builder")
 	}
 
-	anteDecorators := []sdk.AnteDecorator{
+	if options.EthKeeper == nil {
+		return nil, errors.Wrap(sdkerrors.ErrLogic, "eth keeper is required for ante builder")
+	}
+
+	if options.MaxNonceGap == 0 {
+		options.MaxNonceGap = DefaultMaxNonceGap
+	}
+
+	cosmosHandler := sdk.ChainAnteDecorators(
 		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
 		ante.NewExtensionOptionsDecorator(options.ExtensionOptionChecker),
 		ante.NewValidateBasicDecorator(),
 		ante.NewTxTimeoutHeightDecorator(),
 		ante.NewValidateMemoDecorator(options.AccountKeeper),
-		// EthTransactions can skip consuming transaction gas as it will be done
-		// in the StateTransition.
-		EthSkipDecorator[ante.ConsumeTxSizeGasDecorator]{
-			ante.NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
-		},
-		// EthTransaction can skip deduct fee transactions as they are done in the
-		// StateTransition. // TODO: check to make sure this doesn't cause spam.
-		EthSkipDecorator[ante.DeductFeeDecorator]{
-			ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper,
-				options.FeegrantKeeper, options.TxFeeChecker),
-		},
+		ante.NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
+		ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper,
+			options.FeegrantKeeper, options.TxFeeChecker),
 		ante.NewSetPubKeyDecorator(options.AccountKeeper),
 		ante.NewValidateSigCountDecorator(options.AccountKeeper),
-		// In order to match ethereum gas consumption, we do not consume any gas when
-		// verifying the signature.
-		EthSkipDecorator[ante.SigGasConsumeDecorator]{
-			ante.NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer),
-		},
-		// EthTransaction can skip Signature Verification as we do this in the mempool.
-		// TODO: // check with Marko to make sure this is okay (ties into the one below)
-		EthSkipDecorator[ante.SigVerificationDecorator]{
-			ante.NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler),
-		},
-		// EthTransactions are allowed to skip sequence verification as we do this in the
-		// state transition.
-		// NOTE: we may need to change this as it could cause issues if a client is intertwining
-		// Ethreum and Cosmos transactions within a close timeframe.
-		// By skipping this for Eth Transactions, the Account Seq of the sender does not get updated
-		// in checkState during checkTx, but only in DeliverTx, since we are upping in nonce during the
-		// actual execution of the block and not during the ante handler.
-		// TODO: // check with Marko to make sure this is okay.
-		EthSkipDecorator[ante.IncrementSequenceDecorator]{
-			ante.NewIncrementSequenceDecorator(options.AccountKeeper),
-		},
-	}
-	return sdk.ChainAnteDecorators(anteDecorators...), nil
+		ante.NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer),
+		ante.NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler),
+		ante.NewIncrementSequenceDecorator(options.AccountKeeper),
+	)
+
+	// EthTransactions do not consume gas for their size or signature, and do not deduct fees or
+	// increment the sequence of the sender in the ante handler, as this is all done in the
+	// StateTransition. Instead, they are checked against the chain state with the same rules as
+	// the StateTransition, so that invalid transactions never reach the mempool.
+	ethHandler := sdk.ChainAnteDecorators(
+		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
+		ante.NewExtensionOptionsDecorator(options.ExtensionOptionChecker),
+		ante.NewValidateBasicDecorator(),
+		ante.NewTxTimeoutHeightDecorator(),
+		ante.NewValidateMemoDecorator(options.AccountKeeper),
+		ante.NewSetPubKeyDecorator(options.AccountKeeper),
+		ante.NewValidateSigCountDecorator(options.AccountKeeper),
+		NewEthSigVerificationDecorator(options.EthKeeper),
+		NewEthTxFeeCapDecorator(options.EthKeeper),
+		NewEthGasDecorator(options.EthKeeper),
+		NewEthAccountDecorator(options.EthKeeper, options.MaxNonceGap),
+	)
+
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		if isEthTx(tx) {
+			return ethHandler(ctx, tx, simulate)
+		}
+		return cosmosHandler(ctx, tx, simulate)
+	}, nil
 }
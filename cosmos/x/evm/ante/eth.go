@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package ante
+
+import (
+	"errors"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/params"
+	errorslib "pkg.berachain.dev/polaris/lib/errors"
+	"pkg.berachain.dev/polaris/lib/utils"
+)
+
+// DefaultMaxNonceGap is the default maximum distance between the nonce of a transaction that is
+// checked for the mempool and the state nonce of its sender.
+const DefaultMaxNonceGap = 1024
+
+var (
+	// ErrInvalidEthTx is returned if a transaction does not carry a valid Ethereum transaction.
+	ErrInvalidEthTx = errors.New("invalid ethereum transaction")
+	// ErrUnprotectedTx is returned if a transaction is not replay-protected as per EIP-155.
+	ErrUnprotectedTx = errors.New("only replay-protected (EIP-155) transactions allowed")
+	// ErrTxFeeCapExceeded is returned if the fee of a transaction exceeds the RPC tx fee cap.
+	ErrTxFeeCapExceeded = errors.New("tx fee exceeds the configured cap")
+)
+
+// EthKeeper defines the x/evm keeper methods that are required by the Ethereum ante decorators.
+type EthKeeper interface {
+	// ChainConfig returns the Ethereum chain config at the given context.
+	ChainConfig(sdk.Context) *params.ChainConfig
+	// BaseFee returns the base fee of the block built on top of the current chain head.
+	BaseFee(sdk.Context) *big.Int
+	// GetNonce returns the nonce of the given address at the given context.
+	GetNonce(sdk.Context, common.Address) uint64
+	// GetBalance returns the balance, in the EVM denom, of the given address at the given context.
+	GetBalance(sdk.Context, common.Address) *big.Int
+	// RPCTxFeeCap returns the cap, in ether, of the fee of the transactions that are checked for
+	// the mempool. A cap of 0 disables it.
+	RPCTxFeeCap() float64
+}
+
+// isEthTx returns whether the given transaction is an Ethereum transaction. This is safe since
+// EthTransactions are guaranteed to be the first and only message in a transaction.
+func isEthTx(tx sdk.Tx) bool {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return false
+	}
+	_, ok := utils.GetAs[*types.EthTransactionRequest](msgs[0])
+	return ok
+}
+
+// getEthTx returns the Ethereum transaction of the given transaction.
+func getEthTx(tx sdk.Tx) (*coretypes.Transaction, error) {
+	msgs := tx.GetMsgs()
+	if len(msgs) != 1 {
+		return nil, errorslib.Wrap(ErrInvalidEthTx, "expected exactly one message")
+	}
+	etr, ok := utils.GetAs[*types.EthTransactionRequest](msgs[0])
+	if !ok {
+		return nil, ErrInvalidEthTx
+	}
+	ethTx := etr.AsTransaction()
+	if ethTx == nil {
+		return nil, errorslib.Wrap(ErrInvalidEthTx, "failed to decode")
+	}
+	return ethTx, nil
+}
+
+// ethTxAndSender returns the Ethereum transaction of the given transaction, and its sender as
+// recovered from the signature with the signer of the current block.
+func ethTxAndSender(
+	ctx sdk.Context, ek EthKeeper, tx sdk.Tx,
+) (*coretypes.Transaction, common.Address, error) {
+	ethTx, err := getEthTx(tx)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	signer := coretypes.MakeSigner(ek.ChainConfig(ctx), big.NewInt(ctx.BlockHeight()))
+	sender, err := signer.Sender(ethTx)
+	if err != nil {
+		return nil, common.Address{}, errorslib.Wrap(coretypes.ErrInvalidSig, err.Error())
+	}
+	return ethTx, sender, nil
+}
+
+// EthSigVerificationDecorator recovers the sender of an Ethereum transaction from its signature,
+// and checks that the transaction is replay-protected for the chain ID of the chain.
+type EthSigVerificationDecorator struct {
+	ek EthKeeper
+}
+
+// NewEthSigVerificationDecorator returns a new EthSigVerificationDecorator.
+func NewEthSigVerificationDecorator(ek EthKeeper) EthSigVerificationDecorator {
+	return EthSigVerificationDecorator{ek}
+}
+
+// AnteHandle implements the sdk.AnteDecorator interface.
+func (svd EthSigVerificationDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	ethTx, err := getEthTx(tx)
+	if err != nil {
+		return ctx, err
+	}
+	if !ethTx.Protected() {
+		return ctx, ErrUnprotectedTx
+	}
+	if chainID := svd.ek.ChainConfig(ctx).ChainID; ethTx.ChainId().Cmp(chainID) != 0 {
+		return ctx, errorslib.Wrapf(
+			coretypes.ErrInvalidChainID, "have %s, want %s", ethTx.ChainId(), chainID,
+		)
+	}
+	if _, _, err = ethTxAndSender(ctx, svd.ek, tx); err != nil {
+		return ctx, err
+	}
+	return next(ctx, tx, simulate)
+}
+
+// EthGasDecorator checks that an Ethereum transaction pays for its intrinsic gas, and that its fee
+// cap covers the base fee of the next block.
+type EthGasDecorator struct {
+	ek EthKeeper
+}
+
+// NewEthGasDecorator returns a new EthGasDecorator.
+func NewEthGasDecorator(ek EthKeeper) EthGasDecorator {
+	return EthGasDecorator{ek}
+}
+
+// AnteHandle implements the sdk.AnteDecorator interface.
+func (gd EthGasDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	ethTx, err := getEthTx(tx)
+	if err != nil {
+		return ctx, err
+	}
+
+	rules := gd.ek.ChainConfig(ctx).Rules(
+		big.NewInt(ctx.BlockHeight()), true, uint64(ctx.BlockTime().Unix()),
+	)
+	intrinsicGas, err := core.IntrinsicGas(
+		ethTx.Data(), ethTx.AccessList(), ethTx.To() == nil,
+		rules.IsHomestead, rules.IsIstanbul, rules.IsShanghai,
+	)
+	if err != nil {
+		return ctx, err
+	}
+	if ethTx.Gas() < intrinsicGas {
+		return ctx, errorslib.Wrapf(
+			core.ErrIntrinsicGas, "have %d, want %d", ethTx.Gas(), intrinsicGas,
+		)
+	}
+
+	if baseFee := gd.ek.BaseFee(ctx); baseFee != nil && ethTx.GasFeeCap().Cmp(baseFee) < 0 {
+		return ctx, errorslib.Wrapf(
+			core.ErrFeeCapTooLow, "maxFeePerGas: %s baseFee: %s", ethTx.GasFeeCap(), baseFee,
+		)
+	}
+	return next(ctx, tx, simulate)
+}
+
+// EthAccountDecorator checks the nonce of an Ethereum transaction against the state nonce of its
+// sender, and that the sender can afford the cost of the transaction. The nonce of a transaction
+// may be ahead of the state nonce, by at most the max nonce gap, as the state nonce is only
+// incremented when the transaction is executed. The checks are only run when checking
+// transactions for the mempool, as the StateTransition runs them again on execution.
+type EthAccountDecorator struct {
+	ek          EthKeeper
+	maxNonceGap uint64
+}
+
+// NewEthAccountDecorator returns a new EthAccountDecorator.
+func NewEthAccountDecorator(ek EthKeeper, maxNonceGap uint64) EthAccountDecorator {
+	return EthAccountDecorator{ek, maxNonceGap}
+}
+
+// AnteHandle implements the sdk.AnteDecorator interface.
+func (ad EthAccountDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	if !ctx.IsCheckTx() && !ctx.IsReCheckTx() {
+		return next(ctx, tx, simulate)
+	}
+
+	ethTx, sender, err := ethTxAndSender(ctx, ad.ek, tx)
+	if err != nil {
+		return ctx, err
+	}
+
+	nonce := ad.ek.GetNonce(ctx, sender)
+	if ethTx.Nonce() < nonce {
+		return ctx, errorslib.Wrapf(
+			core.ErrNonceTooLow, "address %s, tx: %d state: %d", sender, ethTx.Nonce(), nonce,
+		)
+	}
+	if ethTx.Nonce() >= nonce+ad.maxNonceGap {
+		return ctx, errorslib.Wrapf(
+			core.ErrNonceTooHigh, "address %s, tx: %d state: %d", sender, ethTx.Nonce(), nonce,
+		)
+	}
+
+	if balance := ad.ek.GetBalance(ctx, sender); balance.Cmp(ethTx.Cost()) < 0 {
+		return ctx, errorslib.Wrapf(
+			core.ErrInsufficientFunds, "address %s have %s want %s", sender, balance, ethTx.Cost(),
+		)
+	}
+	return next(ctx, tx, simulate)
+}
+
+// EthTxFeeCapDecorator rejects Ethereum transactions whose fee exceeds the RPC tx fee cap. As the
+// cap is a node-local setting, it is only enforced when checking transactions for the mempool.
+type EthTxFeeCapDecorator struct {
+	ek EthKeeper
+}
+
+// NewEthTxFeeCapDecorator returns a new EthTxFeeCapDecorator.
+func NewEthTxFeeCapDecorator(ek EthKeeper) EthTxFeeCapDecorator {
+	return EthTxFeeCapDecorator{ek}
+}
+
+// AnteHandle implements the sdk.AnteDecorator interface.
+func (fcd EthTxFeeCapDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	feeCap := fcd.ek.RPCTxFeeCap()
+	if !ctx.IsCheckTx() || feeCap == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	ethTx, err := getEthTx(tx)
+	if err != nil {
+		return ctx, err
+	}
+	fee := new(big.Int).Mul(ethTx.GasFeeCap(), new(big.Int).SetUint64(ethTx.Gas()))
+	feeEth, _ := new(big.Float).Quo(new(big.Float).SetInt(fee), big.NewFloat(params.Ether)).
+		Float64()
+	if feeEth > feeCap {
+		return ctx, errorslib.Wrapf(
+			ErrTxFeeCapExceeded, "tx fee (%.2f ether) exceeds the cap (%.2f ether)", feeEth, feeCap,
+		)
+	}
+	return next(ctx, tx, simulate)
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package ante_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+
+	testutil "pkg.berachain.dev/polaris/cosmos/testing/utils"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/ante"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Eth ante decorators", func() {
+	var (
+		key, _  = crypto.GenerateEthKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		signer  = coretypes.LatestSignerForChainID(params.DefaultChainConfig.ChainID)
+
+		ctx sdk.Context
+		ek  *mockEthKeeper
+	)
+
+	// newTxWithSigner signs the given Ethereum transaction with the given signer, and wraps it in
+	// a Cosmos transaction.
+	newTxWithSigner := func(signer coretypes.Signer, data coretypes.TxData) sdk.Tx {
+		builder := moduletestutil.MakeTestEncodingConfig().TxConfig.NewTxBuilder()
+		Expect(builder.SetMsgs(
+			types.NewFromTransaction(coretypes.MustSignNewTx(key, signer, data)),
+		)).To(Succeed())
+		return builder.GetTx()
+	}
+
+	// newTx signs the given Ethereum transaction and wraps it in a Cosmos transaction.
+	newTx := func(data coretypes.TxData) sdk.Tx {
+		return newTxWithSigner(signer, data)
+	}
+
+	// dynamicFeeTx returns a valid dynamic fee transfer with the given nonce and fee cap.
+	dynamicFeeTx := func(nonce uint64, feeCap int64) *coretypes.DynamicFeeTx {
+		return &coretypes.DynamicFeeTx{
+			ChainID:   params.DefaultChainConfig.ChainID,
+			Nonce:     nonce,
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(feeCap),
+			To:        &common.Address{},
+			Value:     big.NewInt(1),
+		}
+	}
+
+	// handle runs the given decorator on the given transaction.
+	handle := func(decorator sdk.AnteDecorator, tx sdk.Tx) error {
+		_, err := decorator.AnteHandle(ctx, tx, false,
+			func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil },
+		)
+		return err
+	}
+
+	BeforeEach(func() {
+		ctx = testutil.NewContext().WithBlockHeight(1).WithIsCheckTx(true)
+		ek = &mockEthKeeper{
+			baseFee:  big.NewInt(10),
+			nonces:   map[common.Address]uint64{address: 5},
+			balances: map[common.Address]*big.Int{address: big.NewInt(1e18)},
+		}
+	})
+
+	Describe("EthSigVerificationDecorator", func() {
+		It("should accept replay-protected transactions", func() {
+			Expect(handle(ante.NewEthSigVerificationDecorator(ek), newTx(dynamicFeeTx(5, 10)))).
+				To(Succeed())
+		})
+
+		It("should reject transactions for another chain", func() {
+			data := dynamicFeeTx(5, 10)
+			data.ChainID = big.NewInt(1)
+			tx := newTxWithSigner(coretypes.LatestSignerForChainID(data.ChainID), data)
+			Expect(handle(ante.NewEthSigVerificationDecorator(ek), tx)).
+				To(MatchError(coretypes.ErrInvalidChainID))
+		})
+
+		It("should reject transactions that are not replay-protected", func() {
+			tx := newTxWithSigner(coretypes.HomesteadSigner{}, &coretypes.LegacyTx{
+				Nonce:    5,
+				Gas:      params.TxGas,
+				GasPrice: big.NewInt(10),
+				To:       &common.Address{},
+				Value:    big.NewInt(1),
+			})
+			Expect(handle(ante.NewEthSigVerificationDecorator(ek), tx)).
+				To(MatchError(ante.ErrUnprotectedTx))
+		})
+	})
+
+	Describe("EthGasDecorator", func() {
+		It("should check the intrinsic gas and the base fee", func() {
+			decorator := ante.NewEthGasDecorator(ek)
+			Expect(handle(decorator, newTx(dynamicFeeTx(5, 10)))).To(Succeed())
+
+			data := dynamicFeeTx(5, 10)
+			data.Gas = params.TxGas - 1
+			Expect(handle(decorator, newTx(data))).To(MatchError(core.ErrIntrinsicGas))
+			Expect(handle(decorator, newTx(dynamicFeeTx(5, 9)))).
+				To(MatchError(core.ErrFeeCapTooLow))
+		})
+	})
+
+	Describe("EthAccountDecorator", func() {
+		It("should check the nonce bounds and the balance", func() {
+			decorator := ante.NewEthAccountDecorator(ek, 2)
+			Expect(handle(decorator, newTx(dynamicFeeTx(5, 10)))).To(Succeed())
+			Expect(handle(decorator, newTx(dynamicFeeTx(6, 10)))).To(Succeed())
+			Expect(handle(decorator, newTx(dynamicFeeTx(4, 10)))).
+				To(MatchError(core.ErrNonceTooLow))
+			Expect(handle(decorator, newTx(dynamicFeeTx(7, 10)))).
+				To(MatchError(core.ErrNonceTooHigh))
+
+			ek.balances[address] = big.NewInt(1)
+			Expect(handle(decorator, newTx(dynamicFeeTx(5, 10)))).
+				To(MatchError(core.ErrInsufficientFunds))
+		})
+
+		It("should only run the checks when checking txs", func() {
+			decorator := ante.NewEthAccountDecorator(ek, 2)
+			ek.balances[address] = big.NewInt(1)
+			ctx = ctx.WithIsCheckTx(false)
+			Expect(handle(decorator, newTx(dynamicFeeTx(4, 10)))).To(Succeed())
+			Expect(handle(decorator, newTx(dynamicFeeTx(7, 10)))).To(Succeed())
+
+			ctx = ctx.WithIsReCheckTx(true)
+			Expect(handle(decorator, newTx(dynamicFeeTx(5, 10)))).
+				To(MatchError(core.ErrInsufficientFunds))
+		})
+	})
+
+	Describe("EthTxFeeCapDecorator", func() {
+		It("should only reject transactions over the fee cap when checking txs", func() {
+			decorator := ante.NewEthTxFeeCapDecorator(ek)
+			tx := newTx(dynamicFeeTx(5, 1e14)) // 2.1 ether
+			Expect(handle(decorator, tx)).To(Succeed())
+
+			ek.feeCap = 1
+			Expect(handle(decorator, tx)).To(MatchError(ante.ErrTxFeeCapExceeded))
+
+			ctx = ctx.WithIsCheckTx(false)
+			Expect(handle(decorator, tx)).To(Succeed())
+		})
+	})
+})
+
+// mockEthKeeper is an in-memory `ante.EthKeeper`.
+type mockEthKeeper struct {
+	baseFee  *big.Int
+	feeCap   float64
+	nonces   map[common.Address]uint64
+	balances map[common.Address]*big.Int
+}
+
+func (m *mockEthKeeper) ChainConfig(sdk.Context) *params.ChainConfig {
+	return params.DefaultChainConfig
+}
+
+func (m *mockEthKeeper) BaseFee(sdk.Context) *big.Int {
+	return m.baseFee
+}
+
+func (m *mockEthKeeper) GetNonce(_ sdk.Context, addr common.Address) uint64 {
+	return m.nonces[addr]
+}
+
+func (m *mockEthKeeper) GetBalance(_ sdk.Context, addr common.Address) *big.Int {
+	if balance, ok := m.balances[addr]; ok {
+		return balance
+	}
+	return new(big.Int)
+}
+
+func (m *mockEthKeeper) RPCTxFeeCap() float64 {
+	return m.feeCap
+}
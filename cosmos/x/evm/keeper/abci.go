@@ -23,7 +23,17 @@ package keeper
 import (
 	"context"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmempool "github.com/cosmos/cosmos-sdk/types/mempool"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/precompile"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/txpool"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	"pkg.berachain.dev/polaris/eth/common"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/lib/utils"
 )
 
 // BeginBlocker is called during the BeginBlock processing of the ABCI lifecycle.
@@ -31,6 +41,17 @@ func (k *Keeper) BeginBlocker(ctx context.Context) {
 	sCtx := sdk.UnwrapSDKContext(ctx)
 	// Prepare the Polaris Ethereum block.
 	k.polaris.Prepare(ctx, sCtx.BlockHeight())
+	// Rebuild the precompile access rules from the params of the block.
+	utils.MustGetAs[precompile.Plugin](k.host.GetPrecompilePlugin()).Prepare(ctx)
+
+	// Speculatively execute the Ethereum transactions of the accepted proposal of the block, if
+	// parallel execution is enabled. The transactions that are not in the block are ignored.
+	if k.proposedHeight == sCtx.BlockHeight() && len(k.proposedTxs) > 0 {
+		if err := k.polaris.SpeculateTransactions(ctx, k.proposedTxs); err != nil {
+			k.Logger(sCtx).Error("failed to speculate transactions", "err", err)
+		}
+	}
+	k.proposedHeight, k.proposedTxs = 0, nil
 }
 
 // Precommit is called during the Commit processing of the ABCI lifecycle, right before the state
@@ -40,4 +61,109 @@ func (k *Keeper) Precommit(ctx context.Context) {
 	if err := k.polaris.Finalize(ctx); err != nil {
 		panic(err)
 	}
+
+	// Reset the mempool on top of the new chain head, so that it drops the txs that were mined or
+	// can no longer be executed, and promotes the queued txs that can.
+	head, err := k.polaris.CurrentBlock()
+	if err != nil {
+		k.Logger(sdk.UnwrapSDKContext(ctx)).Error("failed to reset the mempool", "err", err)
+		return
+	}
+	k.host.GetTxPoolPlugin().(txpool.Plugin).Reset(
+		head, k.host.GetBlockPlugin().BaseFee(head.Header()),
+	)
+}
+
+// ProcessProposalHandler returns the handler that processes the block proposals with the given
+// handler. If parallel execution is enabled, the Ethereum transactions of the accepted proposal
+// are kept, so that they are speculatively executed once the block is prepared in BeginBlock.
+// Blocks that are not proposed to the node, such as the blocks that are synced, are executed
+// sequentially.
+func (k *Keeper) ProcessProposalHandler(
+	next sdk.ProcessProposalHandler, txDecoder sdk.TxDecoder,
+) sdk.ProcessProposalHandler {
+	return func(ctx sdk.Context, req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+		resp := next(ctx, req)
+		if resp.Status != abci.ResponseProcessProposal_ACCEPT ||
+			k.host.ParallelExecutionWorkers() <= 0 {
+			return resp
+		}
+
+		txs := make(coretypes.Transactions, 0, len(req.Txs))
+		for _, bz := range req.Txs {
+			tx, err := txDecoder(bz)
+			if err != nil {
+				continue
+			}
+			if msgs := tx.GetMsgs(); len(msgs) > 0 {
+				if etr, ok := utils.GetAs[*types.EthTransactionRequest](msgs[0]); ok {
+					txs = append(txs, etr.AsTransaction())
+				}
+			}
+		}
+		k.proposedHeight, k.proposedTxs = req.Height, txs
+		return resp
+	}
+}
+
+// PrepareProposalHandler returns the handler that builds the block proposal. The Ethereum
+// transactions of the proposal are selected from the mempool by the Polaris block builder,
+// followed by the other transactions of the mempool, until the max tx bytes are reached.
+func (k *Keeper) PrepareProposalHandler(
+	mp sdkmempool.Mempool, txEncoder sdk.TxEncoder,
+) sdk.PrepareProposalHandler {
+	return func(ctx sdk.Context, req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+		logger := k.Logger(ctx)
+		selected, rejected, err := k.polaris.SelectTransactions(ctx, req.Height)
+		if err != nil {
+			logger.Error("failed to select ethereum transactions", "err", err)
+		}
+		for _, r := range rejected {
+			logger.Debug("rejected ethereum transaction", "hash", r.Tx.Hash().Hex(), "err", r.Err)
+		}
+
+		// Split the mempool into the Ethereum transactions, by hash, and the other transactions.
+		ethTxs := make(map[common.Hash]sdk.Tx)
+		var otherTxs []sdk.Tx
+		for it := mp.Select(ctx, req.Txs); it != nil; it = it.Next() {
+			tx := it.Tx()
+			if msgs := tx.GetMsgs(); len(msgs) > 0 {
+				if etr, ok := utils.GetAs[*types.EthTransactionRequest](msgs[0]); ok {
+					ethTxs[etr.AsTransaction().Hash()] = tx
+					continue
+				}
+			}
+			otherTxs = append(otherTxs, tx)
+		}
+
+		txs := make([][]byte, 0, len(selected)+len(otherTxs))
+		var totalBytes int64
+		appendTx := func(tx sdk.Tx) bool {
+			bz, err := txEncoder(tx)
+			if err != nil {
+				logger.Error("failed to encode transaction", "err", err)
+				return true
+			}
+			if totalBytes+int64(len(bz)) > req.MaxTxBytes {
+				return false
+			}
+			totalBytes += int64(len(bz))
+			txs = append(txs, bz)
+			return true
+		}
+		for _, ethTx := range selected {
+			// Once the proposal is full, the remaining Ethereum txs are left out, as they are
+			// ordered by nonce for each sender.
+			if tx, ok := ethTxs[ethTx.Hash()]; ok && !appendTx(tx) {
+				break
+			}
+		}
+		for _, tx := range otherTxs {
+			if !appendTx(tx) {
+				break
+			}
+		}
+
+		return abci.ResponsePrepareProposal{Txs: txs}
+	}
 }
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/ante"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/block"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/configuration"
+	"pkg.berachain.dev/polaris/eth/common"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+// Compile-time interface assertion.
+var _ ante.EthKeeper = (*Keeper)(nil)
+
+// configurationPlugin returns a configuration plugin that reads the params of the x/evm module at
+// the given context. The plugins of the host are bound to the context of the block that is being
+// processed, so the ante handler, which also runs in CheckTx (e.g. before the first block after a
+// restart), reads the params through plugins of its own.
+func (k *Keeper) configurationPlugin(ctx sdk.Context) configuration.Plugin {
+	cp := configuration.NewPlugin(k.storeKey)
+	cp.Prepare(ctx)
+	return cp
+}
+
+// ChainConfig returns the Ethereum chain config at the given context.
+func (k *Keeper) ChainConfig(ctx sdk.Context) *params.ChainConfig {
+	return k.configurationPlugin(ctx).ChainConfig()
+}
+
+// BaseFee returns the base fee of the block built on top of the current chain head.
+func (k *Keeper) BaseFee(ctx sdk.Context) *big.Int {
+	var parent *coretypes.Header
+	if head, err := k.polaris.CurrentBlock(); err == nil {
+		parent = head.Header()
+	}
+	return block.NewPlugin(k.storeKey, k.configurationPlugin(ctx)).BaseFee(parent)
+}
+
+// GetNonce returns the nonce of the given address at the given context.
+func (k *Keeper) GetNonce(ctx sdk.Context, addr common.Address) uint64 {
+	acc := k.ak.GetAccount(ctx, addr[:])
+	if acc == nil {
+		return 0
+	}
+	return acc.GetSequence()
+}
+
+// GetBalance returns the balance, in the EVM denom, of the given address at the given context.
+func (k *Keeper) GetBalance(ctx sdk.Context, addr common.Address) *big.Int {
+	denom := k.configurationPlugin(ctx).GetEvmDenom()
+	return k.bk.GetBalance(ctx, addr[:], denom).Amount.BigInt()
+}
+
+// RPCTxFeeCap returns the cap, in ether, of the fee of the transactions that are checked for the
+// mempool.
+func (k *Keeper) RPCTxFeeCap() float64 {
+	return k.polaris.Config().RPCConfig.RPCTxFeeCap
+}
@@ -31,6 +31,10 @@ func (k *Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) error
 	// We configure the logger here because we want to get the logger off the context opposed to allocating a new one.
 	k.ConfigureGethLogger(ctx)
 
+	if err := genState.Params.ValidateBaseFeeRecipient(k.ak.GetModuleAddress); err != nil {
+		return err
+	}
+
 	// TODO: remove InitGenesis from the interfaces, do check and run instead
 	// Initialize all the plugins.
 	for _, plugin := range k.host.GetAllPlugins() {
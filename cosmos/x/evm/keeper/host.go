@@ -21,8 +21,12 @@
 package keeper
 
 import (
+	"github.com/spf13/cast"
+
 	storetypes "cosmossdk.io/store/types"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkmempool "github.com/cosmos/cosmos-sdk/types/mempool"
@@ -42,21 +46,34 @@ import (
 	"pkg.berachain.dev/polaris/lib/utils"
 )
 
-// Compile-time interface assertion.
-var _ core.PolarisHostChain = (*host)(nil)
+// FlagParallelExecutionWorkers is the app option that sets the number of Ethereum transactions
+// of a block that are speculatively executed in parallel. Parallel execution is disabled if it is
+// not positive, which is the default.
+const FlagParallelExecutionWorkers = "polaris.parallel-execution-workers"
+
+// Compile-time interface assertions.
+var (
+	_ core.PolarisHostChain  = (*host)(nil)
+	_ core.ParallelHostChain = (*host)(nil)
+)
 
 // Host is the interface that must be implemented by the host.
 // It includes core.PolarisHostChain and functions that are called in other packages.
 type Host interface {
 	core.PolarisHostChain
+	core.ParallelHostChain
 	GetAllPlugins() []plugins.BaseCosmosPolaris
 	Setup(
+		storetypes.StoreKey,
 		storetypes.StoreKey,
 		storetypes.StoreKey,
 		state.AccountKeeper,
 		state.BankKeeper,
 		func(height int64, prove bool) (sdk.Context, error),
+		func(abci.RequestQuery) abci.ResponseQuery,
 	)
+	// AddPrecompileMiddleware adds middlewares that are run around every precompile call.
+	AddPrecompileMiddleware(...precompile.Middleware)
 }
 
 type host struct {
@@ -70,6 +87,10 @@ type host struct {
 	txp txpool.Plugin
 
 	pcs func() *ethprecompile.Injector
+	// pms are the precompile middlewares that are added to the precompile plugin in Setup.
+	pms []precompile.Middleware
+	// workers is the number of Ethereum transactions that are executed in parallel.
+	workers int
 }
 
 // Newhost creates new instances of the plugin host.
@@ -86,32 +107,59 @@ func NewHost(
 	h := &host{}
 
 	// Build the Plugins
-	h.bp = block.NewPlugin(storeKey)
 	h.cp = configuration.NewPlugin(storeKey)
+	h.bp = block.NewPlugin(storeKey, h.cp)
 	h.gp = gas.NewPlugin()
 	h.txp = txpool.NewPlugin(h.cp, utils.MustGetAs[*mempool.EthTxPool](ethTxMempool))
 	h.pcs = precompiles
+	h.workers = cast.ToInt(appOpts.Get(FlagParallelExecutionWorkers))
 
 	return h
 }
 
 // Setup sets up the precompile and state plugins with the given precompiles and keepers. It also
-// sets the query context function for the block and state plugins (to support historical queries).
+// sets the query context function for the block, configuration, historical and state plugins (to
+// support historical and concurrent queries).
 func (h *host) Setup(
 	storeKey storetypes.StoreKey,
 	offchainStoreKey storetypes.StoreKey,
+	accountStoreKey storetypes.StoreKey,
 	ak state.AccountKeeper,
 	bk state.BankKeeper,
 	qc func(height int64, prove bool) (sdk.Context, error),
+	pq func(abci.RequestQuery) abci.ResponseQuery,
 ) {
 	// Setup the precompile and state plugins
 	h.sp = state.NewPlugin(ak, bk, storeKey, h.cp, log.NewFactory(h.pcs().GetPrecompiles()))
-	h.pp = precompile.NewPlugin(h.pcs().GetPrecompiles(), h.sp)
+	h.pp = precompile.NewPlugin(h.pcs().GetPrecompiles(), h.sp, h.cp)
+	h.pp.AddMiddleware(h.pms...)
 	h.hp = historical.NewPlugin(h.bp, offchainStoreKey, storeKey)
 
-	// Set the query context function for the block and state plugins
+	// Allow the mempool to read state nonces and balances
+	h.txp.SetStatePlugin(h.sp)
+
+	// Set the query context function for the block, configuration, historical, state and txpool
+	// plugins
 	h.sp.SetQueryContextFn(qc)
 	h.bp.SetQueryContextFn(qc)
+	h.cp.SetQueryContextFn(qc)
+	h.hp.SetQueryContextFn(qc)
+	h.txp.SetQueryContextFn(qc)
+
+	// Set the proof query function for the state plugin (to support state proofs)
+	h.sp.SetProofQueryFn(pq)
+	// Set the x/auth store key for the state plugin (to iterate over the accounts)
+	h.sp.SetAccountStoreKey(accountStoreKey)
+}
+
+// AddPrecompileMiddleware adds middlewares to the precompile plugin, or to the plugin that is
+// built in Setup if it is called before Setup.
+func (h *host) AddPrecompileMiddleware(middlewares ...precompile.Middleware) {
+	if h.pp != nil {
+		h.pp.AddMiddleware(middlewares...)
+		return
+	}
+	h.pms = append(h.pms, middlewares...)
 }
 
 // GetBlockPlugin returns the header plugin.
@@ -148,6 +196,11 @@ func (h *host) GetTxPoolPlugin() core.TxPoolPlugin {
 	return h.txp
 }
 
+// ParallelExecutionWorkers implements `core.ParallelHostChain`.
+func (h *host) ParallelExecutionWorkers() int {
+	return h.workers
+}
+
 // GetAllPlugins returns all the plugins.
 func (h *host) GetAllPlugins() []plugins.BaseCosmosPolaris {
 	return []plugins.BaseCosmosPolaris{h.bp, h.cp, h.gp, h.hp, h.pp, h.sp, h.txp}
@@ -21,9 +21,16 @@
 package keeper
 
 import (
+	"context"
+	"path/filepath"
+
+	"github.com/spf13/cast"
+
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -33,6 +40,7 @@ import (
 	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/txpool"
 	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
 	ethprecompile "pkg.berachain.dev/polaris/eth/core/precompile"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
 	ethlog "pkg.berachain.dev/polaris/eth/log"
 	"pkg.berachain.dev/polaris/eth/provider"
 )
@@ -50,6 +58,16 @@ type Keeper struct {
 	authority string
 	// The host contains various plugins that are are used to implement `core.PolarisHostChain`.
 	host Host
+	// journal is whether the local transactions are journaled under the Polaris data dir.
+	journal bool
+	// stopLocals stops resubmitting the local transactions.
+	stopLocals context.CancelFunc
+
+	// proposedTxs are the Ethereum transactions of the last accepted proposal, at proposedHeight,
+	// which are speculatively executed if parallel execution is enabled. They are only accessed
+	// by the ABCI consensus connection.
+	proposedHeight int64
+	proposedTxs    coretypes.Transactions
 }
 
 // NewKeeper creates new instances of the polaris Keeper.
@@ -68,6 +86,7 @@ func NewKeeper(
 		bk:        bk,
 		authority: authority,
 		storeKey:  storeKey,
+		journal:   cast.ToBool(appOpts.Get(txpool.FlagJournal)),
 	}
 
 	k.host = NewHost(
@@ -85,18 +104,41 @@ func NewKeeper(
 // Setup sets up the plugins in the Host. It also build the Polaris EVM Provider.
 func (k *Keeper) Setup(
 	offchainStoreKey *storetypes.KVStoreKey,
+	accountStoreKey storetypes.StoreKey,
 	qc func(height int64, prove bool) (sdk.Context, error),
+	pq func(abci.RequestQuery) abci.ResponseQuery,
 	polarisConfigPath string,
 	polarisDataDir string,
 
 ) {
 	// Setup plugins in the Host
-	k.host.Setup(k.storeKey, offchainStoreKey, k.ak, k.bk, qc)
+	k.host.Setup(k.storeKey, offchainStoreKey, accountStoreKey, k.ak, k.bk, qc, pq)
+
+	// Start resubmitting the local transactions, which are journaled if enabled.
+	var journalPath string
+	if k.journal {
+		journalPath = filepath.Join(polarisDataDir, txpool.JournalFileName)
+	}
+	var ctx context.Context
+	ctx, k.stopLocals = context.WithCancel(context.Background())
+	if err := k.host.GetTxPoolPlugin().(txpool.Plugin).StartLocals(ctx, journalPath); err != nil {
+		ethlog.Root().Error("failed to load the local transaction journal", "err", err)
+	}
 
 	// Build the Polaris EVM Provider
 	k.polaris = provider.NewPolarisProvider(polarisConfigPath, polarisDataDir, k.host, nil)
 }
 
+// Close stops the background services of the keeper and closes the local transaction journal.
+func (k *Keeper) Close() {
+	if k.stopLocals != nil {
+		k.stopLocals()
+	}
+	if err := k.host.GetTxPoolPlugin().(txpool.Plugin).CloseLocals(); err != nil {
+		ethlog.Root().Error("failed to close the local transaction journal", "err", err)
+	}
+}
+
 // ConfigureGethLogger configures the Geth logger to use the Cosmos logger.
 func (k *Keeper) ConfigureGethLogger(ctx sdk.Context) {
 	ethlog.Root().SetHandler(ethlog.FuncHandler(func(r *ethlog.Record) error {
@@ -126,3 +168,8 @@ func (k *Keeper) GetHost() Host {
 func (k *Keeper) SetClientCtx(clientContext client.Context) {
 	k.host.GetTxPoolPlugin().(txpool.Plugin).SetClientContext(clientContext)
 }
+
+// SetAnteHandler sets the ante handler that validates the private transactions.
+func (k *Keeper) SetAnteHandler(ah sdk.AnteHandler) {
+	k.host.GetTxPoolPlugin().(txpool.Plugin).SetAnteHandler(ah)
+}
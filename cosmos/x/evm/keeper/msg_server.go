@@ -28,6 +28,7 @@ import (
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/configuration"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/precompile"
 	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
 	"pkg.berachain.dev/polaris/lib/utils"
 )
@@ -72,9 +73,15 @@ func (k *Keeper) UpdateParams(
 		)
 	}
 
+	if err := req.Params.ValidateBaseFeeRecipient(k.ak.GetModuleAddress); err != nil {
+		return nil, err
+	}
+
 	// Update the params.
 	cp := utils.MustGetAs[configuration.Plugin](k.host.GetConfigurationPlugin())
 	cp.Prepare(ctx)
 	cp.SetParams(&req.Params)
+	// The precompile access rules of the new params apply to the rest of the block.
+	utils.MustGetAs[precompile.Plugin](k.host.GetPrecompilePlugin()).Prepare(ctx)
 	return &types.UpdateParamsResponse{}, nil
 }
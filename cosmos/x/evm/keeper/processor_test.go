@@ -91,7 +91,7 @@ var _ = Describe("Processor", func() {
 			ak, bk,
 			"authority",
 			simtestutil.NewAppOptionsWithFlagHome("tmp/berachain"),
-			evmmempool.NewEthTxPoolFrom(sdkmempool.NewPriorityMempool(
+			evmmempool.NewEthTxPool(evmmempool.DefaultConfig(), sdkmempool.NewPriorityMempool(
 				sdkmempool.DefaultPriorityNonceMempoolConfig()),
 			),
 			func() *ethprecompile.Injector {
@@ -103,7 +103,10 @@ var _ = Describe("Processor", func() {
 		validator.Status = stakingtypes.Bonded
 		sk.SetValidator(ctx, validator)
 		sc = staking.NewPrecompileContract(&sk)
-		k.Setup(storetypes.NewKVStoreKey("offchain-evm"), nil, "", GinkgoT().TempDir())
+		k.Setup(
+			storetypes.NewKVStoreKey("offchain-evm"), testutil.AccKey, nil, nil, "",
+			GinkgoT().TempDir(),
+		)
 		k.ConfigureGethLogger(ctx)
 		_ = sk.SetParams(ctx, stakingtypes.DefaultParams())
 		for _, plugin := range k.GetHost().GetAllPlugins() {
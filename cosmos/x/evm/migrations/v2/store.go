@@ -18,31 +18,35 @@
 // MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
 // TITLE.
 
-package ante
+package v2
 
 import (
+	storetypes "cosmossdk.io/store/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/configuration"
 	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
-	"pkg.berachain.dev/polaris/lib/utils"
 )
 
-// EthSkipDecorator is an AnteDecorator that wraps an existing AnteDecorator. It allows
-// EthTransactions to skip said Decorator by checking the first message in the transaction
-// for an EthTransactionRequest. This is safe since EthTransactions are guaranteed to be
-// the first and only message in a transaction.
-type EthSkipDecorator[T sdk.AnteDecorator] struct {
-	decorator T
-}
-
-// AnteHandle implements the sdk.AnteDecorator interface, it is handle the
-// type check for the message type.
-func (sd EthSkipDecorator[T]) AnteHandle(
-	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
-) (sdk.Context, error) {
-	if _, ok := utils.GetAs[*types.EthTransactionRequest](tx.GetMsgs()[0]); ok {
-		return next(ctx, tx, simulate)
+// MigrateStore performs the in-place store migration of the x/evm module from version 1 to 2. The
+// EIP-1559 base fee params were introduced in version 2, so they are set to their default values.
+func MigrateStore(ctx sdk.Context, storeKey storetypes.StoreKey) error {
+	cp := configuration.NewPlugin(storeKey)
+	cp.Prepare(ctx)
+	params := cp.GetParams()
+	if params.BaseFeeChangeDenominator == 0 {
+		params.BaseFeeChangeDenominator = types.DefaultBaseFeeChangeDenominator
 	}
-
-	return sd.decorator.AnteHandle(ctx, tx, simulate, next)
+	if params.ElasticityMultiplier == 0 {
+		params.ElasticityMultiplier = types.DefaultElasticityMultiplier
+	}
+	if params.MinBaseFee == 0 {
+		params.MinBaseFee = types.DefaultMinBaseFee
+	}
+	if err := params.ValidateBasic(); err != nil {
+		return err
+	}
+	cp.SetParams(params)
+	return nil
 }
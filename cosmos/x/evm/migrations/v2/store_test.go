@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package v2_test
+
+import (
+	"testing"
+
+	testutil "pkg.berachain.dev/polaris/cosmos/testing/utils"
+	v2 "pkg.berachain.dev/polaris/cosmos/x/evm/migrations/v2"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/configuration"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestV2(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "cosmos/x/evm/migrations/v2")
+}
+
+var _ = Describe("MigrateStore", func() {
+	It("should set the base fee params to their defaults", func() {
+		ctx := testutil.NewContext()
+		cp := configuration.NewPlugin(testutil.EvmKey)
+		cp.Prepare(ctx)
+
+		// The params of version 1 have no base fee params.
+		params := types.DefaultParams()
+		params.EvmDenom = "stake"
+		params.BaseFeeChangeDenominator = 0
+		params.ElasticityMultiplier = 0
+		params.MinBaseFee = 0
+		cp.SetParams(params)
+
+		Expect(v2.MigrateStore(ctx, testutil.EvmKey)).To(Succeed())
+		migrated := cp.GetParams()
+		Expect(migrated.EvmDenom).To(Equal("stake"))
+		Expect(migrated.BaseFeeChangeDenominator).To(
+			BeEquivalentTo(types.DefaultBaseFeeChangeDenominator),
+		)
+		Expect(migrated.ElasticityMultiplier).To(BeEquivalentTo(types.DefaultElasticityMultiplier))
+		Expect(migrated.MinBaseFee).To(Equal(types.DefaultMinBaseFee))
+	})
+
+	It("should not override the base fee params that are set", func() {
+		ctx := testutil.NewContext()
+		cp := configuration.NewPlugin(testutil.EvmKey)
+		cp.Prepare(ctx)
+		params := types.DefaultParams()
+		params.MinBaseFee = 7
+		cp.SetParams(params)
+
+		Expect(v2.MigrateStore(ctx, testutil.EvmKey)).To(Succeed())
+		Expect(cp.GetParams().MinBaseFee).To(Equal(uint64(7)))
+	})
+})
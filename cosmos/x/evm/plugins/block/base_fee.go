@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package block
+
+import (
+	"math/big"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+)
+
+// BaseFee returns the base fee of the block built on top of the given parent header, as defined
+// by EIP-1559, using the base fee parameters of the x/evm module.
+//
+// BaseFee implements core.BlockPlugin.
+func (p *plugin) BaseFee(parent *coretypes.Header) *big.Int {
+	return calcBaseFee(parent, p.cp.GetParams())
+}
+
+// calcBaseFee calculates the base fee of the block following the given parent header. The base fee
+// of the first block (or of a block following a parent without a base fee) is the minimum base
+// fee. The given params must be valid, i.e. have a non-zero change denominator and elasticity
+// multiplier (see `Params.ValidateBasic`).
+func calcBaseFee(parent *coretypes.Header, evmParams *types.Params) *big.Int {
+	minBaseFee := new(big.Int).SetUint64(evmParams.GetMinBaseFee())
+	if parent == nil || parent.BaseFee == nil {
+		return minBaseFee
+	}
+
+	denominator := evmParams.GetBaseFeeChangeDenominator()
+	elasticity := evmParams.GetElasticityMultiplier()
+
+	// If the parent gas used is the same as the target, the base fee remains unchanged.
+	parentGasTarget := parent.GasLimit / elasticity
+	if parentGasTarget == 0 || parent.GasUsed == parentGasTarget {
+		return bigMax(new(big.Int).Set(parent.BaseFee), minBaseFee)
+	}
+
+	var (
+		num   = new(big.Int)
+		denom = new(big.Int)
+		next  = new(big.Int)
+	)
+	if parent.GasUsed > parentGasTarget {
+		// If the parent block used more gas than its target, the base fee should increase.
+		// max(1, parentBaseFee * gasUsedDelta / parentGasTarget / baseFeeChangeDenominator)
+		num.SetUint64(parent.GasUsed - parentGasTarget)
+		num.Mul(num, parent.BaseFee)
+		num.Div(num, denom.SetUint64(parentGasTarget))
+		num.Div(num, denom.SetUint64(denominator))
+		next.Add(parent.BaseFee, bigMax(num, big.NewInt(1)))
+	} else {
+		// Otherwise if the parent block used less gas than its target, the base fee should
+		// decrease.
+		// max(0, parentBaseFee - parentBaseFee * gasUsedDelta / parentGasTarget / denominator)
+		num.SetUint64(parentGasTarget - parent.GasUsed)
+		num.Mul(num, parent.BaseFee)
+		num.Div(num, denom.SetUint64(parentGasTarget))
+		num.Div(num, denom.SetUint64(denominator))
+		next.Sub(parent.BaseFee, num)
+	}
+
+	return bigMax(next, minBaseFee)
+}
+
+// bigMax returns the larger of x or y.
+func bigMax(x, y *big.Int) *big.Int {
+	if x.Cmp(y) < 0 {
+		return y
+	}
+	return x
+}
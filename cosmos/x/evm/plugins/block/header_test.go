@@ -37,7 +37,7 @@ var _ = Describe("Header", func() {
 
 	BeforeEach(func() {
 		ctx = testutil.NewContext().WithBlockGasMeter(storetypes.NewGasMeter(uint64(10000)))
-		p = utils.MustGetAs[*plugin](NewPlugin(testutil.EvmKey))
+		p = utils.MustGetAs[*plugin](NewPlugin(testutil.EvmKey, nil))
 		p.Prepare(ctx)
 	})
 
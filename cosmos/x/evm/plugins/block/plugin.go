@@ -32,9 +32,6 @@ import (
 	"pkg.berachain.dev/polaris/eth/core"
 )
 
-// TODO: change this.
-const bf = uint64(1)
-
 type Plugin interface {
 	plugins.BaseCosmosPolaris
 	core.BlockPlugin
@@ -50,11 +47,14 @@ type plugin struct {
 	storekey storetypes.StoreKey
 	// getQueryContext allows for querying block headers.
 	getQueryContext func(height int64, prove bool) (sdk.Context, error)
+	// cp provides the x/evm module parameters, used for calculating the base fee.
+	cp ConfigurationPlugin
 }
 
-func NewPlugin(storekey storetypes.StoreKey) Plugin {
+func NewPlugin(storekey storetypes.StoreKey, cp ConfigurationPlugin) Plugin {
 	return &plugin{
 		storekey: storekey,
+		cp:       cp,
 	}
 }
 
@@ -63,14 +63,6 @@ func (p *plugin) Prepare(ctx context.Context) {
 	p.ctx = sdk.UnwrapSDKContext(ctx)
 }
 
-// BaseFee returns the base fee for the current block.
-// TODO: implement properly with DynamicFee Module of some kind.
-//
-// BaseFee implements core.BlockPlugin.
-func (p *plugin) BaseFee() uint64 {
-	return bf
-}
-
 // GetNewBlockMetadata returns the host chain block metadata for the given block height. It returns
 // the coinbase address, the timestamp of the block.
 func (p *plugin) GetNewBlockMetadata(number int64) (common.Address, uint64) {
@@ -81,3 +73,17 @@ func (p *plugin) GetNewBlockMetadata(number int64) (common.Address, uint64) {
 
 	return common.BytesToAddress(cometHeader.ProposerAddress), uint64(cometHeader.Time.UTC().Unix())
 }
+
+// GetStateRoot returns the app hash of the parent of the given block height, i.e. the commitment to
+// the IAVL state at the end of the parent block. The state proofs at the parent block are thus
+// verified against the root of the header of the given block.
+//
+// GetStateRoot implements core.BlockPlugin.
+func (p *plugin) GetStateRoot(number int64) common.Hash {
+	cometHeader := p.ctx.BlockHeader()
+	if cometHeader.Height != number {
+		panic("block height mismatch")
+	}
+
+	return common.BytesToHash(cometHeader.AppHash)
+}
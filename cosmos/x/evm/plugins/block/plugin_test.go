@@ -21,8 +21,59 @@
 package block
 
 import (
+	"math/big"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+
 	. "github.com/onsi/ginkgo/v2"
-	// . "github.com/onsi/gomega".
+	. "github.com/onsi/gomega"
 )
 
-var _ = Describe("Block Plugin", func() {})
+var _ = Describe("Block Plugin", func() {
+	Describe("Base Fee", func() {
+		var evmParams *types.Params
+
+		BeforeEach(func() {
+			evmParams = types.DefaultParams()
+			evmParams.MinBaseFee = 7
+		})
+
+		It("should return the min base fee for the first block", func() {
+			Expect(calcBaseFee(nil, evmParams)).To(Equal(big.NewInt(7)))
+			Expect(calcBaseFee(&coretypes.Header{}, evmParams)).To(Equal(big.NewInt(7)))
+		})
+
+		It("should not change when the parent used its gas target", func() {
+			parent := &coretypes.Header{GasLimit: 20000, GasUsed: 10000, BaseFee: big.NewInt(1000)}
+			Expect(calcBaseFee(parent, evmParams)).To(Equal(big.NewInt(1000)))
+		})
+
+		It("should increase when the parent used more than its gas target", func() {
+			parent := &coretypes.Header{GasLimit: 20000, GasUsed: 20000, BaseFee: big.NewInt(1000)}
+			Expect(calcBaseFee(parent, evmParams)).To(Equal(big.NewInt(1125)))
+
+			// The base fee must increase by at least 1.
+			parent.BaseFee = big.NewInt(7)
+			parent.GasUsed = 10001
+			Expect(calcBaseFee(parent, evmParams)).To(Equal(big.NewInt(8)))
+		})
+
+		It("should decrease when the parent used less than its gas target", func() {
+			parent := &coretypes.Header{GasLimit: 20000, GasUsed: 0, BaseFee: big.NewInt(1000)}
+			Expect(calcBaseFee(parent, evmParams)).To(Equal(big.NewInt(875)))
+		})
+
+		It("should respect the configured elasticity and change denominator", func() {
+			evmParams.ElasticityMultiplier = 4
+			evmParams.BaseFeeChangeDenominator = 2
+			parent := &coretypes.Header{GasLimit: 20000, GasUsed: 20000, BaseFee: big.NewInt(1000)}
+			Expect(calcBaseFee(parent, evmParams)).To(Equal(big.NewInt(2500)))
+		})
+
+		It("should not decrease below the min base fee", func() {
+			parent := &coretypes.Header{GasLimit: 20000, GasUsed: 0, BaseFee: big.NewInt(8)}
+			Expect(calcBaseFee(parent, evmParams)).To(Equal(big.NewInt(7)))
+		})
+	})
+})
@@ -22,6 +22,7 @@ package configuration
 
 import (
 	"context"
+	"errors"
 
 	storetypes "cosmossdk.io/store/types"
 
@@ -33,6 +34,7 @@ import (
 	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core"
 	"pkg.berachain.dev/polaris/eth/params"
+	errorslib "pkg.berachain.dev/polaris/lib/errors"
 )
 
 // Plugin is the interface that must be implemented by the plugin.
@@ -42,6 +44,8 @@ type Plugin interface {
 	SetParams(params *types.Params)
 	GetParams() *types.Params
 	GetEvmDenom() string
+	// SetQueryContextFn sets the function used for querying historical params.
+	SetQueryContextFn(fn func(height int64, prove bool) (sdk.Context, error))
 }
 
 // plugin implements the core.ConfigurationPlugin interface.
@@ -49,6 +53,10 @@ type plugin struct {
 	storeKey    storetypes.StoreKey
 	paramsStore storetypes.KVStore
 	evmDenom    string
+	// blockHeight is the height of the current block.
+	blockHeight int64
+	// getQueryContext allows for querying historical params.
+	getQueryContext func(height int64, prove bool) (sdk.Context, error)
 }
 
 // NewPlugin returns a new plugin instance.
@@ -62,6 +70,12 @@ func NewPlugin(storeKey storetypes.StoreKey) Plugin {
 func (p *plugin) Prepare(ctx context.Context) {
 	sCtx := sdk.UnwrapSDKContext(ctx)
 	p.paramsStore = sCtx.KVStore(p.storeKey)
+	p.blockHeight = sCtx.BlockHeight()
+}
+
+// SetQueryContextFn sets the query context func for the plugin.
+func (p *plugin) SetQueryContextFn(gqc func(height int64, prove bool) (sdk.Context, error)) {
+	p.getQueryContext = gqc
 }
 
 func (p *plugin) GetEvmDenom() string {
@@ -76,6 +90,30 @@ func (p *plugin) ChainConfig() *params.ChainConfig {
 	return p.GetParams().EthereumChainConfig()
 }
 
+// ChainConfigAt implements the core.ConfigurationPlugin interface. It returns the chain config
+// that the block at the given height was executed with, or the current chain config if the height
+// is the current block.
+func (p *plugin) ChainConfigAt(height int64) (*params.ChainConfig, error) {
+	if height >= p.blockHeight {
+		return p.ChainConfig(), nil
+	}
+	if p.getQueryContext == nil {
+		return nil, errors.New("ChainConfigAt: getQueryContext is nil")
+	}
+
+	// A block is executed with the params committed at the previous height. The genesis params
+	// are not committed at a height of their own, so they are read at the first height.
+	queryHeight := height - 1
+	if queryHeight < 1 {
+		queryHeight = 1
+	}
+	ctx, err := p.getQueryContext(queryHeight, false)
+	if err != nil {
+		return nil, errorslib.Wrap(err, "ChainConfigAt: failed to use query context")
+	}
+	return getParams(ctx.KVStore(p.storeKey)).EthereumChainConfig(), nil
+}
+
 // ExtraEips implements the core.ConfigurationPlugin interface.
 func (p *plugin) ExtraEips() []int {
 	eips := make([]int, 0)
@@ -91,3 +129,14 @@ func (p *plugin) FeeCollector() *common.Address {
 	addr := common.BytesToAddress([]byte(authtypes.FeeCollectorName))
 	return &addr
 }
+
+// BaseFeeCollector implements the core.ConfigurationPlugin interface. It returns the address of
+// the module account configured to receive the base fee, or nil if the base fee is burned.
+func (p *plugin) BaseFeeCollector() *common.Address {
+	recipient := p.GetParams().BaseFeeRecipient
+	if recipient == "" {
+		return nil
+	}
+	addr := common.BytesToAddress(authtypes.NewModuleAddress(recipient))
+	return &addr
+}
@@ -21,12 +21,16 @@
 package configuration
 
 import (
+	"math/big"
+
 	storetypes "cosmossdk.io/store/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	testutil "pkg.berachain.dev/polaris/cosmos/testing/utils"
 	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/params"
 	enclib "pkg.berachain.dev/polaris/lib/encoding"
 
@@ -91,10 +95,72 @@ var _ = Describe("Plugin", func() {
 		})
 	})
 
+	Describe("ChainConfigAt", func() {
+		var historicalCtx sdk.Context
+
+		BeforeEach(func() {
+			p.SetParams(types.DefaultParams())
+			p.blockHeight = 10
+
+			historicalCtx = testutil.NewContext()
+			historicalConfig := *params.DefaultChainConfig
+			historicalConfig.LondonBlock = big.NewInt(100)
+			historicalParams := types.DefaultParams()
+			historicalParams.ChainConfig = string(enclib.MustMarshalJSON(historicalConfig))
+			bz, err := historicalParams.Marshal()
+			Expect(err).ToNot(HaveOccurred())
+			historicalCtx.KVStore(p.storeKey).Set([]byte{types.ParamsKey}, bz)
+		})
+
+		It("should return the current chain config at the current height", func() {
+			config, err := p.ChainConfigAt(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(Equal(params.DefaultChainConfig))
+		})
+
+		It("should error without a query context function", func() {
+			_, err := p.ChainConfigAt(5)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return the chain config at a historical height", func() {
+			var queried int64
+			p.SetQueryContextFn(func(height int64, _ bool) (sdk.Context, error) {
+				queried = height
+				return historicalCtx, nil
+			})
+
+			config, err := p.ChainConfigAt(5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queried).To(Equal(int64(4)))
+			Expect(config.LondonBlock).To(Equal(big.NewInt(100)))
+
+			_, err = p.ChainConfigAt(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queried).To(Equal(int64(1)))
+		})
+	})
+
 	Describe("ExtraEips", func() {
 		It("should return an empty slice", func() {
 			eips := p.ExtraEips()
 			Expect(eips).To(BeEmpty())
 		})
 	})
+
+	Describe("BaseFeeCollector", func() {
+		It("should return nil when the base fee is burned", func() {
+			p.SetParams(types.DefaultParams())
+			Expect(p.BaseFeeCollector()).To(BeNil())
+		})
+
+		It("should return the module address of the base fee recipient", func() {
+			storedParams := types.DefaultParams()
+			storedParams.BaseFeeRecipient = "distribution"
+			p.SetParams(storedParams)
+
+			expected := common.BytesToAddress(authtypes.NewModuleAddress("distribution"))
+			Expect(p.BaseFeeCollector()).To(Equal(&expected))
+		})
+	})
 })
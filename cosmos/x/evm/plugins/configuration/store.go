@@ -21,12 +21,19 @@
 package configuration
 
 import (
+	storetypes "cosmossdk.io/store/types"
+
 	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
 )
 
 // GetParams is used to get the params for the evm module.
 func (p *plugin) GetParams() *types.Params {
-	bz := p.paramsStore.Get([]byte{types.ParamsKey})
+	return getParams(p.paramsStore)
+}
+
+// getParams reads the params for the evm module from the given store.
+func getParams(store storetypes.KVStore) *types.Params {
+	bz := store.Get([]byte{types.ParamsKey})
 	if bz == nil {
 		return &types.Params{}
 	}
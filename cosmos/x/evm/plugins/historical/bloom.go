@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package historical
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/store/prefix"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+)
+
+// GetBloomBits implements `core.HistoricalPlugin`. It is called concurrently by the bloom matcher
+// goroutines, so the bloom bits are read through a query context at the latest committed height,
+// rather than through the context of the block being executed.
+func (p *plugin) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	if p.getQueryContext == nil {
+		return nil, errors.New("GetBloomBits: getQueryContext is nil")
+	}
+	// A height of 0 queries the latest committed height.
+	ctx, err := p.getQueryContext(0, false)
+	if err != nil {
+		return nil, err
+	}
+	bits := prefix.NewStore(ctx.KVStore(p.offchainStoreKey),
+		[]byte{types.BloomBitsKeyPrefix}).Get(bloomBitsKey(bit, section))
+	if bits == nil {
+		return nil, fmt.Errorf("failed to find bloom bits %d for section %d", bit, section)
+	}
+	return bits, nil
+}
+
+// StoreBloomBits implements `core.HistoricalPlugin`.
+func (p *plugin) StoreBloomBits(bit uint, section uint64, bits []byte) error {
+	prefix.NewStore(p.ctx.KVStore(p.offchainStoreKey),
+		[]byte{types.BloomBitsKeyPrefix}).Set(bloomBitsKey(bit, section), bits)
+	return nil
+}
+
+// GetBloomSections implements `core.HistoricalPlugin`.
+func (p *plugin) GetBloomSections() (uint64, error) {
+	bz := p.ctx.KVStore(p.offchainStoreKey).Get([]byte{types.BloomSectionsKey})
+	if bz == nil {
+		return 0, nil
+	}
+	return sdk.BigEndianToUint64(bz), nil
+}
+
+// StoreBloomSections implements `core.HistoricalPlugin`.
+func (p *plugin) StoreBloomSections(sections uint64) error {
+	p.ctx.KVStore(p.offchainStoreKey).Set(
+		[]byte{types.BloomSectionsKey}, sdk.Uint64ToBigEndian(sections),
+	)
+	return nil
+}
+
+// bloomBitsKey returns the key of the bloom bits of the given bit and section, which is the
+// bit (uint16 big endian) followed by the section (uint64 big endian).
+func bloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 10) //nolint:gomnd // 2 + 8.
+	binary.BigEndian.PutUint16(key[0:], uint16(bit))
+	binary.BigEndian.PutUint64(key[2:], section)
+	return key
+}
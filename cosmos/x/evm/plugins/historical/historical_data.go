@@ -98,6 +98,36 @@ func (p *plugin) StoreTransactions(
 	return nil
 }
 
+// TruncateAbove implements `core.HistoricalPlugin`. Only the keys of the dropped blocks are
+// deleted, so the offchain store is never scanned. The deletions are written to the context of the
+// block that is being processed: as the Cosmos host chain does not implement
+// `core.RewindableHostChain`, the chain is only ever truncated when the block that was just
+// finalized is discarded, by the goroutine that processes blocks.
+func (p *plugin) TruncateAbove(number int64, dropped []*coretypes.Block) error {
+	store := p.ctx.KVStore(p.offchainStoreKey)
+	numStore := prefix.NewStore(store, []byte{types.BlockHashKeyToNumPrefix})
+	receiptsStore := prefix.NewStore(store, []byte{types.BlockHashKeyToReceiptsPrefix})
+	txStore := prefix.NewStore(store, []byte{types.TxHashKeyToTxPrefix})
+	for _, block := range dropped {
+		if block.Number().Int64() <= number {
+			return fmt.Errorf("block %d is not above block %d", block.Number().Int64(), number)
+		}
+
+		// delete the block number, receipts, and txs of the dropped block.
+		numStore.Delete(block.Hash().Bytes())
+		receiptsStore.Delete(block.Hash().Bytes())
+		for _, tx := range block.Transactions() {
+			txStore.Delete(tx.Hash().Bytes())
+		}
+	}
+
+	// rewind the version of the offchain store.
+	if sdk.BigEndianToUint64(store.Get([]byte{types.VersionKey})) > uint64(number) {
+		store.Set([]byte{types.VersionKey}, sdk.Uint64ToBigEndian(uint64(number)))
+	}
+	return nil
+}
+
 // GetBlockByNumber returns the block at the given height.
 func (p *plugin) GetBlockByNumber(number int64) (*coretypes.Block, error) {
 	// get header from on chain.
@@ -35,6 +35,10 @@ import (
 type Plugin interface {
 	plugins.BaseCosmosPolaris
 	core.HistoricalPlugin
+
+	// SetQueryContextFn sets the function used for reading the offchain store concurrently with
+	// the execution of blocks.
+	SetQueryContextFn(fn func(height int64, prove bool) (sdk.Context, error))
 }
 
 // plugin keeps track of polaris blocks via headers.
@@ -47,6 +51,8 @@ type plugin struct {
 	storekey storetypes.StoreKey
 	//  `offchainStore` is the offchain store, used for accessing offchain data.
 	offchainStoreKey storetypes.StoreKey
+	// getQueryContext allows for reading the offchain store at the latest committed height.
+	getQueryContext func(height int64, prove bool) (sdk.Context, error)
 }
 
 // NewPlugin creates a new instance of the block plugin from the given context.
@@ -60,6 +66,11 @@ func NewPlugin(
 	}
 }
 
+// SetQueryContextFn sets the query context func for the plugin.
+func (p *plugin) SetQueryContextFn(gqc func(height int64, prove bool) (sdk.Context, error)) {
+	p.getQueryContext = gqc
+}
+
 // Prepare implements core.HistoricalPlugin.
 func (p *plugin) Prepare(ctx context.Context) {
 	p.ctx = sdk.UnwrapSDKContext(ctx)
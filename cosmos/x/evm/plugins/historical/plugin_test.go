@@ -21,10 +21,82 @@
 package historical
 
 import (
+	"math/big"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	testutil "pkg.berachain.dev/polaris/cosmos/testing/utils"
+	"pkg.berachain.dev/polaris/eth/core"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("Historical Plugin", func() {
+	var (
+		p      core.HistoricalPlugin
+		blocks []*coretypes.Block
+	)
+
+	BeforeEach(func() {
+		ctx := testutil.NewContext()
+		p = NewPlugin(nil, storetypes.NewKVStoreKey("offchain-evm"), testutil.EvmKey)
+		p.Prepare(ctx)
+
+		blocks = nil
+		for n := int64(1); n <= 3; n++ {
+			tx := coretypes.NewTx(&coretypes.LegacyTx{Nonce: uint64(n), GasPrice: big.NewInt(1)})
+			block := coretypes.NewBlockWithHeader(&coretypes.Header{Number: big.NewInt(n)}).
+				WithBody(coretypes.Transactions{tx}, nil)
+			Expect(p.StoreBlock(block)).To(Succeed())
+			Expect(p.StoreReceipts(block.Hash(), coretypes.Receipts{{}})).To(Succeed())
+			Expect(p.StoreTransactions(n, block.Hash(), block.Transactions())).To(Succeed())
+			blocks = append(blocks, block)
+		}
+	})
+
+	It("should truncate the data above the given block number", func() {
+		Expect(p.TruncateAbove(1, []*coretypes.Block{blocks[2], blocks[1]})).To(Succeed())
+
+		_, err := p.GetReceiptsByHash(blocks[0].Hash())
+		Expect(err).ToNot(HaveOccurred())
+		for _, block := range blocks[1:] {
+			_, err = p.GetReceiptsByHash(block.Hash())
+			Expect(err).To(HaveOccurred())
+			_, err = p.GetTransactionByHash(block.Transactions()[0].Hash())
+			Expect(err).To(HaveOccurred())
+		}
+
+		// the next block after the new head can be stored again.
+		Expect(p.StoreBlock(blocks[1])).To(Succeed())
+	})
+
+	It("should not truncate blocks at or below the given block number", func() {
+		Expect(p.TruncateAbove(1, []*coretypes.Block{blocks[0]})).ToNot(Succeed())
+	})
+
+	It("should read the bloom bits at the latest committed height", func() {
+		_, err := p.GetBloomBits(1, 0)
+		Expect(err).To(HaveOccurred())
+
+		// The bloom bits of the block being executed are not committed yet.
+		Expect(p.StoreBloomBits(1, 0, []byte{1})).To(Succeed())
+		committed := testutil.NewContext()
+		p.(Plugin).SetQueryContextFn(func(height int64, _ bool) (sdk.Context, error) {
+			Expect(height).To(BeZero())
+			return committed, nil
+		})
+		_, err = p.GetBloomBits(1, 0)
+		Expect(err).To(HaveOccurred())
+
+		p.Prepare(committed)
+		Expect(p.StoreBloomBits(1, 0, []byte{2})).To(Succeed())
+		p.Prepare(testutil.NewContext())
+		Expect(p.GetBloomBits(1, 0)).To(Equal([]byte{2}))
+	})
 
 	// It("should get the header at current height", func() {
 	// 	header, err := p.GetHeaderByNumber(ctx.BlockHeight())
@@ -20,10 +20,37 @@
 
 package precompile
 
-import storetypes "cosmossdk.io/store/types"
+import (
+	"context"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	"pkg.berachain.dev/polaris/eth/common"
+)
 
 type (
 	StatePlugin interface {
 		SetGasConfig(storetypes.GasConfig, storetypes.GasConfig)
 	}
+
+	// ConfigurationPlugin provides the params of the x/evm module, which hold the precompile
+	// access rules.
+	ConfigurationPlugin interface {
+		GetParams() *types.Params
+	}
+
+	// Middleware is a hook around every precompile call that is run by the plugin, which can be
+	// used for metrics, rate limiting, tracing or audit logging of precompile calls. The context
+	// is the Cosmos SDK context of the call.
+	Middleware interface {
+		// Before is called before the precompile at the given address is run with the given
+		// method ID and ABI encoded arguments. The method ID is nil if the input is too short. If
+		// Before returns an error, the call reverts with the error as its reason.
+		Before(ctx context.Context, addr common.Address, method []byte, args []byte) error
+		// After is called after the precompile is run with its output, its error and the gas it
+		// used. It is also called if Before aborted the call, for the middlewares whose Before
+		// was called.
+		After(ctx context.Context, ret []byte, err error, gasUsed uint64)
+	}
 )
@@ -23,6 +23,7 @@ package precompile
 import (
 	"context"
 	"math/big"
+	"sync/atomic"
 
 	storetypes "cosmossdk.io/store/types"
 
@@ -33,6 +34,7 @@ import (
 	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core"
 	"pkg.berachain.dev/polaris/eth/core/precompile"
+	ethstate "pkg.berachain.dev/polaris/eth/core/state"
 	"pkg.berachain.dev/polaris/eth/core/vm"
 	"pkg.berachain.dev/polaris/eth/params"
 	"pkg.berachain.dev/polaris/lib/registry"
@@ -49,6 +51,19 @@ type Plugin interface {
 	SetKVGasConfig(storetypes.GasConfig)
 	TransientKVGasConfig() storetypes.GasConfig
 	SetTransientKVGasConfig(storetypes.GasConfig)
+	// AddMiddleware adds middlewares that are run around every precompile call. The Before hooks
+	// are called in the order that the middlewares were added and the After hooks in reverse.
+	AddMiddleware(...Middleware)
+	// Prepare rebuilds the access controller from the params of the configuration plugin. It
+	// must be called whenever the params may change, i.e. at the beginning of every block and
+	// when the params are updated.
+	Prepare(context.Context)
+}
+
+// accessRules are the precompile access rules of the params, or the error of the invalid rules.
+type accessRules struct {
+	controller *precompile.AccessController
+	err        error
 }
 
 // plugin runs precompile containers in the Cosmos environment with the context gas configs.
@@ -62,10 +77,20 @@ type plugin struct {
 	transientKVGasConfig storetypes.GasConfig
 	// sp allows resetting the context for the reentrancy into the EVM.
 	sp StatePlugin
+	// cp provides the precompile access rules, access control is disabled if it is nil.
+	cp ConfigurationPlugin
+	// access holds the access rules that were built by the last `Prepare`, and is read by the
+	// concurrent calls of the EVM. Access control is disabled if it holds nil.
+	access atomic.Pointer[accessRules]
+	// middlewares are run around every precompile call.
+	middlewares []Middleware
 }
 
-// NewPlugin creates and returns a `plugin` with the default kv gas configs.
-func NewPlugin(precompiles []precompile.Registrable, sp StatePlugin) Plugin {
+// NewPlugin creates and returns a `plugin` with the default kv gas configs. Calls to precompiles
+// are checked against the access rules in the params provided by the given configuration plugin.
+func NewPlugin(
+	precompiles []precompile.Registrable, sp StatePlugin, cp ConfigurationPlugin,
+) Plugin {
 	return &plugin{
 		Registry:    registry.NewMap[common.Address, vm.PrecompileContainer](),
 		precompiles: precompiles,
@@ -74,7 +99,27 @@ func NewPlugin(precompiles []precompile.Registrable, sp StatePlugin) Plugin {
 		kvGasConfig:          storetypes.GasConfig{},
 		transientKVGasConfig: storetypes.GasConfig{},
 		sp:                   sp,
+		cp:                   cp,
+	}
+}
+
+// Clone returns a new plugin that runs the same precompiles, with the same access rules and
+// middlewares, and an empty registry. The clone does not toggle the gas configs of the state
+// plugin on reentrancy, as it is only used to execute transactions outside of block processing,
+// on state that is not read through the state plugin of the host chain.
+//
+// Clone implements `precompile.ClonablePlugin`.
+func (p *plugin) Clone() precompile.Plugin {
+	clone := &plugin{
+		Registry:             registry.NewMap[common.Address, vm.PrecompileContainer](),
+		precompiles:          p.precompiles,
+		kvGasConfig:          p.kvGasConfig,
+		transientKVGasConfig: p.transientKVGasConfig,
+		cp:                   p.cp,
+		middlewares:          p.middlewares,
 	}
+	clone.access.Store(p.access.Load())
+	return clone
 }
 
 // GetPrecompiles implements `core.PrecompilePlugin`.
@@ -98,6 +143,25 @@ func (p *plugin) SetTransientKVGasConfig(transientKVGasConfig storetypes.GasConf
 	p.transientKVGasConfig = transientKVGasConfig
 }
 
+// Prepare implements `Plugin`.
+func (p *plugin) Prepare(context.Context) {
+	if p.cp == nil {
+		return
+	}
+	params := p.cp.GetParams()
+	if len(params.PrecompileAllowlist) == 0 && len(params.PrecompileDenylist) == 0 {
+		p.access.Store(nil)
+		return
+	}
+	controller, err := params.PrecompileAccessController()
+	p.access.Store(&accessRules{controller: controller, err: err})
+}
+
+// AddMiddleware implements `Plugin`.
+func (p *plugin) AddMiddleware(middlewares ...Middleware) {
+	p.middlewares = append(p.middlewares, middlewares...)
+}
+
 // Run runs the a precompile container and returns the remaining gas after execution by injecting
 // a Cosmos SDK `GasMeter`. This function returns an error if the precompile execution returns an
 // error or insufficient gas is provided.
@@ -107,15 +171,53 @@ func (p *plugin) Run(
 	evm precompile.EVM, pc vm.PrecompileContainer, input []byte,
 	caller common.Address, value *big.Int, suppliedGas uint64, readonly bool,
 ) ([]byte, uint64, error) {
-	// use a precompile-specific gas meter for dynamic consumption
-	gm := storetypes.NewInfiniteGasMeter()
-	// consume static gas from RequiredGas
-	gm.ConsumeGas(pc.RequiredGas(input), "RequiredGas")
+	// deny calls that are not allowed by the access rules with a standard revert, before any gas
+	// is consumed
+	if err := p.checkAccess(pc.RegistryKey(), caller, input); err != nil {
+		return precompile.PackRevert(err.Error()), suppliedGas, vm.ErrExecutionReverted
+	}
 
 	// get native Cosmos SDK context from the Polaris StateDB
 	sdb := utils.MustGetAs[vm.PolarisStateDB](evm.GetStateDB())
+
+	// deny calls that modify state on top of an overlay, as the precompiles write directly to the
+	// stores of the context, which the overlay does not buffer
+	if ethstate.IsOverlayContext(sdb.GetContext()) {
+		if ro, ok := pc.(precompile.ReadOnlyContainer); ok && !ro.IsReadOnly(input) {
+			return precompile.PackRevert(precompile.ErrOverlayWrite.Error()),
+				suppliedGas, vm.ErrExecutionReverted
+		}
+	}
 	ctx := sdk.UnwrapSDKContext(sdb.GetContext())
 
+	// run the Before hooks, any of which may abort the call with a standard revert
+	var method, args []byte
+	if len(input) >= precompile.NumBytesMethodID {
+		method, args = input[:precompile.NumBytesMethodID], input[precompile.NumBytesMethodID:]
+	}
+	for i, mw := range p.middlewares {
+		if err := mw.Before(ctx, pc.RegistryKey(), method, args); err != nil {
+			p.runAfter(ctx, i, nil, err, 0)
+			return precompile.PackRevert(err.Error()), suppliedGas, vm.ErrExecutionReverted
+		}
+	}
+
+	ret, remainingGas, err := p.run(ctx, sdb, evm, pc, input, caller, value, suppliedGas, readonly)
+	p.runAfter(ctx, len(p.middlewares), ret, err, suppliedGas-remainingGas)
+	return ret, remainingGas, err
+}
+
+// run runs the precompile container with a precompile-specific gas meter and returns the
+// remaining gas after execution.
+func (p *plugin) run(
+	ctx sdk.Context, sdb vm.PolarisStateDB, evm precompile.EVM, pc vm.PrecompileContainer,
+	input []byte, caller common.Address, value *big.Int, suppliedGas uint64, readonly bool,
+) ([]byte, uint64, error) {
+	// use a precompile-specific gas meter for dynamic consumption
+	gm := storetypes.NewInfiniteGasMeter()
+	// consume static gas from RequiredGas
+	gm.ConsumeGas(pc.RequiredGas(input), "RequiredGas")
+
 	// begin precompile execution => begin emitting Cosmos event as Eth logs
 	cem := utils.MustGetAs[state.ControllableEventManager](ctx.EventManager())
 	cem.BeginPrecompileExecution(sdb)
@@ -135,6 +237,11 @@ func (p *plugin) Run(
 	// end precompile execution => stop emitting Cosmos event as Eth logs
 	cem.EndPrecompileExecution()
 
+	// consume dynamic gas based on the output of the execution
+	if dg, ok := pc.(precompile.DynamicGasContainer); ok && err == nil {
+		gm.ConsumeGas(dg.ReturnGas(input, ret), "ReturnGas")
+	}
+
 	// handle overconsumption of gas
 	if gm.GasConsumed() > suppliedGas {
 		return nil, 0, vm.ErrOutOfGas
@@ -144,10 +251,33 @@ func (p *plugin) Run(
 	return ret, suppliedGas - gm.GasConsumed(), err
 }
 
+// runAfter runs the After hooks of the first n middlewares, in reverse order.
+func (p *plugin) runAfter(ctx sdk.Context, n int, ret []byte, err error, gasUsed uint64) {
+	for i := n - 1; i >= 0; i-- {
+		p.middlewares[i].After(ctx, ret, err, gasUsed)
+	}
+}
+
+// checkAccess returns an error if the caller may not call the method of the precompile at the
+// given address that is selected by the input.
+func (p *plugin) checkAccess(addr, caller common.Address, input []byte) error {
+	rules := p.access.Load()
+	switch {
+	case rules == nil:
+		return nil
+	case rules.err != nil:
+		return rules.err
+	}
+	return rules.controller.Check(addr, caller, input)
+}
+
 // EnableReentrancy sets the state so that execution can enter the EVM again.
 //
 // EnableReentrancy implements `core.PrecompilePlugin`.
 func (p *plugin) EnableReentrancy(_ context.Context) {
+	if p.sp == nil {
+		return
+	}
 	// We remove the KVStore gas metering from the context prior to entering the EVM state
 	// transition. This is because the EVM is not aware of the Cosmos SDK's gas metering and is
 	// designed to be used in a standalone manner, as each of the EVM's opcodes are priced
@@ -160,6 +290,9 @@ func (p *plugin) EnableReentrancy(_ context.Context) {
 //
 // DisableReentrancy implements `core.PrecompilePlugin`.
 func (p *plugin) DisableReentrancy(ctx context.Context) {
+	if p.sp == nil {
+		return
+	}
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	// restore ctx gas configs for continuing precompile execution
 	p.sp.SetGasConfig(sdkCtx.KVGasConfig(), sdkCtx.TransientKVGasConfig())
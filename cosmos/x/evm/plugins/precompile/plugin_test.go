@@ -22,15 +22,23 @@ package precompile
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 
+	generated "pkg.berachain.dev/polaris/contracts/bindings/cosmos/precompile"
+	cosmlib "pkg.berachain.dev/polaris/cosmos/lib"
+	"pkg.berachain.dev/polaris/cosmos/precompile/bank"
 	testutil "pkg.berachain.dev/polaris/cosmos/testing/utils"
 	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/state/events"
 	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/state/events/mock"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
 	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core/precompile"
+	ethstate "pkg.berachain.dev/polaris/eth/core/state"
 	"pkg.berachain.dev/polaris/eth/core/vm"
 	"pkg.berachain.dev/polaris/lib/utils"
 
@@ -49,7 +57,7 @@ var _ = Describe("plugin", func() {
 		ctx = ctx.WithEventManager(
 			events.NewManagerFrom(ctx.EventManager(), mock.NewPrecompileLogFactory()),
 		)
-		p = utils.MustGetAs[*plugin](NewPlugin(nil, nil))
+		p = utils.MustGetAs[*plugin](NewPlugin(nil, nil, nil))
 		e = &mockEVM{}
 	})
 
@@ -64,6 +72,100 @@ var _ = Describe("plugin", func() {
 		Expect(err.Error()).To(Equal("out of gas"))
 	})
 
+	It("should revert calls that are denied by the access rules", func() {
+		p.cp = &mockConfigPlugin{&types.Params{PrecompileDenylist: []string{addr.Hex()}}}
+		p.Prepare(ctx)
+		ret, remainingGas, err := p.Run(e, &mockStateless{}, []byte{}, addr, new(big.Int), 30, false)
+		Expect(err).To(Equal(vm.ErrExecutionReverted))
+		Expect(remainingGas).To(Equal(uint64(30)))
+		reason, err := abi.UnpackRevert(ret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(Equal(precompile.ErrAccessDenied.Error()))
+
+		// Clones check the same access rules.
+		clone := utils.MustGetAs[*plugin](p.Clone())
+		Expect(clone.checkAccess(addr, addr, nil)).To(MatchError(precompile.ErrAccessDenied))
+
+		// Calls from allowed callers are run.
+		p.cp = &mockConfigPlugin{&types.Params{
+			PrecompileAllowlist: []string{addr.Hex() + "@" + addr.Hex()},
+		}}
+		p.Prepare(ctx)
+		_, remainingGas, err = p.Run(e, &mockStateless{}, []byte{}, addr, new(big.Int), 30, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remainingGas).To(Equal(uint64(10)))
+	})
+
+	It("should run the middlewares around the call", func() {
+		var calls []string
+		first := &mockMiddleware{name: "first", calls: &calls}
+		second := &mockMiddleware{name: "second", calls: &calls}
+		p.AddMiddleware(first, second)
+
+		input := []byte{1, 2, 3, 4, 5}
+		_, _, err := p.Run(e, &mockStateless{}, input, addr, new(big.Int), 30, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{"first.Before", "second.Before", "second.After", "first.After"}))
+		Expect(first.method).To(Equal([]byte{1, 2, 3, 4}))
+		Expect(first.args).To(Equal([]byte{5}))
+		Expect(first.gasUsed).To(Equal(uint64(20)))
+
+		// A Before error aborts the call with a revert.
+		calls = nil
+		second.beforeErr = errors.New("rate limited")
+		ret, remainingGas, err := p.Run(e, &mockStateless{}, input, addr, new(big.Int), 30, false)
+		Expect(err).To(Equal(vm.ErrExecutionReverted))
+		Expect(remainingGas).To(Equal(uint64(30)))
+		Expect(calls).To(Equal([]string{"first.Before", "second.Before", "first.After"}))
+		Expect(first.afterErr).To(MatchError("rate limited"))
+		reason, err := abi.UnpackRevert(ret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(Equal("rate limited"))
+	})
+
+	It("should revert calls that modify state on top of an overlay", func() {
+		var bk bankkeeper.BaseKeeper
+		ctx, _, bk, _ = testutil.SetupMinimalKeepers()
+		ctx = ctx.WithEventManager(
+			events.NewManagerFrom(ctx.EventManager(), mock.NewPrecompileLogFactory()),
+		)
+		from, to := common.Address{1}, common.Address{2}
+		coins := sdk.NewCoins(sdk.NewInt64Coin("abera", 100))
+		Expect(bk.MintCoins(ctx, types.ModuleName, coins)).To(Succeed())
+		Expect(bk.SendCoinsFromModuleToAccount(
+			ctx, types.ModuleName, cosmlib.AddressToAccAddress(from), coins,
+		)).To(Succeed())
+		bk.SetSendEnabled(ctx, "abera", true)
+
+		pc, err := precompile.NewStatefulFactory().Build(
+			bank.NewPrecompileContract(bankkeeper.NewMsgServerImpl(bk), bk), p,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		bankABI := abi.MustUnmarshalJSON(generated.BankModuleMetaData.ABI)
+		e = &mockEVM{overlay: true}
+
+		input, err := bankABI.Pack("send", from, to, []generated.IBankModuleCoin{
+			{Amount: big.NewInt(100), Denom: "abera"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		ret, remainingGas, err := p.Run(e, pc, input, from, new(big.Int), 1e6, false)
+		Expect(err).To(Equal(vm.ErrExecutionReverted))
+		Expect(remainingGas).To(Equal(uint64(1e6)))
+		reason, err := abi.UnpackRevert(ret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(Equal(precompile.ErrOverlayWrite.Error()))
+		Expect(bk.GetBalance(ctx, cosmlib.AddressToAccAddress(from), "abera").Amount.Int64()).
+			To(Equal(int64(100)))
+		Expect(bk.GetBalance(ctx, cosmlib.AddressToAccAddress(to), "abera").IsZero()).To(BeTrue())
+
+		// Calls that do not modify state are run.
+		input, err = bankABI.Pack("getBalance", from, "abera")
+		Expect(err).ToNot(HaveOccurred())
+		ret, _, err = p.Run(e, pc, input, from, new(big.Int), 1e6, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(new(big.Int).SetBytes(ret)).To(Equal(big.NewInt(100)))
+	})
+
 	// TODO: re-enable once dynamic gas config is implemented.
 	// It("should plug in custom gas configs", func() {
 	// 	Expect(p.KVGasConfig().DeleteCost).To(Equal(uint64(0)))
@@ -80,20 +182,64 @@ var _ = Describe("plugin", func() {
 
 type mockEVM struct {
 	precompile.EVM
+	// overlay makes the statedb return the context of an overlay.
+	overlay bool
 }
 
 func (me *mockEVM) GetStateDB() vm.GethStateDB {
-	return &mockSDB{}
+	return &mockSDB{overlay: me.overlay}
 }
 
 type mockSDB struct {
 	vm.PolarisStateDB
+	overlay bool
 }
 
 func (ms *mockSDB) GetContext() context.Context {
+	if ms.overlay {
+		return ethstate.NewQueryOverlay(&mockStatePlugin{}).GetContext()
+	}
+	return ctx
+}
+
+type mockStatePlugin struct {
+	ethstate.Plugin
+}
+
+func (msp *mockStatePlugin) GetContext() context.Context {
 	return ctx
 }
 
+type mockConfigPlugin struct {
+	params *types.Params
+}
+
+func (mcp *mockConfigPlugin) GetParams() *types.Params {
+	return mcp.params
+}
+
+type mockMiddleware struct {
+	name   string
+	calls  *[]string
+	method []byte
+	args   []byte
+	// beforeErr is returned by Before and afterErr is the error passed to After.
+	beforeErr error
+	afterErr  error
+	gasUsed   uint64
+}
+
+func (mm *mockMiddleware) Before(_ context.Context, _ common.Address, method, args []byte) error {
+	*mm.calls = append(*mm.calls, mm.name+".Before")
+	mm.method, mm.args = method, args
+	return mm.beforeErr
+}
+
+func (mm *mockMiddleware) After(_ context.Context, _ []byte, err error, gasUsed uint64) {
+	*mm.calls = append(*mm.calls, mm.name+".After")
+	mm.afterErr, mm.gasUsed = err, gasUsed
+}
+
 type mockStateless struct{}
 
 var addr = common.BytesToAddress([]byte{1})
@@ -61,7 +61,7 @@ func (p *plugin) ExportGenesis(ctx sdk.Context, data *types.GenesisState) {
 		data.HashToCode = make(map[string]string)
 	}
 
-	p.IterateCode(func(address common.Address, code []byte) bool {
+	p.IterateCode(common.Address{}, func(address common.Address, code []byte) bool {
 		// Get the contract code hash.
 		codeHash := p.GetCodeHash(address)
 		// If the contract is nil, allocate memory for it.
@@ -74,7 +74,7 @@ func (p *plugin) ExportGenesis(ctx sdk.Context, data *types.GenesisState) {
 		return false // keep iterating
 	})
 
-	p.IterateState(func(addr common.Address, key, value common.Hash) bool {
+	p.IterateState(common.Address{}, func(addr common.Address, key, value common.Hash) bool {
 		// if the slot to value map is nil on the contract, allocate memory for it.
 		if data.AddressToContract[addr.Hex()].SlotToValue == nil {
 			data.AddressToContract[addr.Hex()].SlotToValue = make(map[string]string)
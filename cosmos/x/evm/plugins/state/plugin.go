@@ -25,9 +25,13 @@ import (
 	"errors"
 	"math/big"
 
+	"cosmossdk.io/store/prefix"
 	storetypes "cosmossdk.io/store/types"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	"pkg.berachain.dev/polaris/cosmos/lib"
 	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins"
@@ -36,6 +40,7 @@ import (
 	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
 	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core"
+	ethstate "pkg.berachain.dev/polaris/eth/core/state"
 	"pkg.berachain.dev/polaris/eth/crypto"
 	"pkg.berachain.dev/polaris/eth/rpc"
 	"pkg.berachain.dev/polaris/lib/snapshot"
@@ -44,6 +49,12 @@ import (
 
 const pluginRegistryKey = `statePlugin`
 
+// Compile-time interface assertions.
+var (
+	_ ethstate.IterablePlugin = (*plugin)(nil)
+	_ ethstate.ProofPlugin    = (*plugin)(nil)
+)
+
 var (
 	// EmptyCodeHash is the code hash of an empty code
 	// 0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470.
@@ -57,10 +68,24 @@ type Plugin interface {
 	core.StatePlugin
 	// SetQueryContextFn sets the query context func for the plugin.
 	SetQueryContextFn(fn func(height int64, prove bool) (sdk.Context, error))
-	// IterateState iterates over the state of all accounts and calls the given callback function.
-	IterateState(fn func(addr common.Address, key common.Hash, value common.Hash) bool)
-	// IterateCode iterates over the code of all accounts and calls the given callback function.
-	IterateCode(fn func(addr common.Address, code []byte) bool)
+	// GetLatestCommittedState returns a view of the state at the latest committed height, which
+	// is safe to read concurrently with the execution of blocks.
+	GetLatestCommittedState() (core.StatePlugin, error)
+	// SetProofQueryFn sets the function used for querying proofs of the state.
+	SetProofQueryFn(fn func(abci.RequestQuery) abci.ResponseQuery)
+	// SetAccountStoreKey sets the key of the x/auth store, which the accounts are iterated over.
+	SetAccountStoreKey(storetypes.StoreKey)
+	// IterateAccounts iterates over the accounts from the given start address, in ascending order,
+	// and calls the given callback function.
+	IterateAccounts(start common.Address, fn func(addr common.Address) bool)
+	// IterateState iterates over the state of the accounts from the given start address, in
+	// ascending order, and calls the given callback function.
+	IterateState(
+		start common.Address, fn func(addr common.Address, key common.Hash, value common.Hash) bool,
+	)
+	// IterateCode iterates over the code of the accounts from the given start address, in
+	// ascending order, and calls the given callback function.
+	IterateCode(start common.Address, fn func(addr common.Address, code []byte) bool)
 	// SetGasConfig sets the gas config for the plugin.
 	SetGasConfig(storetypes.GasConfig, storetypes.GasConfig)
 }
@@ -102,6 +127,9 @@ type plugin struct {
 	// Store the evm store key for quick lookups to the evm store
 	storeKey storetypes.StoreKey
 
+	// accountStoreKey is the key of the x/auth store, which is used to iterate over the accounts
+	accountStoreKey storetypes.StoreKey
+
 	// keepers used for balance and account information.
 	ak AccountKeeper
 	bk BankKeeper
@@ -109,6 +137,9 @@ type plugin struct {
 	// getQueryContext allows for querying state a historical height.
 	getQueryContext func(height int64, prove bool) (sdk.Context, error)
 
+	// queryProofFn allows for querying proofs of the state at a historical height.
+	queryProofFn func(abci.RequestQuery) abci.ResponseQuery
+
 	// we load the evm denom in the constructor, to prevent going to
 	// the params to get it mid interpolation.
 	cp ConfigurationPlugin
@@ -366,11 +397,39 @@ func (p *plugin) SetCode(addr common.Address, code []byte) {
 	}
 }
 
-// IterateCode iterates over all the addresses with code and calls the given method.
-func (p *plugin) IterateCode(fn func(address common.Address, code []byte) bool) {
-	it := storetypes.KVStorePrefixIterator(
-		p.cms.GetKVStore(p.storeKey),
-		[]byte{types.CodeHashKeyPrefix},
+// SetAccountStoreKey implements `Plugin`.
+func (p *plugin) SetAccountStoreKey(key storetypes.StoreKey) {
+	p.accountStoreKey = key
+}
+
+// IterateAccounts iterates over the addresses of the accounts in the x/auth store, from the given
+// start address, and calls the given method. The iteration starts at the start address, so that
+// paging through the accounts does not scan the accounts of the previous pages again.
+func (p *plugin) IterateAccounts(start common.Address, fn func(address common.Address) bool) {
+	if p.accountStoreKey == nil {
+		panic("no account store key set in host chain")
+	}
+	accounts := prefix.NewStore(
+		p.cms.GetKVStore(p.accountStoreKey), authtypes.AddressStoreKeyPrefix.Bytes(),
+	)
+	it := accounts.Iterator(start.Bytes(), nil)
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if fn(common.BytesToAddress(it.Key())) {
+			break
+		}
+	}
+}
+
+// IterateCode iterates over the addresses with code, from the given start address, and calls the
+// given method.
+func (p *plugin) IterateCode(
+	start common.Address, fn func(address common.Address, code []byte) bool,
+) {
+	it := p.cms.GetKVStore(p.storeKey).Iterator(
+		CodeHashKeyFor(start),
+		storetypes.PrefixEndBytes([]byte{types.CodeHashKeyPrefix}),
 	)
 	defer it.Close()
 
@@ -428,11 +487,14 @@ func (p *plugin) SetStorage(addr common.Address, storage map[common.Hash]common.
 	}
 }
 
-// IterateState iterates over all the contract state, and calls the given function.
-func (p *plugin) IterateState(cb func(addr common.Address, key, value common.Hash) bool) {
-	it := storetypes.KVStorePrefixIterator(
-		p.cms.GetCommittedKVStore(p.storeKey),
-		[]byte{types.StorageKeyPrefix},
+// IterateState iterates over the contract state, from the given start address, and calls the
+// given function.
+func (p *plugin) IterateState(
+	start common.Address, cb func(addr common.Address, key, value common.Hash) bool,
+) {
+	it := p.cms.GetCommittedKVStore(p.storeKey).Iterator(
+		StorageKeyFor(start),
+		storetypes.PrefixEndBytes([]byte{types.StorageKeyPrefix}),
 	)
 	defer it.Close()
 
@@ -525,6 +587,26 @@ func (p *plugin) GetStateByNumber(number int64) (core.StatePlugin, error) {
 
 	// Create a State Plugin with the requested chain height.
 	sp := NewPlugin(p.ak, p.bk, p.storeKey, p.cp, p.plf)
+	sp.SetProofQueryFn(p.queryProofFn)
+	sp.SetAccountStoreKey(p.accountStoreKey)
+	sp.Reset(ctx)
+	return sp, nil
+}
+
+// GetLatestCommittedState implements `Plugin`. Unlike `GetStateByNumber`, it never branches off
+// the live context of the plugin.
+func (p *plugin) GetLatestCommittedState() (core.StatePlugin, error) {
+	if p.getQueryContext == nil {
+		return nil, errors.New("no query context function set in host chain")
+	}
+	// A height of 0 queries the latest committed height.
+	ctx, err := p.getQueryContext(0, false)
+	if err != nil {
+		return nil, err
+	}
+	sp := NewPlugin(p.ak, p.bk, p.storeKey, p.cp, p.plf)
+	sp.SetProofQueryFn(p.queryProofFn)
+	sp.SetAccountStoreKey(p.accountStoreKey)
 	sp.Reset(ctx)
 	return sp, nil
 }
@@ -220,6 +220,24 @@ var _ = Describe("State Plugin", func() {
 		})
 	})
 
+	Describe("TestIterateAccounts", func() {
+		It("should iterate over the accounts from the start address", func() {
+			p := sp.(state.Plugin)
+			p.SetAccountStoreKey(testutil.AccKey)
+			addrs := []common.Address{{1}, {2}, {3}}
+			for _, addr := range addrs {
+				sp.CreateAccount(addr)
+			}
+
+			var iterated []common.Address
+			p.IterateAccounts(common.Address{2}, func(addr common.Address) bool {
+				iterated = append(iterated, addr)
+				return addr == common.Address{3}
+			})
+			Expect(iterated).To(Equal(addrs[1:]))
+		})
+	})
+
 	Describe("TestState", func() {
 		It("should have empty state", func() {
 			Expect(sp.GetState(alice, common.Hash{3})).To(Equal(common.Hash{}))
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	storetypes "cosmossdk.io/store/types"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/types/address"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
+	"pkg.berachain.dev/polaris/eth/common"
+	errorslib "pkg.berachain.dev/polaris/lib/errors"
+)
+
+// =============================================================================
+// Proofs
+// =============================================================================
+
+// The proofs returned by the plugin are ICS-23 proofs of the IAVL stores backing the EVM state.
+// Every proof is a marshaled `ProofOps` with two operations: an IAVL proof of the key against the
+// root of its store, followed by a proof of the store root against the app hash.
+//
+// The proofs are queried at the height of the plugin's context, so that they prove the values that
+// are read from the plugin, i.e. the state at the end of block n. The app hash of that state is
+// only committed to in the comet header of block n+1, so it is the root of the Polaris header of
+// block n+1 (see `GetStateRoot` of the block plugin), NOT the root of the header of block n. The
+// app hash is thus returned separately by `GetProofRoot`, and clients must check it against the
+// root of the header of the block following the one that they queried. Consequently, the proofs of
+// the latest block can not be verified until the next block is committed.
+//
+// The storage of an account is not committed to separately in the IAVL tree, so there is no
+// per-account storage root: the storage proofs are verified against the app hash as well.
+
+// SetProofQueryFn sets the function used for querying proofs from the commit multi-store.
+func (p *plugin) SetProofQueryFn(fn func(abci.RequestQuery) abci.ResponseQuery) {
+	p.queryProofFn = fn
+}
+
+// GetProof returns the proofs of the evm denom balance (x/bank), the account (x/auth, which holds
+// the nonce) and the code hash (x/evm) of the given address.
+//
+// GetProof implements `state.ProofPlugin`.
+func (p *plugin) GetProof(addr common.Address) ([][]byte, error) {
+	keys := []struct {
+		storeName string
+		key       []byte
+	}{
+		{banktypes.StoreKey, balanceStoreKeyFor(addr, p.cp.GetEvmDenom())},
+		{authtypes.StoreKey, accountStoreKeyFor(addr)},
+		{p.storeKey.Name(), CodeHashKeyFor(addr)},
+	}
+
+	proofs := make([][]byte, 0, len(keys))
+	for _, k := range keys {
+		proof, _, err := p.queryProof(k.storeName, k.key)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, proof)
+	}
+	return proofs, nil
+}
+
+// GetStorageProof returns the proof of the given storage slot (`SlotKeyFor`) of the given address.
+//
+// GetStorageProof implements `state.ProofPlugin`.
+func (p *plugin) GetStorageProof(addr common.Address, slot common.Hash) ([][]byte, error) {
+	proof, _, err := p.queryProof(p.storeKey.Name(), SlotKeyFor(addr, slot))
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{proof}, nil
+}
+
+// GetProofRoot returns the app hash that the proofs are verified against, which is the root of the
+// header of the next block.
+//
+// GetProofRoot implements `state.ProofPlugin`.
+func (p *plugin) GetProofRoot() (common.Hash, error) {
+	_, roots, err := p.queryProof(p.storeKey.Name(), []byte{types.ParamsKey})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(roots[len(roots)-1]), nil
+}
+
+// queryProof queries the proof of the given key in the given store at the height of the plugin's
+// context. It returns the marshaled proof, along with the roots computed by each of its
+// operations (the last one being the app hash).
+func (p *plugin) queryProof(storeName string, key []byte) ([]byte, [][]byte, error) {
+	if p.queryProofFn == nil {
+		return nil, nil, errors.New("no proof query function set in host chain")
+	}
+
+	res := p.queryProofFn(abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/key", storeName),
+		Data:   key,
+		Height: p.ctx.BlockHeight(),
+		Prove:  true,
+	})
+	if !res.IsOK() {
+		return nil, nil, fmt.Errorf("failed to query proof: %s", res.Log)
+	}
+	if res.ProofOps == nil || len(res.ProofOps.Ops) == 0 {
+		return nil, nil, errors.New("failed to query proof: no proof returned")
+	}
+
+	// Run the proof operations to compute the roots they commit to. An empty value proves the
+	// absence of the key.
+	var args [][]byte
+	if len(res.Value) != 0 {
+		args = [][]byte{res.Value}
+	}
+	roots := make([][]byte, 0, len(res.ProofOps.Ops))
+	for _, op := range res.ProofOps.Ops {
+		operator, err := storetypes.CommitmentOpDecoder(op)
+		if err != nil {
+			return nil, nil, errorslib.Wrap(err, "failed to decode proof")
+		}
+		if args, err = operator.Run(args); err != nil {
+			return nil, nil, errorslib.Wrap(err, "failed to verify proof")
+		}
+		roots = append(roots, args[0])
+	}
+
+	bz, err := res.ProofOps.Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	return bz, roots, nil
+}
+
+// accountStoreKeyFor returns the key under which the account of the given address is stored in the
+// x/auth store.
+func accountStoreKeyFor(addr common.Address) []byte {
+	key := append([]byte{}, authtypes.AddressStoreKeyPrefix.Bytes()...)
+	return append(key, addr.Bytes()...)
+}
+
+// balanceStoreKeyFor returns the key under which the balance of the given denom of the given
+// address is stored in the x/bank store.
+func balanceStoreKeyFor(addr common.Address, denom string) []byte {
+	key := append([]byte{}, banktypes.BalancesPrefix.Bytes()...)
+	key = append(key, address.MustLengthPrefix(addr.Bytes())...)
+	return append(key, []byte(denom)...)
+}
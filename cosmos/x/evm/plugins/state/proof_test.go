@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package state_test
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	testutil "pkg.berachain.dev/polaris/cosmos/testing/utils"
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/state"
+	"pkg.berachain.dev/polaris/eth/common"
+	ethstate "pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/lib/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Proofs", func() {
+	var sp state.Plugin
+	var pp ethstate.ProofPlugin
+
+	BeforeEach(func() {
+		ctx, ak, bk, _ := testutil.SetupMinimalKeepers()
+		sp = state.NewPlugin(ak, bk, testutil.EvmKey, &mockConfigurationPlugin{}, nil)
+		sp.Reset(ctx.WithBlockHeight(7))
+		pp = utils.MustGetAs[ethstate.ProofPlugin](sp)
+	})
+
+	It("should error without a proof query function", func() {
+		_, err := pp.GetProof(alice)
+		Expect(err).To(HaveOccurred())
+		_, err = pp.GetStorageProof(alice, common.Hash{1})
+		Expect(err).To(HaveOccurred())
+		_, err = pp.GetProofRoot()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should query proofs of the state keys at the current height", func() {
+		var reqs []abci.RequestQuery
+		sp.SetProofQueryFn(func(req abci.RequestQuery) abci.ResponseQuery {
+			reqs = append(reqs, req)
+			return abci.ResponseQuery{Code: 1, Log: "not committed"}
+		})
+
+		_, err := pp.GetStorageProof(alice, common.Hash{1})
+		Expect(err).To(MatchError(ContainSubstring("not committed")))
+		Expect(reqs).To(HaveLen(1))
+		Expect(reqs[0].Path).To(Equal("/store/" + testutil.EvmKey.Name() + "/key"))
+		Expect(reqs[0].Data).To(Equal(state.SlotKeyFor(alice, common.Hash{1})))
+		Expect(reqs[0].Height).To(Equal(int64(7)))
+		Expect(reqs[0].Prove).To(BeTrue())
+	})
+
+	It("should error if no proof is returned", func() {
+		sp.SetProofQueryFn(func(req abci.RequestQuery) abci.ResponseQuery {
+			return abci.ResponseQuery{Value: []byte{1}}
+		})
+
+		_, err := pp.GetProof(alice)
+		Expect(err).To(MatchError(ContainSubstring("no proof returned")))
+	})
+})
@@ -20,6 +20,19 @@
 
 package txpool
 
+import (
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/txpool/mempool"
+	"pkg.berachain.dev/polaris/eth/core"
+)
+
 type ConfigurationPlugin interface {
 	GetEvmDenom() string
 }
+
+// StatePlugin is the state plugin of the host chain. Its live state is only read when the
+// mempool is reset, on the goroutine that executes the blocks; the mempool otherwise reads the
+// state at the latest committed height.
+type StatePlugin interface {
+	mempool.StateRetriever
+	GetLatestCommittedState() (core.StatePlugin, error)
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package txpool
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/ethereum/go-ethereum/rlp"
+
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+	ethlog "pkg.berachain.dev/polaris/eth/log"
+)
+
+const (
+	// FlagJournal is the app option that enables the on-disk journal of local transactions.
+	FlagJournal = "polaris.txpool.journal"
+
+	// JournalFileName is the name of the journal of local transactions in the Polaris data dir.
+	JournalFileName = "transactions.rlp"
+)
+
+const (
+	// journalPerm is the file permission of the journal.
+	journalPerm = 0o644
+	// journalDirPerm is the file permission of the directory of the journal, if it is created.
+	journalDirPerm = 0o750
+)
+
+// errNoActiveJournal is returned if a transaction is inserted into the journal before it was
+// loaded or rotated.
+var errNoActiveJournal = errors.New("no active journal")
+
+// journal is a rotating, RLP encoded, on-disk log of the locally submitted transactions, which
+// allows them to survive node restarts.
+type journal struct {
+	// path is the filesystem path of the journal
+	path string
+	// writer is the output stream that new transactions are appended to
+	writer io.WriteCloser
+}
+
+// newJournal returns a new journal at the given path.
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load parses the transactions of the journal and calls the given function for each of them.
+// A missing journal is not an error, as it is created on the first rotation. A journal that can
+// not be decoded to the end, e.g. because the node crashed while appending to it, is not an error
+// either: the transactions before the first undecodable one are kept, and the rest is dropped by
+// the next rotation.
+func (j *journal) load(add func(*coretypes.Transaction)) error {
+	input, err := os.Open(j.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	stream := rlp.NewStream(input, 0)
+	for loaded := 0; ; loaded++ {
+		tx := new(coretypes.Transaction)
+		if err = stream.Decode(tx); err != nil {
+			if !errors.Is(err, io.EOF) {
+				ethlog.Root().Warn(
+					"failed to decode local transaction journal", "loaded", loaded, "err", err,
+				)
+			}
+			return nil
+		}
+		add(tx)
+	}
+}
+
+// insert appends the given transaction to the journal.
+func (j *journal) insert(tx *coretypes.Transaction) error {
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+	return rlp.Encode(j.writer, tx)
+}
+
+// close closes the journal, if it is open. Transactions can not be inserted into a closed journal
+// until it is rotated.
+func (j *journal) close() error {
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return err
+}
+
+// rotate regenerates the journal with the given transactions, dropping the transactions that
+// are no longer tracked.
+func (j *journal) rotate(txs coretypes.Transactions) error {
+	// Close the current journal, if any is open.
+	if err := j.close(); err != nil {
+		return err
+	}
+
+	// Generate a new journal with the given transactions, and replace the current one.
+	//#nosec: G304 // the journal path is built from the node's data dir.
+	replacement, err := os.OpenFile(j.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, journalPerm)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		if err = rlp.Encode(replacement, tx); err != nil {
+			replacement.Close()
+			return err
+		}
+	}
+	if err = replacement.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(j.path+".new", j.path); err != nil {
+		return err
+	}
+
+	// Reopen the journal in append mode.
+	//#nosec: G304 // the journal path is built from the node's data dir.
+	sink, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, journalPerm)
+	if err != nil {
+		return err
+	}
+	j.writer = sink
+	return nil
+}
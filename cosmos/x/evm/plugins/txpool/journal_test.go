@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins/txpool/mempool"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	ethcrypto "pkg.berachain.dev/polaris/eth/crypto"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("journal", func() {
+	var (
+		key, _ = ethcrypto.GenerateEthKey()
+		signer = types.NewLondonSigner(big.NewInt(420))
+		path   string
+		txs    types.Transactions
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), JournalFileName)
+		txs = nil
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			txs = append(txs, types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+				Nonce: nonce,
+				Gas:   21000,
+			}))
+		}
+	})
+
+	load := func(j *journal) types.Transactions {
+		var loaded types.Transactions
+		Expect(j.load(func(tx *types.Transaction) { loaded = append(loaded, tx) })).To(Succeed())
+		return loaded
+	}
+
+	It("should load nothing from a missing journal", func() {
+		Expect(load(newJournal(path))).To(BeEmpty())
+	})
+
+	It("should not insert into an inactive journal", func() {
+		Expect(newJournal(path).insert(txs[0])).To(MatchError(errNoActiveJournal))
+	})
+
+	It("should load the rotated and inserted transactions", func() {
+		j := newJournal(path)
+		Expect(j.rotate(txs[:2])).To(Succeed())
+		Expect(j.insert(txs[2])).To(Succeed())
+
+		loaded := load(newJournal(path))
+		Expect(loaded).To(HaveLen(3))
+		for i, tx := range loaded {
+			Expect(tx.Hash()).To(Equal(txs[i].Hash()))
+		}
+
+		Expect(j.rotate(txs[2:])).To(Succeed())
+		loaded = load(newJournal(path))
+		Expect(loaded).To(HaveLen(1))
+		Expect(loaded[0].Hash()).To(Equal(txs[2].Hash()))
+	})
+
+	It("should keep the transactions before a truncated tail", func() {
+		j := newJournal(path)
+		Expect(j.rotate(txs)).To(Succeed())
+		Expect(j.close()).To(Succeed())
+		info, err := os.Stat(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.Truncate(path, info.Size()-1)).To(Succeed())
+
+		loaded := load(newJournal(path))
+		Expect(loaded).To(HaveLen(2))
+		for i, tx := range loaded {
+			Expect(tx.Hash()).To(Equal(txs[i].Hash()))
+		}
+	})
+
+	It("should not insert into a closed journal", func() {
+		j := newJournal(path)
+		Expect(j.rotate(txs[:1])).To(Succeed())
+		Expect(j.close()).To(Succeed())
+		Expect(j.close()).To(Succeed())
+		Expect(j.insert(txs[1])).To(MatchError(errNoActiveJournal))
+	})
+
+	It("should track the journaled transactions as local transactions on start", func() {
+		Expect(newJournal(path).rotate(txs)).To(Succeed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		p := NewPlugin(nil, mempool.NewEthTxPool(mempool.DefaultConfig(), nil)).(*plugin)
+		Expect(p.StartLocals(ctx, path)).To(Succeed())
+		sender, err := signer.Sender(txs[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.locals[sender]).To(HaveLen(3))
+		Expect(load(newJournal(path))).To(HaveLen(3))
+
+		Expect(p.CloseLocals()).To(Succeed())
+		Expect(p.journal).To(BeNil())
+	})
+
+	It("should create the directory of the journal on start", func() {
+		path = filepath.Join(GinkgoT().TempDir(), "data", "polaris", JournalFileName)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		p := NewPlugin(nil, mempool.NewEthTxPool(mempool.DefaultConfig(), nil)).(*plugin)
+		Expect(p.StartLocals(ctx, path)).To(Succeed())
+		Expect(path).To(BeARegularFile())
+	})
+})
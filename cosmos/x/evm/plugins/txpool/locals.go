@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package txpool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+	ethlog "pkg.berachain.dev/polaris/eth/log"
+)
+
+// resubmitInterval is the interval at which the local transactions that are missing from the
+// mempool are resubmitted, and at which the journal is regenerated.
+const resubmitInterval = time.Minute
+
+// StartLocals loads the local transactions of the journal at the given path, if not empty, and
+// starts resubmitting the local transactions that are missing from the mempool (e.g. because they
+// were dropped by CometBFT or the node restarted), until they are mined or their nonce is
+// superseded. The local transactions are resubmitted right away, and then periodically until the
+// given context is cancelled. If the journal can not be set up, the local transactions are still
+// resubmitted without a journal, and the error of the journal is returned.
+func (p *plugin) StartLocals(ctx context.Context, journalPath string) error {
+	var err error
+	if journalPath != "" {
+		err = p.loadJournal(journalPath)
+	}
+
+	go func() {
+		ticker := time.NewTicker(resubmitInterval)
+		defer ticker.Stop()
+		for {
+			p.resubmitLocals()
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return err
+}
+
+// loadJournal tracks the transactions of the journal at the given path as local transactions, and
+// regenerates the journal with them. The journal is dropped if it can not be regenerated.
+func (p *plugin) loadJournal(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), journalDirPerm); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	j := newJournal(path)
+	err := j.load(func(tx *coretypes.Transaction) {
+		p.mempool.MarkLocal(tx.Hash())
+		p.addLocal(tx)
+	})
+	if err == nil {
+		err = j.rotate(p.localTxs())
+	}
+	if err != nil {
+		return err
+	}
+	p.journal = j
+	return nil
+}
+
+// CloseLocals closes the journal of the local transactions, if any. The local transactions are no
+// longer journaled afterwards.
+func (p *plugin) CloseLocals() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.journal == nil {
+		return nil
+	}
+	err := p.journal.close()
+	p.journal = nil
+	return err
+}
+
+// trackLocal tracks the given locally submitted transaction, and appends it to the journal.
+func (p *plugin) trackLocal(tx *coretypes.Transaction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.addLocal(tx)
+	if p.journal != nil {
+		if err := p.journal.insert(tx); err != nil {
+			ethlog.Root().Warn("failed to journal local transaction", "hash", tx.Hash(), "err", err)
+		}
+	}
+}
+
+// resubmitLocals stops tracking the local transactions that were mined or superseded, rebroadcasts
+// the local transactions that are missing from the mempool, and regenerates the journal. The
+// nonces are read from the state at the latest committed height, as this runs concurrently with
+// the execution of blocks.
+func (p *plugin) resubmitLocals() {
+	p.mu.Lock()
+	if p.clientContext.Client == nil || p.sr == nil {
+		// The node is not ready to broadcast transactions yet.
+		p.mu.Unlock()
+		return
+	}
+	state, err := p.sr.committedState()
+	if err != nil || state == nil {
+		p.mu.Unlock()
+		ethlog.Root().Debug("failed to read the committed state", "err", err)
+		return
+	}
+	var missing coretypes.Transactions
+	for sender, txs := range p.locals {
+		next := state.GetNonce(sender)
+		for nonce, tx := range txs {
+			if nonce < next {
+				p.mempool.UnmarkLocal(tx.Hash())
+				delete(txs, nonce)
+			} else if p.mempool.GetTransaction(tx.Hash()) == nil {
+				missing = append(missing, tx)
+			}
+		}
+		if len(txs) == 0 {
+			delete(p.locals, sender)
+		}
+	}
+	if p.journal != nil {
+		if err := p.journal.rotate(p.localTxs()); err != nil {
+			ethlog.Root().Warn("failed to rotate local transaction journal", "err", err)
+		}
+	}
+	p.mu.Unlock()
+
+	// Rebroadcast in nonce order, so that the transactions of a sender are not rejected for
+	// having a nonce gap.
+	sort.Sort(coretypes.TxByNonce(missing))
+	for _, tx := range missing {
+		if err := p.broadcast(tx); err != nil {
+			ethlog.Root().Debug("failed to resubmit local transaction", "hash", tx.Hash(), "err", err)
+		}
+	}
+}
+
+// addLocal tracks the given local transaction, replacing any local transaction of the same sender
+// and nonce. It assumes that the caller holds the write lock.
+func (p *plugin) addLocal(tx *coretypes.Transaction) {
+	sender, err := coretypes.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+	if err != nil {
+		return
+	}
+	txs, ok := p.locals[sender]
+	if !ok {
+		txs = make(map[uint64]*coretypes.Transaction)
+		p.locals[sender] = txs
+	}
+	if replaced, found := txs[tx.Nonce()]; found {
+		p.mempool.UnmarkLocal(replaced.Hash())
+	}
+	txs[tx.Nonce()] = tx
+}
+
+// localTxs returns the tracked local transactions. It assumes that the caller holds the read
+// lock.
+func (p *plugin) localTxs() coretypes.Transactions {
+	var txs coretypes.Transactions
+	for _, senderTxs := range p.locals {
+		for _, tx := range senderTxs {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
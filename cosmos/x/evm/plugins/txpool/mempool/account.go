@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package mempool
+
+import (
+	"math/big"
+	"sort"
+
+	"pkg.berachain.dev/polaris/eth/common"
+)
+
+// account holds the transactions of a sender in the mempool, along with the number of its pending
+// and queued transactions, so that the mempool never has to walk all of its transactions to
+// classify a new one.
+type account struct {
+	sender common.Address
+
+	// txs indexes the transactions of the sender by nonce
+	txs map[uint64]*txEntry
+
+	// nonce is the state nonce of the sender, cached as of the last reset. If we are unable to
+	// read the state, it is the lowest nonce of the transactions of the sender.
+	nonce uint64
+
+	// pending is the number of pending transactions of the sender, which form a contiguous nonce
+	// sequence starting at nonce
+	pending int
+
+	// queued is the number of queued transactions of the sender, which have a nonce higher than
+	// the nonce of the last pending transaction plus one
+	queued int
+
+	// lastPending and lastQueued are the last pending and queued transactions of the sender, which
+	// are the ones that can be evicted without creating a nonce gap, or nil if they are local
+	lastPending *txEntry
+	lastQueued  *txEntry
+
+	// pendingIndex and queuedIndex are the indexes of the account in the pending and queued price
+	// heaps of the mempool, or -1 if the account is not in the heap
+	pendingIndex int
+	queuedIndex  int
+}
+
+// newAccount returns a new account of the given sender with the given state nonce.
+func newAccount(sender common.Address, nonce uint64) *account {
+	return &account{
+		sender:       sender,
+		txs:          make(map[uint64]*txEntry),
+		nonce:        nonce,
+		pendingIndex: -1,
+		queuedIndex:  -1,
+	}
+}
+
+// evictable returns the last pending, or queued, transaction of the account that may be evicted.
+func (acc *account) evictable(pending bool) *txEntry {
+	if pending {
+		return acc.lastPending
+	}
+	return acc.lastQueued
+}
+
+// heapIndex returns the index of the account in the pending, or queued, price heap.
+func (acc *account) heapIndex(pending bool) *int {
+	if pending {
+		return &acc.pendingIndex
+	}
+	return &acc.queuedIndex
+}
+
+// lowestNonce returns the lowest nonce of the given transactions, indexed by nonce.
+func lowestNonce(txs map[uint64]*txEntry) uint64 {
+	var lowest uint64
+	first := true
+	for nonce := range txs {
+		if first || nonce < lowest {
+			lowest, first = nonce, false
+		}
+	}
+	return lowest
+}
+
+// pendingTxs returns the pending transactions of the account, in nonce order.
+func (acc *account) pendingTxs() []*txEntry {
+	if acc.pending == 0 {
+		return nil
+	}
+	pending := make([]*txEntry, acc.pending)
+	for i := range pending {
+		pending[i] = acc.txs[acc.nonce+uint64(i)]
+	}
+	return pending
+}
+
+// queuedTxs returns the queued transactions of the account, in nonce order.
+func (acc *account) queuedTxs() []*txEntry {
+	if acc.queued == 0 {
+		return nil
+	}
+	queued := make([]*txEntry, 0, acc.queued)
+	for nonce, entry := range acc.txs {
+		if nonce > acc.nonce+uint64(acc.pending) {
+			queued = append(queued, entry)
+		}
+	}
+	sort.Slice(queued, func(i, j int) bool { return queued[i].tx.Nonce() < queued[j].tx.Nonce() })
+	return queued
+}
+
+// priceHeap implements heap.Interface, ordering accounts by ascending effective tip of their
+// evictable pending, or queued, transaction, and then by descending time at which it entered the
+// mempool. The root of the heap holds the cheapest transaction to evict.
+type priceHeap struct {
+	accounts []*account
+	pending  bool
+	baseFee  *big.Int
+}
+
+func (h *priceHeap) Len() int { return len(h.accounts) }
+
+func (h *priceHeap) Less(i, j int) bool {
+	a, b := h.accounts[i].evictable(h.pending), h.accounts[j].evictable(h.pending)
+	switch cmp := a.effectiveTip(h.baseFee).Cmp(b.effectiveTip(h.baseFee)); {
+	case cmp != 0:
+		return cmp < 0
+	default:
+		return a.added.After(b.added)
+	}
+}
+
+func (h *priceHeap) Swap(i, j int) {
+	h.accounts[i], h.accounts[j] = h.accounts[j], h.accounts[i]
+	*h.accounts[i].heapIndex(h.pending) = i
+	*h.accounts[j].heapIndex(h.pending) = j
+}
+
+func (h *priceHeap) Push(x any) {
+	acc := x.(*account) //nolint:errcheck // required by heap.Interface.
+	*acc.heapIndex(h.pending) = len(h.accounts)
+	h.accounts = append(h.accounts, acc)
+}
+
+func (h *priceHeap) Pop() any {
+	old := h.accounts
+	n := len(old)
+	acc := old[n-1]
+	old[n-1] = nil
+	h.accounts = old[:n-1]
+	*acc.heapIndex(h.pending) = -1
+	return acc
+}
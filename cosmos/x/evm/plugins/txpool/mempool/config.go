@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package mempool
+
+const (
+	// defaultPriceBump is the default minimum price bump, in percent, to replace a transaction.
+	defaultPriceBump = 10
+	// defaultAccountSlots is the default maximum number of pending transactions per account.
+	defaultAccountSlots = 16
+	// defaultGlobalSlots is the default maximum number of pending transactions.
+	defaultGlobalSlots = 4096 + 1024
+	// defaultAccountQueue is the default maximum number of queued transactions per account.
+	defaultAccountQueue = 64
+	// defaultGlobalQueue is the default maximum number of queued transactions.
+	defaultGlobalQueue = 1024
+)
+
+// Config is the configuration of the Ethereum transaction mempool.
+type Config struct {
+	// PriceBump is the minimum price bump, in percent, of both the fee cap and the tip cap of a
+	// transaction that replaces another transaction of the same sender and nonce.
+	PriceBump uint64
+
+	// AccountSlots is the maximum number of pending (executable) transactions per account.
+	AccountSlots uint64
+
+	// GlobalSlots is the maximum number of pending (executable) transactions of all accounts.
+	GlobalSlots uint64
+
+	// AccountQueue is the maximum number of queued (non-executable) transactions per account.
+	AccountQueue uint64
+
+	// GlobalQueue is the maximum number of queued (non-executable) transactions of all accounts.
+	GlobalQueue uint64
+}
+
+// DefaultConfig returns the default configuration of the Ethereum transaction mempool, which
+// matches the defaults of the Go-Ethereum transaction pool.
+func DefaultConfig() Config {
+	return Config{
+		PriceBump:    defaultPriceBump,
+		AccountSlots: defaultAccountSlots,
+		GlobalSlots:  defaultGlobalSlots,
+		AccountQueue: defaultAccountQueue,
+		GlobalQueue:  defaultGlobalQueue,
+	}
+}
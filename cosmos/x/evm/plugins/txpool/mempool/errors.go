@@ -24,4 +24,23 @@ import "errors"
 
 var (
 	ErrIncorrectTxType = errors.New("tx is not of type EthTransactionRequest")
+
+	// ErrAlreadyKnown is returned when a transaction is already in the mempool.
+	ErrAlreadyKnown = errors.New("already known")
+
+	// ErrReplaceUnderpriced is returned when a transaction replaces another transaction of the
+	// same sender and nonce without bumping its fees by the minimum price bump.
+	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
+
+	// ErrUnderpriced is returned when the mempool is full and a transaction does not pay a higher
+	// tip than the cheapest transaction that could be evicted.
+	ErrUnderpriced = errors.New("transaction underpriced")
+
+	// ErrAccountLimitExceeded is returned when the sender of a transaction already has the
+	// maximum number of pending, or queued, transactions in the mempool.
+	ErrAccountLimitExceeded = errors.New("account limit exceeded")
+
+	// ErrTxPoolOverflow is returned when the mempool has the maximum number of private
+	// transactions, which are never evicted.
+	ErrTxPoolOverflow = errors.New("txpool is full")
 )
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package mempool
+
+import (
+	"container/heap"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmempool "github.com/cosmos/cosmos-sdk/types/mempool"
+
+	"pkg.berachain.dev/polaris/eth/common"
+)
+
+// Compile-time assertion that the iterator implements the SDK mempool iterator.
+var _ sdkmempool.Iterator = (*iterator)(nil)
+
+// iterator iterates over the pending Ethereum transactions of the mempool by effective tip, in
+// nonce order for each sender, and then over the transactions of the Cosmos mempool.
+type iterator struct {
+	// heads holds the next transaction of each sender, ordered by effective tip
+	heads *txsByTip
+	// pending holds the remaining transactions of each sender, in nonce order
+	pending map[common.Address][]*txEntry
+	// cosmosIt iterates over the transactions of the Cosmos mempool
+	cosmosIt sdkmempool.Iterator
+}
+
+// newIterator returns an iterator over the given pending transactions and the given Cosmos
+// mempool iterator, or nil if there is nothing to iterate over.
+func newIterator(
+	pending map[common.Address][]*txEntry, baseFee *big.Int, cosmosIt sdkmempool.Iterator,
+) *iterator {
+	heads := &txsByTip{baseFee: baseFee}
+	for sender, txs := range pending {
+		heads.entries = append(heads.entries, txs[0])
+		pending[sender] = txs[1:]
+	}
+	if heads.Len() == 0 {
+		return nil
+	}
+	heap.Init(heads)
+
+	return &iterator{
+		heads:    heads,
+		pending:  pending,
+		cosmosIt: cosmosIt,
+	}
+}
+
+// Next implements sdkmempool.Iterator.
+func (it *iterator) Next() sdkmempool.Iterator {
+	if it.heads.Len() == 0 {
+		if it.cosmosIt = it.cosmosIt.Next(); it.cosmosIt == nil {
+			return nil
+		}
+		return it
+	}
+
+	// Replace the current transaction by the next transaction of the same sender, if any.
+	sender := it.heads.entries[0].sender
+	if txs := it.pending[sender]; len(txs) > 0 {
+		it.heads.entries[0], it.pending[sender] = txs[0], txs[1:]
+		heap.Fix(it.heads, 0)
+	} else {
+		heap.Pop(it.heads)
+	}
+
+	if it.heads.Len() == 0 && it.cosmosIt == nil {
+		return nil
+	}
+	return it
+}
+
+// Tx implements sdkmempool.Iterator.
+func (it *iterator) Tx() sdk.Tx {
+	if it.heads.Len() > 0 {
+		return it.heads.entries[0].sdkTx
+	}
+	return it.cosmosIt.Tx()
+}
+
+// txsByTip implements heap.Interface, ordering transactions by descending effective tip and then
+// by the time at which they entered the mempool.
+type txsByTip struct {
+	entries []*txEntry
+	baseFee *big.Int
+}
+
+func (s *txsByTip) Len() int { return len(s.entries) }
+
+func (s *txsByTip) Less(i, j int) bool {
+	switch cmp := s.entries[i].effectiveTip(s.baseFee).Cmp(s.entries[j].effectiveTip(s.baseFee)); {
+	case cmp != 0:
+		return cmp > 0
+	default:
+		return s.entries[i].added.Before(s.entries[j].added)
+	}
+}
+
+func (s *txsByTip) Swap(i, j int) { s.entries[i], s.entries[j] = s.entries[j], s.entries[i] }
+
+func (s *txsByTip) Push(x any) {
+	s.entries = append(s.entries, x.(*txEntry)) //nolint:errcheck // required by heap.Interface.
+}
+
+func (s *txsByTip) Pop() any {
+	old := s.entries
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	s.entries = old[:n-1]
+	return entry
+}
@@ -21,90 +21,633 @@
 package mempool
 
 import (
+	"container/heap"
 	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkmempool "github.com/cosmos/cosmos-sdk/types/mempool"
 
+	"github.com/ethereum/go-ethereum/event"
+
 	"pkg.berachain.dev/polaris/cosmos/x/evm/types"
 	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
 	coretypes "pkg.berachain.dev/polaris/eth/core/types"
 	"pkg.berachain.dev/polaris/lib/utils"
 )
 
-// EthTxPool is a mempool for Ethereum transactions. It wraps a
-// PriorityNonceMempool and caches transactions that are added to the mempool by
-// ethereum transaction hash.
+const (
+	// percent is the denominator of the price bump percentage.
+	percent = 100
+	// txEventBuffer is the number of NewTxsEvents that can be waiting to be sent to the
+	// subscribers, before new events are dropped.
+	txEventBuffer = 1024
+)
+
+// Compile-time assertion that the EthTxPool implements the SDK mempool.
+var _ sdkmempool.Mempool = (*EthTxPool)(nil)
+
+// EthTxPool is a mempool for Ethereum transactions. It orders the transactions of each sender by
+// nonce, splits them into pending (executable) and queued (waiting on a nonce gap to be filled)
+// transactions, supports replace-by-fee, and enforces per-account and global slot limits by
+// evicting the transactions with the lowest effective tip. Transactions that are not Ethereum
+// transactions are kept in the given Cosmos mempool.
 type EthTxPool struct {
-	sdkmempool.Mempool
+	// cfg is the configuration of the mempool
+	cfg Config
+
+	// cosmosPool holds the transactions that are not Ethereum transactions
+	cosmosPool sdkmempool.Mempool
+
+	// txs indexes the transactions of the mempool by ethereum transaction hash
+	txs map[common.Hash]*txEntry
+
+	// accounts indexes the transactions of the mempool by sender and nonce
+	accounts map[common.Address]*account
+
+	// numPending and numQueued are the number of pending and queued transactions in the mempool
+	numPending int
+	numQueued  int
+
+	// pendingPrices and queuedPrices order the accounts by the effective tip of their evictable
+	// pending and queued transactions, to find the cheapest transaction to evict in constant time
+	pendingPrices *priceHeap
+	queuedPrices  *priceHeap
 
-	// ethTxCache caches transactions that are added to the mempool
-	// so that they can be retrieved later
-	ethTxCache map[common.Hash]*coretypes.Transaction
+	// private and privateNonces index the private transactions of the mempool, by hash and by
+	// sender and nonce. They are kept apart from the other transactions, as they are only
+	// selected for the blocks proposed by this node and must not be exposed.
+	private       map[common.Hash]*txEntry
+	privateNonces map[common.Address]map[uint64]*txEntry
 
-	// // nonceCache caches the pending nonce by txhash
-	// nonceCache map[common.Address]*coretypes.Transaction
+	// locals holds the hashes of the locally submitted transactions, which are never evicted
+	locals map[common.Hash]struct{}
 
-	// // minedBlockCache caches the mined transaction by block hash
-	// minedBlockCache map[common.Hash][]*coretypes.Transaction
+	// sr is used to retrieve the state nonce of senders, in order to split the transactions of
+	// the mempool into pending and queued. As the mempool is read concurrently with the execution
+	// of blocks, it must not read from the live state.
+	sr StateRetriever
 
-	// blockNumberCache
+	// baseFee is the base fee used to compute the effective tip of the transactions
+	baseFee *big.Int
 
+	// txFeed is used to notify subscribers of transactions entering the mempool. The events are
+	// sent from txEvents by a separate goroutine, so that slow subscribers never block CheckTx
+	txFeed   event.Feed
+	scope    event.SubscriptionScope
+	txEvents chan core.NewTxsEvent
+	quit     chan struct{}
+
+	// mu protects the indexes, as the mempool is read by the rpc concurrently with ABCI
+	mu sync.RWMutex
+}
+
+// txEntry is an Ethereum transaction in the mempool.
+type txEntry struct {
+	tx     *coretypes.Transaction
+	sdkTx  sdk.Tx
+	sender common.Address
+	// added is the time at which the transaction entered the mempool
+	added time.Time
+	// pending is whether the transaction was pending when the mempool was last updated
+	pending bool
+	// maxBlockNumber is the block number by which a private transaction is dropped, if not 0
+	maxBlockNumber uint64
 }
 
-// New is called when the mempool is created.
-func NewEthTxPoolFrom(m sdkmempool.Mempool) *EthTxPool {
-	return &EthTxPool{
-		Mempool:    m,
-		ethTxCache: make(map[common.Hash]*coretypes.Transaction),
+// NewEthTxPool returns a new Ethereum transaction mempool with the given configuration, which
+// keeps the transactions that are not Ethereum transactions in the given Cosmos mempool.
+func NewEthTxPool(cfg Config, cosmosPool sdkmempool.Mempool) *EthTxPool {
+	etp := &EthTxPool{
+		cfg:           cfg,
+		cosmosPool:    cosmosPool,
+		txs:           make(map[common.Hash]*txEntry),
+		accounts:      make(map[common.Address]*account),
+		pendingPrices: &priceHeap{pending: true},
+		queuedPrices:  &priceHeap{},
+		private:       make(map[common.Hash]*txEntry),
+		privateNonces: make(map[common.Address]map[uint64]*txEntry),
+		locals:        make(map[common.Hash]struct{}),
+		txEvents:      make(chan core.NewTxsEvent, txEventBuffer),
+		quit:          make(chan struct{}),
 	}
+	go etp.eventLoop()
+	return etp
 }
 
-// Insert is called when a transaction is added to the mempool.
-func (etp *EthTxPool) Insert(ctx context.Context, tx sdk.Tx) error {
-	// Call the base mempool's Insert method
-	if err := etp.Mempool.Insert(ctx, tx); err != nil {
-		return err
+// Stop stops sending events to the subscribers of the mempool, and unsubscribes them.
+func (etp *EthTxPool) Stop() {
+	close(etp.quit)
+	etp.scope.Close()
+}
+
+// eventLoop sends the queued NewTxsEvents to the subscribers, until the mempool is stopped.
+func (etp *EthTxPool) eventLoop() {
+	for {
+		select {
+		case ev := <-etp.txEvents:
+			etp.txFeed.Send(ev)
+		case <-etp.quit:
+			return
+		}
+	}
+}
+
+// notify queues a NewTxsEvent of the given transactions for the subscribers. It never blocks: if
+// the subscribers fall too far behind, the event is dropped.
+func (etp *EthTxPool) notify(txs coretypes.Transactions) {
+	select {
+	case etp.txEvents <- core.NewTxsEvent{Txs: txs}:
+	default:
+	}
+}
+
+// SetStateRetriever sets the state retriever used to split pending and queued transactions. It
+// must be safe to read concurrently with the execution of blocks.
+func (etp *EthTxPool) SetStateRetriever(sr StateRetriever) {
+	etp.sr = sr
+}
+
+// SetBaseFee sets the base fee used to compute the effective tip of the transactions, which
+// orders the transactions selected from, and evicted from, the mempool.
+func (etp *EthTxPool) SetBaseFee(baseFee *big.Int) {
+	etp.mu.Lock()
+	defer etp.mu.Unlock()
+	etp.setBaseFee(baseFee)
+}
+
+// MarkLocal marks the transaction with the given hash as locally submitted, which exempts it from
+// being evicted when the mempool is full.
+func (etp *EthTxPool) MarkLocal(hash common.Hash) {
+	etp.mu.Lock()
+	defer etp.mu.Unlock()
+	etp.locals[hash] = struct{}{}
+	if entry, found := etp.txs[hash]; found {
+		etp.update(etp.accounts[entry.sender])
+	}
+}
+
+// UnmarkLocal unmarks the transaction with the given hash as locally submitted.
+func (etp *EthTxPool) UnmarkLocal(hash common.Hash) {
+	etp.mu.Lock()
+	defer etp.mu.Unlock()
+	delete(etp.locals, hash)
+	if entry, found := etp.txs[hash]; found {
+		etp.update(etp.accounts[entry.sender])
 	}
+}
 
-	// We want to cache
-	etr, ok := utils.GetAs[*types.EthTransactionRequest](tx.GetMsgs()[0])
+// Insert is called when a transaction is added to the mempool. Subscribers are notified of the
+// transactions that became pending, i.e. the inserted transaction if it is executable, along with
+// the queued transactions of the same sender that it made executable.
+func (etp *EthTxPool) Insert(ctx context.Context, tx sdk.Tx) error {
+	etr, ok := getEthTransactionRequest(tx)
 	if !ok {
-		return nil
+		if etp.cosmosPool == nil {
+			return ErrIncorrectTxType
+		}
+		return etp.cosmosPool.Insert(ctx, tx)
+	}
+
+	sender, err := etr.GetSender()
+	if err != nil {
+		return err
 	}
 
 	t := etr.AsTransaction()
-	etp.ethTxCache[t.Hash()] = t
+	etp.mu.Lock()
+	promoted, err := etp.add(&txEntry{tx: t, sdkTx: tx, sender: sender, added: time.Now()})
+	etp.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if len(promoted) > 0 {
+		etp.notify(promoted)
+	}
 	return nil
 }
 
+// Select returns an iterator over the pending and private Ethereum transactions of the mempool, by
+// effective tip and in nonce order for each sender, followed by the transactions of the Cosmos
+// mempool.
+func (etp *EthTxPool) Select(ctx context.Context, txs [][]byte) sdkmempool.Iterator {
+	var cosmosIt sdkmempool.Iterator
+	if etp.cosmosPool != nil {
+		cosmosIt = etp.cosmosPool.Select(ctx, txs)
+	}
+
+	etp.mu.RLock()
+	pending := etp.executable()
+	baseFee := etp.baseFee
+	etp.mu.RUnlock()
+
+	if it := newIterator(pending, baseFee, cosmosIt); it != nil {
+		return it
+	}
+	return cosmosIt
+}
+
+// CountTx returns the number of transactions in the mempool.
+func (etp *EthTxPool) CountTx() int {
+	etp.mu.RLock()
+	count := len(etp.txs) + len(etp.private)
+	etp.mu.RUnlock()
+
+	if etp.cosmosPool != nil {
+		count += etp.cosmosPool.CountTx()
+	}
+	return count
+}
+
+// Remove is called when a transaction is removed from the mempool.
+func (etp *EthTxPool) Remove(tx sdk.Tx) error {
+	etr, ok := getEthTransactionRequest(tx)
+	if !ok {
+		if etp.cosmosPool == nil {
+			return sdkmempool.ErrTxNotFound
+		}
+		return etp.cosmosPool.Remove(tx)
+	}
+
+	etp.mu.Lock()
+	defer etp.mu.Unlock()
+	hash := etr.AsTransaction().Hash()
+	if entry, found := etp.txs[hash]; found {
+		etp.remove(entry)
+		return nil
+	}
+	if entry, found := etp.private[hash]; found {
+		etp.removePrivate(entry)
+		return nil
+	}
+	return sdkmempool.ErrTxNotFound
+}
+
 // GetTx is called when a transaction is retrieved from the mempool.
 func (etp *EthTxPool) GetTransaction(hash common.Hash) *coretypes.Transaction {
-	return etp.ethTxCache[hash]
+	etp.mu.RLock()
+	defer etp.mu.RUnlock()
+	if entry, found := etp.txs[hash]; found {
+		return entry.tx
+	}
+	return nil
 }
 
 // GetPoolTransactions is called when the mempool is retrieved.
 func (etp *EthTxPool) GetPoolTransactions() coretypes.Transactions {
-	txs := make(coretypes.Transactions, 0, len(etp.ethTxCache))
-	for _, tx := range etp.ethTxCache {
-		txs = append(txs, tx)
+	etp.mu.RLock()
+	defer etp.mu.RUnlock()
+	txs := make(coretypes.Transactions, 0, len(etp.txs))
+	for _, entry := range etp.txs {
+		txs = append(txs, entry.tx)
 	}
 	return txs
 }
 
-// Remove is called when a transaction is removed from the mempool.
-func (etp *EthTxPool) Remove(tx sdk.Tx) error {
-	// Call the base mempool's Remove method
-	if err := etp.Mempool.Remove(tx); err != nil {
-		return err
+// Content returns the pending and queued transactions of the mempool, grouped by sender.
+func (etp *EthTxPool) Content() (
+	map[common.Address]coretypes.Transactions, map[common.Address]coretypes.Transactions,
+) {
+	etp.mu.RLock()
+	defer etp.mu.RUnlock()
+
+	pending := make(map[common.Address]coretypes.Transactions)
+	queued := make(map[common.Address]coretypes.Transactions)
+	for sender, acc := range etp.accounts {
+		if acc.pending > 0 {
+			pending[sender] = transactions(acc.pendingTxs())
+		}
+		if acc.queued > 0 {
+			queued[sender] = transactions(acc.queuedTxs())
+		}
 	}
+	return pending, queued
+}
 
-	// We want to cache this tx.
-	etr, ok := utils.GetAs[*types.EthTransactionRequest](tx)
-	if !ok {
+// ContentFrom returns the pending and queued transactions of the given sender in the mempool.
+func (etp *EthTxPool) ContentFrom(
+	addr common.Address,
+) (coretypes.Transactions, coretypes.Transactions) {
+	etp.mu.RLock()
+	defer etp.mu.RUnlock()
+	acc, found := etp.accounts[addr]
+	if !found {
+		return nil, nil
+	}
+	return transactions(acc.pendingTxs()), transactions(acc.queuedTxs())
+}
+
+// Stats returns the number of pending and queued transactions in the mempool.
+func (etp *EthTxPool) Stats() (int, int) {
+	etp.mu.RLock()
+	defer etp.mu.RUnlock()
+	return etp.numPending, etp.numQueued
+}
+
+// SubscribeNewTxsEvent registers a subscription of NewTxsEvent, which is emitted whenever
+// transactions become pending in the mempool.
+func (etp *EthTxPool) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
+	return etp.scope.Track(etp.txFeed.Subscribe(ch))
+}
+
+// GetNonce returns the next nonce of the given sender, taking into account the transactions in
+// the mempool: it is the highest nonce of the contiguous sequence of pending transactions of the
+// sender plus one, or the state nonce of the sender if it has no transactions in the mempool.
+func (etp *EthTxPool) GetNonce(addr common.Address) uint64 {
+	etp.mu.RLock()
+	defer etp.mu.RUnlock()
+
+	if acc, found := etp.accounts[addr]; found {
+		return acc.nonce + uint64(acc.pending)
+	}
+	if etp.sr != nil {
+		return etp.sr.GetNonce(addr)
+	}
+	return 0
+}
+
+// add adds the given transaction to the mempool, replacing the transaction of the same sender
+// and nonce if it is sufficiently underpriced, and evicting the cheapest transaction of another
+// sender if the mempool is full. It returns the transactions that became pending, in nonce order.
+// It assumes that the caller holds the write lock.
+func (etp *EthTxPool) add(entry *txEntry) (coretypes.Transactions, error) {
+	tx := entry.tx
+	if _, found := etp.txs[tx.Hash()]; found {
+		return nil, ErrAlreadyKnown
+	}
+	acc, found := etp.accounts[entry.sender]
+	if !found {
+		nonce := tx.Nonce()
+		if etp.sr != nil {
+			nonce = etp.sr.GetNonce(entry.sender)
+		}
+		acc = newAccount(entry.sender, nonce)
+	}
+	if etp.sr != nil && tx.Nonce() < acc.nonce {
+		return nil, fmt.Errorf("%w: address %v, tx: %d state: %d",
+			core.ErrNonceTooLow, entry.sender.Hex(), tx.Nonce(), acc.nonce)
+	}
+
+	// Replace the transaction of the same sender and nonce, if the fees are bumped enough.
+	if old, replaced := acc.txs[tx.Nonce()]; replaced {
+		if !etp.canReplace(old.tx, tx) {
+			return nil, ErrReplaceUnderpriced
+		}
+		delete(etp.txs, old.tx.Hash())
+		entry.pending = old.pending
+		acc.txs[tx.Nonce()], etp.txs[tx.Hash()] = entry, entry
+		etp.update(acc)
+		if entry.pending {
+			return coretypes.Transactions{tx}, nil
+		}
+		return nil, nil
+	}
+
+	// Enforce the per-account and global limits of the class (pending or queued) of the tx.
+	isPending := tx.Nonce() == acc.nonce+uint64(acc.pending) ||
+		(etp.sr == nil && tx.Nonce() < acc.nonce)
+	accountLimit, globalLimit := etp.cfg.AccountQueue, etp.cfg.GlobalQueue
+	accountCount, globalCount := acc.queued, etp.numQueued
+	if isPending {
+		accountLimit, globalLimit = etp.cfg.AccountSlots, etp.cfg.GlobalSlots
+		accountCount, globalCount = acc.pending, etp.numPending
+	}
+	if uint64(accountCount) >= accountLimit {
+		return nil, ErrAccountLimitExceeded
+	}
+	if uint64(globalCount) >= globalLimit {
+		victim := etp.cheapest(isPending, entry.sender)
+		if victim == nil || victim.effectiveTip(etp.baseFee).Cmp(entry.effectiveTip(etp.baseFee)) >= 0 {
+			return nil, ErrUnderpriced
+		}
+		etp.remove(victim)
+	}
+
+	if !found {
+		etp.accounts[entry.sender] = acc
+	}
+	if etp.sr == nil && tx.Nonce() < acc.nonce {
+		acc.nonce = tx.Nonce()
+	}
+	entry.pending = false
+	acc.txs[tx.Nonce()], etp.txs[tx.Hash()] = entry, entry
+	return etp.update(acc), nil
+}
+
+// Reset updates the mempool on top of the given new chain head, whose transactions were just
+// executed. It drops the mined transactions, the transactions with a nonce lower than the new
+// state nonce of their sender, and the transactions whose cost exceeds the new balance of their
+// sender. The queued transactions that became executable are promoted to pending, and subscribers
+// are notified of them. The private transactions that were not mined by their max block number
+// are dropped as well. The given base fee is the base fee of the block following the head, and
+// the given state retriever reads the state right after the head, which may not be committed yet.
+func (etp *EthTxPool) Reset(head *coretypes.Block, baseFee *big.Int, sr StateRetriever) {
+	etp.mu.Lock()
+	if sr == nil {
+		sr = etp.sr
+	}
+
+	// Drop the transactions that were included in the new head.
+	if head != nil {
+		for _, tx := range head.Transactions() {
+			if entry, found := etp.txs[tx.Hash()]; found {
+				etp.remove(entry)
+			}
+		}
+	}
+
+	// Drop the transactions that are stale or unaffordable, and promote the queued transactions
+	// that became executable.
+	var promoted coretypes.Transactions
+	for sender, acc := range etp.accounts {
+		if sr != nil {
+			nonce, balance := sr.GetNonce(sender), sr.GetBalance(sender)
+			acc.nonce = nonce
+			for n, entry := range acc.txs {
+				if n < nonce || entry.tx.Cost().Cmp(balance) > 0 {
+					delete(acc.txs, n)
+					delete(etp.txs, entry.tx.Hash())
+				}
+			}
+		}
+		promoted = append(promoted, etp.update(acc)...)
+	}
+	etp.resetPrivate(head, sr)
+	etp.setBaseFee(baseFee)
+	etp.mu.Unlock()
+
+	if len(promoted) > 0 {
+		etp.notify(promoted)
+	}
+}
+
+// canReplace returns whether the new transaction can replace the old transaction, i.e. both its
+// fee cap and its tip cap are higher than the old ones by at least the price bump.
+func (etp *EthTxPool) canReplace(old, tx *coretypes.Transaction) bool {
+	if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
+		return false
+	}
+	bump := new(big.Int).SetUint64(percent + etp.cfg.PriceBump)
+	thresholdFeeCap := new(big.Int).Div(new(big.Int).Mul(old.GasFeeCap(), bump), big.NewInt(percent))
+	thresholdTip := new(big.Int).Div(new(big.Int).Mul(old.GasTipCap(), bump), big.NewInt(percent))
+	return tx.GasFeeCapIntCmp(thresholdFeeCap) >= 0 && tx.GasTipCapIntCmp(thresholdTip) >= 0
+}
+
+// cheapest returns the transaction with the lowest effective tip, among the last pending, or
+// queued, transaction of every sender other than the given sender. Only the last transaction of a
+// sender is considered, so that evicting it does not create a nonce gap, and local transactions
+// are never considered. It assumes that the caller holds the read lock.
+func (etp *EthTxPool) cheapest(pending bool, exclude common.Address) *txEntry {
+	h := etp.queuedPrices
+	if pending {
+		h = etp.pendingPrices
+	}
+	if h.Len() == 0 {
 		return nil
 	}
+	if root := h.accounts[0]; root.sender != exclude {
+		return root.evictable(pending)
+	}
 
-	delete(etp.ethTxCache, etr.AsTransaction().Hash())
-	return nil
+	// The root is the excluded sender, so the cheapest other sender is one of its children.
+	cheapest := -1
+	for i := 1; i <= 2 && i < h.Len(); i++ {
+		if cheapest < 0 || h.Less(i, cheapest) {
+			cheapest = i
+		}
+	}
+	if cheapest < 0 {
+		return nil
+	}
+	return h.accounts[cheapest].evictable(pending)
+}
+
+// remove removes the given transaction from the indexes. It assumes that the caller holds the
+// write lock.
+func (etp *EthTxPool) remove(entry *txEntry) {
+	delete(etp.txs, entry.tx.Hash())
+	acc, found := etp.accounts[entry.sender]
+	if !found || acc.txs[entry.tx.Nonce()] != entry {
+		return
+	}
+	delete(acc.txs, entry.tx.Nonce())
+	if etp.sr == nil && entry.tx.Nonce() == acc.nonce && len(acc.txs) > 0 {
+		acc.nonce = lowestNonce(acc.txs)
+	}
+	etp.update(acc)
+}
+
+// update recomputes the pending and queued transactions of the given account after it changed,
+// along with the counters and the price heaps of the mempool, and drops the account once it is
+// empty. Only the transactions of the account are walked. Queued transactions are only promoted
+// to pending within the per-account and global slot limits; the executable transactions beyond
+// the limits stay queued until a later update. It returns the transactions that were promoted
+// from queued to pending, in nonce order. It assumes that the caller holds the write lock.
+func (etp *EthTxPool) update(acc *account) coretypes.Transactions {
+	var (
+		promoted                coretypes.Transactions
+		pending, queued         int
+		lastPending, lastQueued *txEntry
+		otherPending            = etp.numPending - acc.pending
+	)
+	for entry := acc.txs[acc.nonce]; entry != nil; entry = acc.txs[acc.nonce+uint64(pending)] {
+		if !entry.pending {
+			if uint64(pending) >= etp.cfg.AccountSlots ||
+				uint64(otherPending+pending) >= etp.cfg.GlobalSlots {
+				break
+			}
+			entry.pending = true
+			promoted = append(promoted, entry.tx)
+		}
+		lastPending = entry
+		pending++
+	}
+	for nonce, entry := range acc.txs {
+		if nonce >= acc.nonce+uint64(pending) {
+			entry.pending = false
+			queued++
+			if lastQueued == nil || nonce > lastQueued.tx.Nonce() {
+				lastQueued = entry
+			}
+		}
+	}
+
+	etp.numPending += pending - acc.pending
+	etp.numQueued += queued - acc.queued
+	acc.pending, acc.queued = pending, queued
+	acc.lastPending, acc.lastQueued = etp.evictable(lastPending), etp.evictable(lastQueued)
+	etp.fix(etp.pendingPrices, acc)
+	etp.fix(etp.queuedPrices, acc)
+
+	if len(acc.txs) == 0 {
+		delete(etp.accounts, acc.sender)
+	}
+	return promoted
+}
+
+// evictable returns the given transaction, unless it is a local transaction, which is never
+// evicted. It assumes that the caller holds the read lock.
+func (etp *EthTxPool) evictable(entry *txEntry) *txEntry {
+	if entry == nil {
+		return nil
+	}
+	if _, local := etp.locals[entry.tx.Hash()]; local {
+		return nil
+	}
+	return entry
+}
+
+// fix updates the position of the given account in the given price heap, adding it to, or
+// removing it from, the heap depending on whether it has a transaction that may be evicted. It
+// assumes that the caller holds the write lock.
+func (etp *EthTxPool) fix(h *priceHeap, acc *account) {
+	index := *acc.heapIndex(h.pending)
+	switch evictable := acc.evictable(h.pending) != nil; {
+	case !evictable && index >= 0:
+		heap.Remove(h, index)
+	case evictable && index >= 0:
+		heap.Fix(h, index)
+	case evictable:
+		heap.Push(h, acc)
+	}
+}
+
+// setBaseFee sets the base fee used to compute the effective tip of the transactions, and
+// reorders the price heaps accordingly. It assumes that the caller holds the write lock.
+func (etp *EthTxPool) setBaseFee(baseFee *big.Int) {
+	etp.baseFee = baseFee
+	etp.pendingPrices.baseFee, etp.queuedPrices.baseFee = baseFee, baseFee
+	heap.Init(etp.pendingPrices)
+	heap.Init(etp.queuedPrices)
+}
+
+// effectiveTip returns the effective tip of the transaction for the given base fee.
+func (entry *txEntry) effectiveTip(baseFee *big.Int) *big.Int {
+	return entry.tx.EffectiveGasTipValue(baseFee)
+}
+
+// getEthTransactionRequest returns the Ethereum transaction request of the given transaction, if
+// it is an Ethereum transaction.
+func getEthTransactionRequest(tx sdk.Tx) (*types.EthTransactionRequest, bool) {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return nil, false
+	}
+	return utils.GetAs[*types.EthTransactionRequest](msgs[0])
+}
+
+// transactions returns the Ethereum transactions of the given entries.
+func transactions(entries []*txEntry) coretypes.Transactions {
+	if len(entries) == 0 {
+		return nil
+	}
+	txs := make(coretypes.Transactions, len(entries))
+	for i, entry := range entries {
+		txs[i] = entry.tx
+	}
+	return txs
 }
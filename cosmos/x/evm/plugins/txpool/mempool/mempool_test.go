@@ -21,7 +21,16 @@
 package mempool
 
 import (
+	"context"
+	"math/big"
 	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/trie"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -32,11 +41,361 @@ func TestEthPool(t *testing.T) {
 	RunSpecs(t, "cosmos/x/evm/plugins/txpool/mempool")
 }
 
+// poorNonce is the nonce of the accounts that have no balance in the mock state retriever.
+const poorNonce = 100
+
+var (
+	alice = common.HexToAddress("0x1234")
+	bob   = common.HexToAddress("0x5678")
+	carol = common.HexToAddress("0x9abc")
+)
+
+// newEntry returns a mempool entry of a dynamic fee transaction of the given sender.
+func newEntry(sender common.Address, nonce uint64, tip, feeCap int64) *txEntry {
+	return &txEntry{
+		tx: coretypes.NewTx(&coretypes.DynamicFeeTx{
+			Nonce:     nonce,
+			Gas:       21000,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(feeCap),
+		}),
+		sender: sender,
+		added:  time.Now(),
+	}
+}
+
 var _ = Describe(`EthTxPool`, func() {
-	Describe(`EthTxPool`, func() {
-		It(`Should return a valid EthTxPool`, func() {
-			ethTxPool := EthTxPool{}
-			Expect(ethTxPool).ToNot(BeNil())
+	var etp *EthTxPool
+
+	BeforeEach(func() {
+		etp = NewEthTxPool(DefaultConfig(), nil)
+		etp.SetStateRetriever(mockStateRetriever{alice: 5})
+	})
+
+	AfterEach(func() {
+		etp.Stop()
+	})
+
+	Describe(`split`, func() {
+		It(`should split transactions by the state nonce`, func() {
+			for _, nonce := range []uint64{8, 6, 5} {
+				Expect(etp.add(newEntry(alice, nonce, 1, 1))).Error().To(Succeed())
+			}
+			pending, queued := etp.ContentFrom(alice)
+			Expect(pending).To(HaveLen(2))
+			Expect(pending[0].Nonce()).To(Equal(uint64(5)))
+			Expect(pending[1].Nonce()).To(Equal(uint64(6)))
+			Expect(queued).To(HaveLen(1))
+			Expect(queued[0].Nonce()).To(Equal(uint64(8)))
+		})
+
+		It(`should queue everything when the next nonce is missing`, func() {
+			Expect(etp.add(newEntry(alice, 7, 1, 1))).Error().To(Succeed())
+			pending, queued := etp.ContentFrom(alice)
+			Expect(pending).To(BeEmpty())
+			Expect(queued).To(HaveLen(1))
+		})
+	})
+
+	Describe(`add`, func() {
+		It(`should reject known and stale transactions`, func() {
+			entry := newEntry(alice, 5, 1, 1)
+			Expect(etp.add(entry)).Error().To(Succeed())
+			Expect(etp.add(entry)).Error().To(MatchError(ErrAlreadyKnown))
+			Expect(etp.add(newEntry(alice, 4, 1, 1))).Error().To(MatchError(core.ErrNonceTooLow))
+		})
+
+		It(`should return the transactions that became pending`, func() {
+			queued := newEntry(alice, 6, 1, 1)
+			Expect(etp.add(queued)).To(BeEmpty())
+			Expect(queued.pending).To(BeFalse())
+
+			pending := newEntry(alice, 5, 1, 1)
+			Expect(etp.add(pending)).To(Equal(coretypes.Transactions{pending.tx, queued.tx}))
+			Expect(queued.pending).To(BeTrue())
+		})
+
+		It(`should keep the pending and queued counts up to date`, func() {
+			e5, e6 := newEntry(alice, 5, 1, 1), newEntry(alice, 6, 1, 1)
+			for _, entry := range []*txEntry{e5, e6, newEntry(alice, 7, 1, 1), newEntry(bob, 3, 1, 1)} {
+				Expect(etp.add(entry)).Error().To(Succeed())
+			}
+			numPending, numQueued := etp.Stats()
+			Expect(numPending).To(Equal(3))
+			Expect(numQueued).To(Equal(1))
+
+			etp.remove(e6)
+			numPending, numQueued = etp.Stats()
+			Expect(numPending).To(Equal(1))
+			Expect(numQueued).To(Equal(2))
+
+			etp.remove(e5)
+			numPending, numQueued = etp.Stats()
+			Expect(numPending).To(BeZero())
+			Expect(numQueued).To(Equal(2))
+		})
+
+		It(`should only replace a transaction with a sufficient price bump`, func() {
+			Expect(etp.add(newEntry(alice, 5, 100, 100))).Error().To(Succeed())
+			Expect(etp.add(newEntry(alice, 5, 109, 200))).Error().To(MatchError(ErrReplaceUnderpriced))
+			Expect(etp.add(newEntry(alice, 5, 200, 109))).Error().To(MatchError(ErrReplaceUnderpriced))
+
+			replacement := newEntry(alice, 5, 110, 110)
+			Expect(etp.add(replacement)).Error().To(Succeed())
+			Expect(etp.CountTx()).To(Equal(1))
+			Expect(etp.GetTransaction(replacement.tx.Hash())).To(Equal(replacement.tx))
+		})
+
+		It(`should enforce the per-account limits`, func() {
+			etp.cfg.AccountSlots, etp.cfg.AccountQueue = 1, 1
+			Expect(etp.add(newEntry(alice, 5, 1, 1))).Error().To(Succeed())
+			Expect(etp.add(newEntry(alice, 6, 1, 1))).Error().To(MatchError(ErrAccountLimitExceeded))
+			Expect(etp.add(newEntry(alice, 7, 1, 1))).Error().To(Succeed())
+			Expect(etp.add(newEntry(alice, 8, 1, 1))).Error().To(MatchError(ErrAccountLimitExceeded))
+		})
+
+		It(`should evict the cheapest transaction when the mempool is full`, func() {
+			etp.cfg.GlobalSlots = 2
+			cheap := newEntry(alice, 5, 1, 10)
+			Expect(etp.add(cheap)).Error().To(Succeed())
+			Expect(etp.add(newEntry(bob, 0, 3, 10))).Error().To(Succeed())
+
+			Expect(etp.add(newEntry(carol, 0, 1, 10))).Error().To(MatchError(ErrUnderpriced))
+			Expect(etp.add(newEntry(carol, 0, 2, 10))).Error().To(Succeed())
+			Expect(etp.GetTransaction(cheap.tx.Hash())).To(BeNil())
+			Expect(etp.Stats()).To(Equal(2))
+		})
+	})
+
+	Describe(`MarkLocal`, func() {
+		It(`should never evict local transactions`, func() {
+			etp.cfg.GlobalSlots = 2
+			local := newEntry(alice, 5, 1, 10)
+			etp.MarkLocal(local.tx.Hash())
+			Expect(etp.add(local)).Error().To(Succeed())
+			Expect(etp.add(newEntry(bob, 0, 3, 10))).Error().To(Succeed())
+
+			Expect(etp.add(newEntry(carol, 0, 2, 10))).Error().To(MatchError(ErrUnderpriced))
+			Expect(etp.add(newEntry(carol, 0, 4, 10))).Error().To(Succeed())
+			Expect(etp.GetTransaction(local.tx.Hash())).To(Equal(local.tx))
+
+			etp.UnmarkLocal(local.tx.Hash())
+			Expect(etp.add(newEntry(bob, 0, 2, 10))).Error().To(Succeed())
+			Expect(etp.GetTransaction(local.tx.Hash())).To(BeNil())
+		})
+	})
+
+	Describe(`Select`, func() {
+		It(`should order pending transactions by effective tip and nonce`, func() {
+			etp.SetBaseFee(big.NewInt(10))
+			a0, a1 := newEntry(alice, 5, 1, 100), newEntry(alice, 6, 5, 100)
+			b0 := newEntry(bob, 0, 50, 13)
+			Expect(etp.add(a0)).Error().To(Succeed())
+			Expect(etp.add(a1)).Error().To(Succeed())
+			Expect(etp.add(b0)).Error().To(Succeed())
+			Expect(etp.add(newEntry(bob, 2, 100, 100))).Error().To(Succeed())
+
+			it := etp.Select(context.Background(), nil).(*iterator)
+			var order []*txEntry
+			for it != nil {
+				order = append(order, it.heads.entries[0])
+				next := it.Next()
+				if next == nil {
+					break
+				}
+				it = next.(*iterator)
+			}
+			Expect(order).To(Equal([]*txEntry{b0, a0, a1}))
+		})
+
+		It(`should return nil for an empty mempool`, func() {
+			Expect(etp.Select(context.Background(), nil)).To(BeNil())
+		})
+	})
+
+	Describe(`Reset`, func() {
+		It(`should drop mined, stale and unaffordable txs and promote queued txs`, func() {
+			sr := mockStateRetriever{alice: 5, bob: poorNonce}
+			etp.SetStateRetriever(sr)
+			mined, stale := newEntry(alice, 6, 1, 1), newEntry(alice, 5, 1, 1)
+			queued := newEntry(alice, 8, 1, 1)
+			for _, entry := range []*txEntry{stale, mined, queued, newEntry(bob, poorNonce, 1, 1)} {
+				Expect(etp.add(entry)).Error().To(Succeed())
+			}
+			Expect(queued.pending).To(BeFalse())
+
+			ch := make(chan core.NewTxsEvent, 1)
+			sub := etp.SubscribeNewTxsEvent(ch)
+			defer sub.Unsubscribe()
+
+			// The txs of alice with nonces 5 to 7 are mined, and bob can no longer pay for the tx.
+			sr[alice] = 8
+			etp.Reset(coretypes.NewBlock(
+				&coretypes.Header{}, coretypes.Transactions{mined.tx}, nil, nil, trie.NewStackTrie(nil),
+			), big.NewInt(1), sr)
+
+			Expect(etp.CountTx()).To(Equal(1))
+			Expect(etp.GetTransaction(queued.tx.Hash())).To(Equal(queued.tx))
+			Expect(etp.Stats()).To(Equal(1))
+			Expect(queued.pending).To(BeTrue())
+			Expect(<-ch).To(Equal(core.NewTxsEvent{Txs: coretypes.Transactions{queued.tx}}))
+		})
+	})
+
+	Describe(`promotion`, func() {
+		It(`should only promote queued txs within the per-account limit`, func() {
+			etp.cfg.AccountSlots = 2
+			e6, e7 := newEntry(alice, 6, 1, 1), newEntry(alice, 7, 1, 1)
+			Expect(etp.add(e6)).Error().To(Succeed())
+			Expect(etp.add(e7)).Error().To(Succeed())
+
+			e5 := newEntry(alice, 5, 1, 1)
+			Expect(etp.add(e5)).To(Equal(coretypes.Transactions{e5.tx, e6.tx}))
+			Expect(e7.pending).To(BeFalse())
+			numPending, numQueued := etp.Stats()
+			Expect(numPending).To(Equal(2))
+			Expect(numQueued).To(Equal(1))
+			Expect(etp.GetNonce(alice)).To(Equal(uint64(7)))
+
+			// Once the tx with nonce 5 is mined, the tx with nonce 7 fits in the limit.
+			etp.Reset(nil, big.NewInt(1), mockStateRetriever{alice: 6})
+			Expect(e7.pending).To(BeTrue())
+			Expect(etp.Stats()).To(Equal(2))
+		})
+
+		It(`should only promote queued txs within the global limit`, func() {
+			etp.cfg.GlobalSlots = 2
+			Expect(etp.add(newEntry(bob, 0, 1, 1))).Error().To(Succeed())
+			e6 := newEntry(alice, 6, 1, 1)
+			Expect(etp.add(e6)).Error().To(Succeed())
+
+			e5 := newEntry(alice, 5, 1, 1)
+			Expect(etp.add(e5)).To(Equal(coretypes.Transactions{e5.tx}))
+			Expect(e6.pending).To(BeFalse())
+			numPending, numQueued := etp.Stats()
+			Expect(numPending).To(Equal(2))
+			Expect(numQueued).To(Equal(1))
+		})
+	})
+
+	Describe(`SubscribeNewTxsEvent`, func() {
+		It(`should not block on slow subscribers`, func() {
+			ch := make(chan core.NewTxsEvent)
+			sub := etp.SubscribeNewTxsEvent(ch)
+			defer sub.Unsubscribe()
+
+			tx := newEntry(alice, 5, 1, 1).tx
+			for i := 0; i < 2*txEventBuffer; i++ {
+				etp.notify(coretypes.Transactions{tx})
+			}
+			Eventually(ch).Should(Receive(Equal(core.NewTxsEvent{Txs: coretypes.Transactions{tx}})))
+		})
+	})
+
+	Describe(`private transactions`, func() {
+		newHead := func(number int64) *coretypes.Block {
+			return coretypes.NewBlock(
+				&coretypes.Header{Number: big.NewInt(number)}, nil, nil, nil, trie.NewStackTrie(nil),
+			)
+		}
+
+		It(`should only expose private transactions to the block proposal`, func() {
+			public, private := newEntry(alice, 5, 1, 1), newEntry(alice, 6, 1, 1)
+			Expect(etp.add(public)).Error().To(Succeed())
+			Expect(etp.addPrivate(private)).To(Succeed())
+			Expect(etp.addPrivate(private)).To(MatchError(ErrAlreadyKnown))
+			Expect(etp.addPrivate(newEntry(bob, 0, 1, 1))).To(Succeed())
+
+			Expect(etp.GetTransaction(private.tx.Hash())).To(BeNil())
+			Expect(etp.GetPoolTransactions()).To(Equal(coretypes.Transactions{public.tx}))
+			pending, queued := etp.Content()
+			Expect(pending).To(Equal(map[common.Address]coretypes.Transactions{alice: {public.tx}}))
+			Expect(queued).To(BeEmpty())
+			Expect(etp.Stats()).To(Equal(1))
+
+			executable := etp.Executable()
+			Expect(executable).To(HaveLen(2))
+			Expect(executable[alice]).To(Equal(coretypes.Transactions{public.tx, private.tx}))
+			Expect(etp.CountTx()).To(Equal(3))
+		})
+
+		It(`should enforce the per-account and global limits`, func() {
+			etp.cfg.AccountSlots, etp.cfg.GlobalSlots = 1, 2
+			first := newEntry(alice, 5, 1, 1)
+			Expect(etp.addPrivate(first)).To(Succeed())
+			Expect(etp.addPrivate(newEntry(alice, 6, 1, 1))).To(MatchError(ErrAccountLimitExceeded))
+			Expect(etp.addPrivate(newEntry(alice, 5, 2, 2))).To(Succeed())
+			Expect(etp.addPrivate(newEntry(bob, 0, 1, 1))).To(Succeed())
+			Expect(etp.addPrivate(newEntry(carol, 0, 1, 1))).To(MatchError(ErrTxPoolOverflow))
+			Expect(etp.CountTx()).To(Equal(2))
+		})
+
+		It(`should drop a private transaction by hash`, func() {
+			entry := newEntry(alice, 5, 1, 1)
+			Expect(etp.add(newEntry(alice, 6, 1, 1))).Error().To(Succeed())
+			Expect(etp.DropPrivate(entry.tx.Hash())).To(BeFalse())
+			Expect(etp.addPrivate(entry)).To(Succeed())
+			Expect(etp.DropPrivate(entry.tx.Hash())).To(BeTrue())
+			Expect(etp.Executable()).To(BeEmpty())
+			Expect(etp.CountTx()).To(Equal(1))
+		})
+
+		It(`should drop the private transactions that are not mined by their max block number`,
+			func() {
+				entry := newEntry(alice, 5, 1, 1)
+				entry.maxBlockNumber = 2
+				Expect(etp.addPrivate(entry)).To(Succeed())
+				etp.Reset(newHead(1), big.NewInt(1), nil)
+				Expect(etp.CountTx()).To(Equal(1))
+				etp.Reset(newHead(2), big.NewInt(1), nil)
+				Expect(etp.CountTx()).To(BeZero())
+			})
+	})
+
+	Describe(`GetNonce`, func() {
+		BeforeEach(func() {
+			etp.SetStateRetriever(mockStateRetriever{alice: 2})
+		})
+
+		It(`should fall back to the state nonce`, func() {
+			Expect(etp.GetNonce(alice)).To(Equal(uint64(2)))
+		})
+
+		It(`should return the highest contiguous pending nonce + 1`, func() {
+			e3 := newEntry(alice, 3, 1, 1)
+			Expect(etp.add(newEntry(alice, 2, 1, 1))).Error().To(Succeed())
+			Expect(etp.add(e3)).Error().To(Succeed())
+			Expect(etp.add(newEntry(alice, 5, 1, 1))).Error().To(Succeed())
+			Expect(etp.GetNonce(alice)).To(Equal(uint64(4)))
+
+			Expect(etp.add(newEntry(alice, 4, 1, 1))).Error().To(Succeed())
+			Expect(etp.GetNonce(alice)).To(Equal(uint64(6)))
+
+			etp.remove(e3)
+			Expect(etp.GetNonce(alice)).To(Equal(uint64(3)))
+			Expect(etp.accounts[alice].txs).To(HaveLen(3))
+		})
+
+		It(`should return the tracked nonce without a state retriever`, func() {
+			etp.SetStateRetriever(nil)
+			Expect(etp.GetNonce(alice)).To(BeZero())
+			Expect(etp.add(newEntry(alice, 7, 1, 1))).Error().To(Succeed())
+			Expect(etp.add(newEntry(alice, 8, 1, 1))).Error().To(Succeed())
+			Expect(etp.GetNonce(alice)).To(Equal(uint64(9)))
 		})
 	})
 })
+
+type mockStateRetriever map[common.Address]uint64
+
+func (m mockStateRetriever) GetNonce(addr common.Address) uint64 {
+	return m[addr]
+}
+
+// GetBalance returns a large balance for every account, unless the account has a nonce of 100.
+func (m mockStateRetriever) GetBalance(addr common.Address) *big.Int {
+	if m[addr] == poorNonce {
+		return big.NewInt(0)
+	}
+	return big.NewInt(1e18)
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package mempool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+	coretypes "pkg.berachain.dev/polaris/eth/core/types"
+)
+
+// InsertPrivate adds the given Ethereum transaction to the private transactions of the mempool,
+// which are only selected for the blocks proposed by this node. Unlike the transactions added
+// with Insert, the subscribers of the mempool are not notified of private transactions, and they
+// are hidden from GetTransaction, GetPoolTransactions, Content, ContentFrom and Stats, so that
+// they do not leak before they are mined. The transaction is dropped once the block with the
+// given number is reached, unless it is 0.
+func (etp *EthTxPool) InsertPrivate(_ context.Context, tx sdk.Tx, maxBlockNumber uint64) error {
+	etr, ok := getEthTransactionRequest(tx)
+	if !ok {
+		return ErrIncorrectTxType
+	}
+	sender, err := etr.GetSender()
+	if err != nil {
+		return err
+	}
+
+	etp.mu.Lock()
+	defer etp.mu.Unlock()
+	return etp.addPrivate(&txEntry{
+		tx:             etr.AsTransaction(),
+		sdkTx:          tx,
+		sender:         sender,
+		added:          time.Now(),
+		maxBlockNumber: maxBlockNumber,
+	})
+}
+
+// DropPrivate removes the private transaction with the given hash from the mempool. It returns
+// whether the transaction was a private transaction of the mempool.
+func (etp *EthTxPool) DropPrivate(hash common.Hash) bool {
+	etp.mu.Lock()
+	defer etp.mu.Unlock()
+	entry, found := etp.private[hash]
+	if found {
+		etp.removePrivate(entry)
+	}
+	return found
+}
+
+// Executable returns the pending transactions of the mempool along with its executable private
+// transactions, in nonce order for each sender. It is used to build the blocks proposed by this
+// node.
+func (etp *EthTxPool) Executable() map[common.Address]coretypes.Transactions {
+	etp.mu.RLock()
+	defer etp.mu.RUnlock()
+	executable := etp.executable()
+	txs := make(map[common.Address]coretypes.Transactions, len(executable))
+	for sender, entries := range executable {
+		txs[sender] = transactions(entries)
+	}
+	return txs
+}
+
+// addPrivate adds the given private transaction to the mempool, replacing the private transaction
+// of the same sender and nonce if it is sufficiently underpriced. As private transactions are
+// never evicted, the private transactions of a sender, and of all senders, are limited by the
+// per-account and global slot limits. It assumes that the caller holds the write lock.
+func (etp *EthTxPool) addPrivate(entry *txEntry) error {
+	tx := entry.tx
+	if _, found := etp.txs[tx.Hash()]; found {
+		return ErrAlreadyKnown
+	}
+	if _, found := etp.private[tx.Hash()]; found {
+		return ErrAlreadyKnown
+	}
+	if etp.sr != nil {
+		next := etp.sr.GetNonce(entry.sender)
+		if acc, found := etp.accounts[entry.sender]; found {
+			next = acc.nonce
+		}
+		if tx.Nonce() < next {
+			return fmt.Errorf("%w: address %v, tx: %d state: %d",
+				core.ErrNonceTooLow, entry.sender.Hex(), tx.Nonce(), next)
+		}
+	}
+
+	txs := etp.privateNonces[entry.sender]
+	if old, replaced := txs[tx.Nonce()]; replaced {
+		if !etp.canReplace(old.tx, tx) {
+			return ErrReplaceUnderpriced
+		}
+		delete(etp.private, old.tx.Hash())
+	} else {
+		if uint64(len(txs)) >= etp.cfg.AccountSlots {
+			return ErrAccountLimitExceeded
+		}
+		if uint64(len(etp.private)) >= etp.cfg.GlobalSlots {
+			return ErrTxPoolOverflow
+		}
+	}
+	if txs == nil {
+		txs = make(map[uint64]*txEntry)
+		etp.privateNonces[entry.sender] = txs
+	}
+	txs[tx.Nonce()] = entry
+	etp.private[tx.Hash()] = entry
+	return nil
+}
+
+// removePrivate removes the given private transaction from the indexes. It assumes that the
+// caller holds the write lock.
+func (etp *EthTxPool) removePrivate(entry *txEntry) {
+	delete(etp.private, entry.tx.Hash())
+	if txs := etp.privateNonces[entry.sender]; txs[entry.tx.Nonce()] == entry {
+		delete(txs, entry.tx.Nonce())
+		if len(txs) == 0 {
+			delete(etp.privateNonces, entry.sender)
+		}
+	}
+}
+
+// resetPrivate drops the private transactions that were included in the given head, have a nonce
+// lower than the state nonce of their sender, or were not included by their max block number. It
+// assumes that the caller holds the write lock.
+func (etp *EthTxPool) resetPrivate(head *coretypes.Block, sr StateRetriever) {
+	if head != nil {
+		for _, tx := range head.Transactions() {
+			if entry, found := etp.private[tx.Hash()]; found {
+				etp.removePrivate(entry)
+			}
+		}
+	}
+	for sender, txs := range etp.privateNonces {
+		var nonce uint64
+		if sr != nil {
+			nonce = sr.GetNonce(sender)
+		}
+		for n, entry := range txs {
+			expired := head != nil && entry.maxBlockNumber != 0 &&
+				head.NumberU64() >= entry.maxBlockNumber
+			if n < nonce || expired {
+				etp.removePrivate(entry)
+			}
+		}
+	}
+}
+
+// executable returns the pending transactions of the mempool merged with its private
+// transactions, in nonce order for each sender. A private transaction takes precedence over the
+// transaction of the same sender and nonce that was gossiped, as it is the one that was submitted
+// to this node. It assumes that the caller holds the read lock.
+func (etp *EthTxPool) executable() map[common.Address][]*txEntry {
+	pending := make(map[common.Address][]*txEntry, len(etp.accounts))
+	for sender, acc := range etp.accounts {
+		if _, found := etp.privateNonces[sender]; found {
+			continue
+		}
+		if txs := acc.pendingTxs(); len(txs) > 0 {
+			pending[sender] = txs
+		}
+	}
+
+	for sender, private := range etp.privateNonces {
+		acc, found := etp.accounts[sender]
+		var next uint64
+		switch {
+		case found:
+			next = acc.nonce
+		case etp.sr != nil:
+			next = etp.sr.GetNonce(sender)
+		default:
+			next = lowestNonce(private)
+		}
+
+		var txs []*txEntry
+		for {
+			entry := private[next]
+			if entry == nil && found {
+				entry = acc.txs[next]
+			}
+			if entry == nil {
+				break
+			}
+			txs = append(txs, entry)
+			next++
+		}
+		if len(txs) > 0 {
+			pending[sender] = txs
+		}
+	}
+	return pending
+}
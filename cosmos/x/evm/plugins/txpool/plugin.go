@@ -21,12 +21,19 @@
 package txpool
 
 import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
 	errorsmod "cosmossdk.io/errors"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/ethereum/go-ethereum/event"
+
 	"pkg.berachain.dev/polaris/cosmos/x/evm/plugins"
 	mempool "pkg.berachain.dev/polaris/cosmos/x/evm/plugins/txpool/mempool"
 	"pkg.berachain.dev/polaris/eth/common"
@@ -38,11 +45,33 @@ import (
 // Plugin represents the transaction pool plugin.
 var _ Plugin = (*plugin)(nil)
 
+// errAnteHandlerNotSet is returned when a private transaction is sent before the ante handler,
+// which validates it, is set.
+var errAnteHandlerNotSet = errors.New(
+	"private transactions are not accepted before the ante handler is set",
+)
+
 // Plugin represents the transaction pool plugin.
 type Plugin interface {
 	core.TxPoolPlugin
 	plugins.BaseCosmosPolaris
 	SetClientContext(client.Context)
+	SetStatePlugin(StatePlugin)
+	// SetQueryContextFn sets the function that returns the query context at the given height,
+	// which is used to validate the private transactions against the latest committed state.
+	SetQueryContextFn(fn func(height int64, prove bool) (sdk.Context, error))
+	// SetAnteHandler sets the ante handler that validates the private transactions, as they do
+	// not go through CheckTx.
+	SetAnteHandler(sdk.AnteHandler)
+	// StartLocals starts resubmitting the locally submitted transactions until they are mined,
+	// journaling them at the given path, if not empty, so that they survive node restarts. The
+	// resubmission stops when the given context is cancelled.
+	StartLocals(ctx context.Context, journalPath string) error
+	// CloseLocals closes the journal of the local transactions, if any.
+	CloseLocals() error
+	// Reset updates the transaction pool on top of the given new chain head, with the given base
+	// fee of the next block.
+	Reset(*coretypes.Block, *big.Int)
 }
 
 // plugin represents the transaction pool plugin.
@@ -50,6 +79,22 @@ type plugin struct {
 	mempool       *mempool.EthTxPool
 	clientContext client.Context
 	cp            ConfigurationPlugin
+	sp            StatePlugin
+	// sr reads the state at the latest committed height, for the mempool and the local transactions
+	sr *committedStateRetriever
+
+	// locals holds the locally submitted transactions, by sender and nonce, until they are mined
+	// or their nonce is superseded
+	locals map[common.Address]map[uint64]*coretypes.Transaction
+	// journal is the optional on-disk journal of the local transactions
+	journal *journal
+
+	// getQueryContext and ante are used to validate the private transactions
+	getQueryContext func(height int64, prove bool) (sdk.Context, error)
+	ante            sdk.AnteHandler
+
+	// mu protects the client context, the ante handler, the local transactions and the journal
+	mu sync.RWMutex
 }
 
 // NewPlugin returns a new transaction pool plugin.
@@ -57,22 +102,41 @@ func NewPlugin(cp ConfigurationPlugin, ethTxMempool *mempool.EthTxPool) Plugin {
 	return &plugin{
 		mempool: ethTxMempool,
 		cp:      cp,
+		locals:  make(map[common.Address]map[uint64]*coretypes.Transaction),
 	}
 }
 
 // SendTx sends a transaction to the transaction pool. It takes in a signed
 // ethereum transaction from the rpc backend and wraps it in a Cosmos
 // transaction. The Cosmos transaction is then broadcasted to the network.
+// The transaction is tracked as a local transaction, which is resubmitted
+// until it is mined and is never evicted from the mempool.
 func (p *plugin) SendTx(signedEthTx *coretypes.Transaction) error {
+	p.mempool.MarkLocal(signedEthTx.Hash())
+	if err := p.broadcast(signedEthTx); err != nil {
+		p.mempool.UnmarkLocal(signedEthTx.Hash())
+		return err
+	}
+	p.trackLocal(signedEthTx)
+	return nil
+}
+
+// broadcast wraps the given transaction in a Cosmos transaction, and broadcasts it to the
+// network.
+func (p *plugin) broadcast(signedEthTx *coretypes.Transaction) error {
+	p.mu.RLock()
+	clientContext := p.clientContext
+	p.mu.RUnlock()
+
 	// Serialize the transaction to Bytes
-	txBytes, err := NewSerializer(p.cp, p.clientContext).Serialize(signedEthTx)
+	txBytes, err := NewSerializer(p.cp, clientContext).Serialize(signedEthTx)
 	if err != nil {
 		return errorslib.Wrap(err, "failed to serialize transaction")
 	}
 
 	// Send the transaction to the CometBFT mempool, which will
 	// gossip it to peers via CometBFT's p2p layer.
-	syncCtx := p.clientContext.WithBroadcastMode(flags.BroadcastSync)
+	syncCtx := clientContext.WithBroadcastMode(flags.BroadcastSync)
 	rsp, err := syncCtx.BroadcastTx(txBytes)
 	if rsp != nil && rsp.Code != 0 {
 		err = errorsmod.ABCIError(rsp.Codespace, rsp.Code, rsp.RawLog)
@@ -86,18 +150,41 @@ func (p *plugin) SendTx(signedEthTx *coretypes.Transaction) error {
 
 // SendPrivTx sends a private transaction to the transaction pool. It takes in
 // a signed ethereum transaction from the rpc backend and wraps it in a Cosmos
-// transaction. The Cosmos transaction is injected into the local mempool, but is
-// NOT gossiped to peers.
-func (p *plugin) SendPrivTx(signedTx *coretypes.Transaction) error {
-	cosmosTx, err := NewSerializer(p.cp, p.clientContext).SerializeToSdkTx(signedTx)
+// transaction. The Cosmos transaction is validated by the ante handler, as in
+// CheckTx, and is kept apart in the local mempool: it is NOT gossiped to peers,
+// nor exposed by the transaction pool, and is only included in the blocks
+// proposed by this node. The transaction is dropped from the mempool if it is
+// not mined by the given block number, unless the block number is 0.
+func (p *plugin) SendPrivTx(signedTx *coretypes.Transaction, maxBlockNumber uint64) error {
+	p.mu.RLock()
+	clientContext, getQueryContext, ante := p.clientContext, p.getQueryContext, p.ante
+	p.mu.RUnlock()
+	if getQueryContext == nil || ante == nil {
+		return errAnteHandlerNotSet
+	}
+
+	cosmosTx, err := NewSerializer(p.cp, clientContext).SerializeToSdkTx(signedTx)
 	if err != nil {
 		return err
 	}
 
-	// We insert into the local mempool, without gossiping to peers.
-	// We use a blank sdk.Context{} as the context, as we don't need to
-	// use it anyways. We set the priority as the gas price of the tx.
-	return p.mempool.Insert(sdk.Context{}.WithPriority(signedTx.GasPrice().Int64()), cosmosTx)
+	// We run the ante handler in CheckTx mode against the latest committed state, as CheckTx
+	// would. Its state changes are discarded along with the query context. The mempool ranks the
+	// transaction by its effective tip at the current base fee, like any other transaction.
+	ctx, err := getQueryContext(0, false)
+	if err != nil {
+		return err
+	}
+	if ctx, err = ante(ctx.WithIsCheckTx(true), cosmosTx, false); err != nil {
+		return err
+	}
+	return p.mempool.InsertPrivate(ctx, cosmosTx, maxBlockNumber)
+}
+
+// CancelPrivTx drops the private transaction with the given hash from the mempool. Transactions
+// that were not sent with SendPrivTx are never cancelled, as they may have been gossiped already.
+func (p *plugin) CancelPrivTx(hash common.Hash) bool {
+	return p.mempool.DropPrivate(hash)
 }
 
 // GetAllTransactions returns all transactions in the transaction pool.
@@ -110,11 +197,80 @@ func (p *plugin) GetTransaction(hash common.Hash) *coretypes.Transaction {
 	return p.mempool.GetTransaction(hash)
 }
 
+// Content returns the pending and queued transactions of the transaction pool, grouped by sender.
+func (p *plugin) Content() (
+	map[common.Address]coretypes.Transactions, map[common.Address]coretypes.Transactions,
+) {
+	return p.mempool.Content()
+}
+
+// ContentFrom returns the pending and queued transactions of the given sender in the transaction
+// pool.
+func (p *plugin) ContentFrom(
+	addr common.Address,
+) (coretypes.Transactions, coretypes.Transactions) {
+	return p.mempool.ContentFrom(addr)
+}
+
+// Stats returns the number of pending and queued transactions in the transaction pool.
+func (p *plugin) Stats() (int, int) {
+	return p.mempool.Stats()
+}
+
+// SubscribeNewTxsEvent registers a subscription of NewTxsEvent.
+func (p *plugin) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
+	return p.mempool.SubscribeNewTxsEvent(ch)
+}
+
+// GetNonce returns the next nonce of the given address, including the pending transactions of
+// the address in the transaction pool.
 func (p *plugin) GetNonce(addr common.Address) (uint64, error) {
-	// TODO: implement this
-	return 0, nil
+	return p.mempool.GetNonce(addr), nil
 }
 
 func (p *plugin) SetClientContext(ctx client.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.clientContext = ctx
 }
+
+// SetQueryContextFn sets the query context func for the plugin.
+func (p *plugin) SetQueryContextFn(gqc func(height int64, prove bool) (sdk.Context, error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.getQueryContext = gqc
+}
+
+// SetAnteHandler sets the ante handler that validates the private transactions.
+func (p *plugin) SetAnteHandler(ante sdk.AnteHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ante = ante
+}
+
+// SetStatePlugin sets the state plugin that is used to split pending and queued transactions, and
+// to stop resubmitting mined local transactions. The mempool reads it at the latest committed
+// height, as it is read by CheckTx and the rpc concurrently with the execution of blocks.
+func (p *plugin) SetStatePlugin(sp StatePlugin) {
+	p.sp = sp
+	p.sr = &committedStateRetriever{sp: sp}
+	p.mempool.SetStateRetriever(p.sr)
+}
+
+// Reset updates the mempool on top of the given new chain head, dropping the transactions that
+// were mined or can no longer be executed, along with the expired private transactions, and
+// promoting the queued transactions that can. It is called before the head is committed, so the
+// live state of the head is read. The view of the committed state is built again on the next read,
+// once the head is committed.
+func (p *plugin) Reset(head *coretypes.Block, baseFee *big.Int) {
+	p.mempool.Reset(head, baseFee, p.sp)
+	if p.sr != nil && head != nil {
+		p.sr.reset(head.Number().Int64())
+	}
+}
+
+// Executable returns the pending and private transactions of the transaction pool, grouped by
+// sender, to include in the blocks proposed by this node.
+func (p *plugin) Executable() map[common.Address]coretypes.Transactions {
+	return p.mempool.Executable()
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package txpool
+
+import (
+	"math/big"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+	ethlog "pkg.berachain.dev/polaris/eth/log"
+)
+
+// committedStateRetriever is a `mempool.StateRetriever` that reads the state at the latest
+// committed height, through a query context, rather than the live state that blocks are executed
+// on. As the mempool reads it while holding its lock, the view of the committed state is only
+// built once after every reset of the mempool, and is then shared by all the reads.
+type committedStateRetriever struct {
+	sp StatePlugin
+
+	// height is the height of the chain head that the mempool was last reset to
+	height int64
+	// state is the latest view of the committed state
+	state core.StatePlugin
+
+	// mu protects the height and the view, as the mempool is read concurrently by the rpc
+	mu sync.Mutex
+}
+
+// reset is called when the mempool is reset to the chain head of the given height, which makes
+// the next read build a new view of the committed state.
+func (sr *committedStateRetriever) reset(height int64) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.height = height
+}
+
+// committedState returns the view of the committed state, building it if the current view is
+// older than the chain head that the mempool was last reset to. The mempool is reset before the
+// head is committed, so until it is, every read builds a view again. If the committed state can
+// not be read, the error is returned along with the previous view, if any.
+func (sr *committedStateRetriever) committedState() (core.StatePlugin, error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	if sr.state != nil && heightOf(sr.state) >= sr.height {
+		return sr.state, nil
+	}
+	state, err := sr.sp.GetLatestCommittedState()
+	if err != nil {
+		return sr.state, err
+	}
+	sr.state = state
+	return state, nil
+}
+
+// GetNonce implements `mempool.StateRetriever`. If the committed state can not be read, the error
+// is logged and the nonce is read from the previous view of the committed state, which the next
+// reset of the mempool corrects.
+func (sr *committedStateRetriever) GetNonce(addr common.Address) uint64 {
+	state, err := sr.committedState()
+	if err != nil {
+		ethlog.Root().Error("failed to read the committed state", "err", err)
+	}
+	if state == nil {
+		return 0
+	}
+	return state.GetNonce(addr)
+}
+
+// GetBalance implements `mempool.StateRetriever`. If the committed state can not be read, the
+// error is logged and the balance is read from the previous view of the committed state.
+func (sr *committedStateRetriever) GetBalance(addr common.Address) *big.Int {
+	state, err := sr.committedState()
+	if err != nil {
+		ethlog.Root().Error("failed to read the committed state", "err", err)
+	}
+	if state == nil {
+		return new(big.Int)
+	}
+	return state.GetBalance(addr)
+}
+
+// heightOf returns the block height of the given view of the state.
+func heightOf(state core.StatePlugin) int64 {
+	return sdk.UnwrapSDKContext(state.GetContext()).BlockHeight()
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package txpool
+
+import (
+	"context"
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("committedStateRetriever", func() {
+	var (
+		sp    *mockCommittedStatePlugin
+		sr    *committedStateRetriever
+		alice = common.Address{1}
+	)
+
+	BeforeEach(func() {
+		sp = &mockCommittedStatePlugin{height: 1, nonce: 5}
+		sr = &committedStateRetriever{sp: sp}
+		sr.reset(1)
+	})
+
+	It("should build the committed state once per reset", func() {
+		Expect(sr.GetNonce(alice)).To(Equal(uint64(5)))
+		Expect(sr.GetNonce(alice)).To(Equal(uint64(5)))
+		Expect(sp.built).To(Equal(1))
+
+		// Until the new head is committed, every read builds the committed state again.
+		sr.reset(2)
+		Expect(sr.GetNonce(alice)).To(Equal(uint64(5)))
+		Expect(sp.built).To(Equal(2))
+
+		sp.height, sp.nonce = 2, 6
+		Expect(sr.GetNonce(alice)).To(Equal(uint64(6)))
+		Expect(sr.GetNonce(alice)).To(Equal(uint64(6)))
+		Expect(sp.built).To(Equal(3))
+	})
+
+	It("should keep reading the previous committed state on errors", func() {
+		Expect(sr.GetNonce(alice)).To(Equal(uint64(5)))
+
+		sp.err = errors.New("no query context")
+		sr.reset(2)
+		Expect(sr.GetNonce(alice)).To(Equal(uint64(5)))
+		_, err := sr.committedState()
+		Expect(err).To(MatchError("no query context"))
+	})
+})
+
+// mockCommittedStatePlugin returns a view of the committed state with the same nonce for every
+// account.
+type mockCommittedStatePlugin struct {
+	StatePlugin
+	height int64
+	nonce  uint64
+	err    error
+	// built is the number of views that were built
+	built int
+}
+
+func (sp *mockCommittedStatePlugin) GetLatestCommittedState() (core.StatePlugin, error) {
+	sp.built++
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return &mockCommittedState{height: sp.height, nonce: sp.nonce}, nil
+}
+
+type mockCommittedState struct {
+	core.StatePlugin
+	height int64
+	nonce  uint64
+}
+
+func (s *mockCommittedState) GetContext() context.Context {
+	return sdk.Context{}.WithContext(context.Background()).WithBlockHeight(s.height)
+}
+
+func (s *mockCommittedState) GetNonce(common.Address) uint64 {
+	return s.nonce
+}
@@ -24,6 +24,18 @@ package types
 import sdkerrors "cosmossdk.io/errors"
 
 var (
-	ErrNoEvmDenom  = sdkerrors.Register(ModuleName, 1, "evm denom not set")
-	ErrNoExtraEIPs = sdkerrors.Register(ModuleName, 2, "extra eips not set")
+	ErrNoEvmDenom                      = sdkerrors.Register(ModuleName, 1, "evm denom not set")
+	ErrNoExtraEIPs                     = sdkerrors.Register(ModuleName, 2, "extra eips not set")
+	ErrInvalidBaseFeeChangeDenominator = sdkerrors.Register(
+		ModuleName, 3, "base fee change denominator must be non-zero",
+	)
+	ErrInvalidElasticityMultiplier = sdkerrors.Register(
+		ModuleName, 4, "elasticity multiplier must be non-zero",
+	)
+	ErrInvalidPrecompileAccessRule = sdkerrors.Register(
+		ModuleName, 5, "invalid precompile access rule",
+	)
+	ErrInvalidBaseFeeRecipient = sdkerrors.Register(
+		ModuleName, 6, "base fee recipient must be empty or a module account name",
+	)
 )
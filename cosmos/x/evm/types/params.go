@@ -22,7 +22,12 @@ package types
 
 import (
 	"encoding/json"
+	"strings"
+	"unicode"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"pkg.berachain.dev/polaris/eth/core/precompile"
 	"pkg.berachain.dev/polaris/eth/params"
 	enclib "pkg.berachain.dev/polaris/lib/encoding"
 )
@@ -30,6 +35,15 @@ import (
 const (
 	// DefaultEvmDenom is the default EVM denom.
 	DefaultEvmDenom = "abera"
+	// DefaultBaseFeeChangeDenominator is the default EIP-1559 base fee change denominator.
+	DefaultBaseFeeChangeDenominator = params.BaseFeeChangeDenominator
+	// DefaultElasticityMultiplier is the default EIP-1559 elasticity multiplier.
+	DefaultElasticityMultiplier = params.ElasticityMultiplier
+	// DefaultMinBaseFee is the default minimum base fee (in wei).
+	DefaultMinBaseFee = uint64(1)
+	// DefaultBaseFeeRecipient is the default base fee recipient, by default the base fee is
+	// burned.
+	DefaultBaseFeeRecipient = ""
 )
 
 var (
@@ -40,9 +54,13 @@ var (
 // DefaultParams contains the default values for all parameters.
 func DefaultParams() *Params {
 	return &Params{
-		EvmDenom:    DefaultEvmDenom,
-		ExtraEIPs:   DefaultExtraEIPs,
-		ChainConfig: string(enclib.MustMarshalJSON(params.DefaultChainConfig)),
+		EvmDenom:                 DefaultEvmDenom,
+		ExtraEIPs:                DefaultExtraEIPs,
+		ChainConfig:              string(enclib.MustMarshalJSON(params.DefaultChainConfig)),
+		BaseFeeChangeDenominator: DefaultBaseFeeChangeDenominator,
+		ElasticityMultiplier:     DefaultElasticityMultiplier,
+		MinBaseFee:               DefaultMinBaseFee,
+		BaseFeeRecipient:         DefaultBaseFeeRecipient,
 	}
 }
 
@@ -54,6 +72,12 @@ func (p Params) EthereumChainConfig() *params.ChainConfig {
 	return enclib.MustUnmarshalJSON[params.ChainConfig]([]byte(p.ChainConfig))
 }
 
+// PrecompileAccessController returns the access controller for the precompile allowlist and
+// denylist.
+func (p Params) PrecompileAccessController() (*precompile.AccessController, error) {
+	return precompile.NewAccessController(p.PrecompileAllowlist, p.PrecompileDenylist)
+}
+
 // ValidateBasic is used to validate the parameters.
 func (p *Params) ValidateBasic() error {
 	if p.EvmDenom == "" {
@@ -62,6 +86,28 @@ func (p *Params) ValidateBasic() error {
 	if p.ExtraEIPs == nil {
 		return ErrNoExtraEIPs
 	}
+	if p.BaseFeeChangeDenominator == 0 {
+		return ErrInvalidBaseFeeChangeDenominator
+	}
+	if p.ElasticityMultiplier == 0 {
+		return ErrInvalidElasticityMultiplier
+	}
+	if _, err := p.PrecompileAccessController(); err != nil {
+		return ErrInvalidPrecompileAccessRule.Wrap(err.Error())
+	}
+	if strings.IndexFunc(p.BaseFeeRecipient, unicode.IsSpace) >= 0 {
+		return ErrInvalidBaseFeeRecipient.Wrapf("invalid module name %q", p.BaseFeeRecipient)
+	}
 	_, err := json.Marshal(p.ChainConfig)
 	return err
 }
+
+// ValidateBaseFeeRecipient validates that the base fee recipient is empty, or the name of a
+// module account, which `moduleAddress` resolves to its address (nil for unknown names). Unlike
+// `ValidateBasic`, it requires the module accounts of the app, so it is checked by the keeper.
+func (p *Params) ValidateBaseFeeRecipient(moduleAddress func(string) sdk.AccAddress) error {
+	if p.BaseFeeRecipient == "" || len(moduleAddress(p.BaseFeeRecipient)) > 0 {
+		return nil
+	}
+	return ErrInvalidBaseFeeRecipient.Wrapf("unknown module account %q", p.BaseFeeRecipient)
+}
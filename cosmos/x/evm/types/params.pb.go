@@ -33,6 +33,25 @@ type Params struct {
 	// `chain_config` represents the ethereum chain config for the polaris
 	// EVM
 	ChainConfig string `protobuf:"bytes,3,opt,name=chain_config,json=chainConfig,proto3" json:"chain_config,omitempty" yaml:"chain_config"`
+	// `base_fee_change_denominator` bounds the amount the base fee can change
+	// between blocks, as defined by EIP-1559.
+	BaseFeeChangeDenominator uint64 `protobuf:"varint,4,opt,name=base_fee_change_denominator,json=baseFeeChangeDenominator,proto3" json:"base_fee_change_denominator,omitempty" yaml:"base_fee_change_denominator"`
+	// `elasticity_multiplier` bounds the maximum gas limit a block may have
+	// relative to its gas target, as defined by EIP-1559.
+	ElasticityMultiplier uint64 `protobuf:"varint,5,opt,name=elasticity_multiplier,json=elasticityMultiplier,proto3" json:"elasticity_multiplier,omitempty" yaml:"elasticity_multiplier"`
+	// `min_base_fee` is the minimum base fee (in wei) of a block.
+	MinBaseFee uint64 `protobuf:"varint,6,opt,name=min_base_fee,json=minBaseFee,proto3" json:"min_base_fee,omitempty" yaml:"min_base_fee"`
+	// `base_fee_recipient` is the name of the module account that receives the
+	// base fee portion of transaction fees. If empty, the base fee is burned.
+	BaseFeeRecipient string `protobuf:"bytes,7,opt,name=base_fee_recipient,json=baseFeeRecipient,proto3" json:"base_fee_recipient,omitempty" yaml:"base_fee_recipient"`
+	// `precompile_allowlist` restricts the callers of precompile methods. Each
+	// rule is formatted as `<precompile>[:<method selector>][@<caller>]`. Once a
+	// precompile method is matched by a rule, it may only be called by the
+	// callers that are allowed by the rules that match it.
+	PrecompileAllowlist []string `protobuf:"bytes,8,rep,name=precompile_allowlist,json=precompileAllowlist,proto3" json:"precompile_allowlist,omitempty" yaml:"precompile_allowlist"`
+	// `precompile_denylist` denies calls to precompile methods. It uses the same
+	// rule format as `precompile_allowlist`, and takes precedence over it.
+	PrecompileDenylist []string `protobuf:"bytes,9,rep,name=precompile_denylist,json=precompileDenylist,proto3" json:"precompile_denylist,omitempty" yaml:"precompile_denylist"`
 }
 
 func (m *Params) Reset()         { *m = Params{} }
@@ -89,6 +108,48 @@ func (m *Params) GetChainConfig() string {
 	return ""
 }
 
+func (m *Params) GetBaseFeeChangeDenominator() uint64 {
+	if m != nil {
+		return m.BaseFeeChangeDenominator
+	}
+	return 0
+}
+
+func (m *Params) GetElasticityMultiplier() uint64 {
+	if m != nil {
+		return m.ElasticityMultiplier
+	}
+	return 0
+}
+
+func (m *Params) GetMinBaseFee() uint64 {
+	if m != nil {
+		return m.MinBaseFee
+	}
+	return 0
+}
+
+func (m *Params) GetBaseFeeRecipient() string {
+	if m != nil {
+		return m.BaseFeeRecipient
+	}
+	return ""
+}
+
+func (m *Params) GetPrecompileAllowlist() []string {
+	if m != nil {
+		return m.PrecompileAllowlist
+	}
+	return nil
+}
+
+func (m *Params) GetPrecompileDenylist() []string {
+	if m != nil {
+		return m.PrecompileDenylist
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Params)(nil), "polaris.evm.v1alpha1.Params")
 }
@@ -96,25 +157,38 @@ func init() {
 func init() { proto.RegisterFile("polaris/evm/v1alpha1/params.proto", fileDescriptor_9f6c2eac5100e18c) }
 
 var fileDescriptor_9f6c2eac5100e18c = []byte{
-	// 288 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x2c, 0xc8, 0xcf, 0x49,
-	0x2c, 0xca, 0x2c, 0xd6, 0x4f, 0x2d, 0xcb, 0xd5, 0x2f, 0x33, 0x4c, 0xcc, 0x29, 0xc8, 0x48, 0x34,
-	0xd4, 0x2f, 0x48, 0x2c, 0x4a, 0xcc, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x81,
-	0x2a, 0xd1, 0x4b, 0x2d, 0xcb, 0xd5, 0x83, 0x29, 0x91, 0x12, 0x49, 0xcf, 0x4f, 0xcf, 0x07, 0x2b,
-	0xd0, 0x07, 0xb1, 0x20, 0x6a, 0x95, 0x76, 0x31, 0x72, 0xb1, 0x05, 0x80, 0x35, 0x0b, 0x19, 0x72,
-	0x71, 0xa6, 0x96, 0xe5, 0xc6, 0xa7, 0xa4, 0xe6, 0xe5, 0xe7, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70,
-	0x3a, 0x89, 0x7c, 0xba, 0x27, 0x2f, 0x50, 0x99, 0x98, 0x9b, 0x63, 0xa5, 0x04, 0x97, 0x52, 0x0a,
-	0xe2, 0x48, 0x2d, 0xcb, 0x75, 0x01, 0x31, 0x85, 0x1c, 0xb9, 0xb8, 0x52, 0x2b, 0x4a, 0x8a, 0x12,
-	0xe3, 0x53, 0x33, 0x0b, 0x8a, 0x25, 0x98, 0x14, 0x98, 0x35, 0x98, 0x9d, 0x94, 0x1e, 0xdd, 0x93,
-	0xe7, 0x74, 0x05, 0x89, 0xba, 0x7a, 0x06, 0x14, 0x7f, 0xba, 0x27, 0x2f, 0x08, 0x35, 0x00, 0xae,
-	0x50, 0x29, 0x88, 0x13, 0xcc, 0x71, 0xcd, 0x2c, 0x28, 0x16, 0xb2, 0xe2, 0xe2, 0x49, 0xce, 0x48,
-	0xcc, 0xcc, 0x8b, 0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0x97, 0x60, 0x06, 0x5b, 0x2c, 0xfe, 0xe9,
-	0x9e, 0xbc, 0x30, 0x44, 0x1f, 0xb2, 0xac, 0x52, 0x10, 0x37, 0x98, 0xeb, 0x0c, 0xe6, 0x39, 0xb9,
-	0x9d, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb,
-	0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x4e, 0x41, 0x76, 0xba, 0x5e,
-	0x52, 0x6a, 0x51, 0x22, 0x58, 0xb9, 0x5e, 0x4a, 0x6a, 0x99, 0x3e, 0x2c, 0xdc, 0x92, 0xf3, 0x8b,
-	0x73, 0xf3, 0x8b, 0xf5, 0x2b, 0xc0, 0x01, 0x58, 0x52, 0x59, 0x90, 0x5a, 0x9c, 0xc4, 0x06, 0x0e,
-	0x0b, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x40, 0xd7, 0xc0, 0xb1, 0x5c, 0x01, 0x00, 0x00,
+	// 481 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7d, 0x53, 0xcd, 0x6e, 0xd4, 0x30,
+	0x10, 0x66, 0xd9, 0x76, 0x69, 0x4c, 0x0f, 0xc5, 0x0d, 0xc2, 0xb4, 0xb0, 0xbb, 0xf8, 0x80, 0x7a,
+	0x40, 0x89, 0x56, 0x9c, 0xe8, 0xad, 0xe9, 0x6e, 0x25, 0x84, 0x10, 0x95, 0x25, 0x2e, 0x5c, 0x22,
+	0x6f, 0xea, 0xa6, 0x56, 0x9d, 0xd8, 0x8a, 0xc3, 0xd2, 0x7d, 0x0b, 0x5e, 0x82, 0x77, 0xe1, 0xd8,
+	0x23, 0xa7, 0x0a, 0x95, 0x37, 0xe0, 0x09, 0x98, 0x38, 0x3f, 0xbb, 0x82, 0x8a, 0xc3, 0x48, 0x33,
+	0xdf, 0x7c, 0xf3, 0xcd, 0x8c, 0x7f, 0xd0, 0x0b, 0xa3, 0x15, 0x2f, 0xa4, 0x0d, 0xc5, 0x22, 0x0b,
+	0x17, 0x13, 0xae, 0xcc, 0x05, 0x9f, 0x84, 0x86, 0x17, 0x3c, 0xb3, 0x81, 0x29, 0x74, 0xa9, 0xb1,
+	0xdf, 0x50, 0x02, 0xa0, 0x04, 0x2d, 0x65, 0xcf, 0x4f, 0x75, 0xaa, 0x1d, 0x21, 0xac, 0xbc, 0x9a,
+	0x4b, 0xbf, 0x6d, 0xa2, 0xc1, 0xa9, 0x2b, 0xc6, 0x13, 0xe4, 0x41, 0x41, 0x7c, 0x26, 0x72, 0x9d,
+	0x91, 0xde, 0xb8, 0x77, 0xe0, 0x45, 0xfe, 0xef, 0x9b, 0xd1, 0xce, 0x92, 0x67, 0xea, 0x90, 0x76,
+	0x29, 0xca, 0xb6, 0xc0, 0x9f, 0x56, 0x2e, 0x3e, 0x42, 0x48, 0x5c, 0x95, 0x05, 0x8f, 0x85, 0x34,
+	0x96, 0xdc, 0x1f, 0xf7, 0x0f, 0xfa, 0x11, 0xbd, 0xbd, 0x19, 0x79, 0xb3, 0x0a, 0x9d, 0xbd, 0x3d,
+	0xb5, 0x20, 0xf0, 0xa8, 0x11, 0xe8, 0x88, 0x94, 0x79, 0x2e, 0x98, 0x81, 0x8f, 0x0f, 0xd1, 0x76,
+	0x72, 0xc1, 0x65, 0x1e, 0x27, 0x3a, 0x3f, 0x97, 0x29, 0xe9, 0xbb, 0xc6, 0x4f, 0xa0, 0x6e, 0xb7,
+	0xae, 0x5b, 0xcf, 0x52, 0xf6, 0xd0, 0x85, 0xc7, 0x2e, 0xc2, 0x02, 0xed, 0xcf, 0xb9, 0x15, 0xf1,
+	0xb9, 0x10, 0x31, 0xe0, 0x79, 0x2a, 0xea, 0x11, 0x65, 0xce, 0x4b, 0x5d, 0x90, 0x0d, 0x90, 0xda,
+	0x88, 0x5e, 0x82, 0x14, 0xad, 0xa5, 0xfe, 0x43, 0xa6, 0x8c, 0x54, 0xd9, 0x13, 0x21, 0x8e, 0x5d,
+	0x6e, 0xba, 0x4a, 0xe1, 0x8f, 0xe8, 0xb1, 0x50, 0xdc, 0x96, 0x32, 0x91, 0xe5, 0x32, 0xce, 0x3e,
+	0xab, 0x52, 0x1a, 0x25, 0x45, 0x41, 0x36, 0x5d, 0x83, 0x31, 0x34, 0x78, 0xd6, 0xec, 0x78, 0x17,
+	0x8d, 0x32, 0x7f, 0x85, 0xbf, 0xef, 0x60, 0xfc, 0x06, 0x6d, 0x43, 0x87, 0xb8, 0x1d, 0x8a, 0x0c,
+	0x9c, 0xda, 0xda, 0xe6, 0xeb, 0x59, 0xca, 0x10, 0x84, 0x51, 0x3d, 0x22, 0x7e, 0x87, 0x70, 0xb7,
+	0x4b, 0x21, 0x12, 0x69, 0xa4, 0xc8, 0x4b, 0xf2, 0xc0, 0x1d, 0xdd, 0x73, 0x10, 0x78, 0xfa, 0xd7,
+	0xbe, 0x1d, 0x87, 0xb2, 0x9d, 0x66, 0x4d, 0xd6, 0x42, 0x98, 0x21, 0xdf, 0x00, 0x41, 0x67, 0x46,
+	0x2a, 0x11, 0x73, 0xa5, 0xf4, 0x17, 0x25, 0x6d, 0x49, 0xb6, 0xe0, 0x3a, 0xbd, 0x68, 0x04, 0x72,
+	0xfb, 0xb5, 0xdc, 0x5d, 0x2c, 0xca, 0x76, 0x57, 0xf0, 0x51, 0x8b, 0xe2, 0x0f, 0x68, 0x0d, 0xae,
+	0xce, 0x79, 0xe9, 0x24, 0x3d, 0x27, 0x39, 0x04, 0xc9, 0xbd, 0x7f, 0x24, 0x5b, 0x12, 0x65, 0x78,
+	0x85, 0x4e, 0x1b, 0x30, 0x3a, 0xf9, 0x7e, 0x3b, 0xec, 0x5d, 0x83, 0xfd, 0x04, 0xfb, 0xfa, 0x6b,
+	0x78, 0xef, 0x1a, 0xec, 0x07, 0xd8, 0xa7, 0x57, 0xe6, 0x32, 0x0d, 0xe6, 0xa2, 0xe0, 0xee, 0x65,
+	0x04, 0x67, 0x62, 0x11, 0xb6, 0x5f, 0x24, 0xd1, 0x36, 0xd3, 0x36, 0xbc, 0x72, 0x7f, 0xa5, 0x5c,
+	0x1a, 0x61, 0xe7, 0x03, 0xf7, 0xec, 0x5f, 0xff, 0x01, 0x30, 0xf5, 0xbd, 0xa9, 0x47, 0x03, 0x00,
+	0x00,
 }
 
 func (m *Params) Marshal() (dAtA []byte, err error) {
@@ -137,6 +211,46 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PrecompileDenylist) > 0 {
+		for iNdEx := len(m.PrecompileDenylist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PrecompileDenylist[iNdEx])
+			copy(dAtA[i:], m.PrecompileDenylist[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.PrecompileDenylist[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.PrecompileAllowlist) > 0 {
+		for iNdEx := len(m.PrecompileAllowlist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PrecompileAllowlist[iNdEx])
+			copy(dAtA[i:], m.PrecompileAllowlist[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.PrecompileAllowlist[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.BaseFeeRecipient) > 0 {
+		i -= len(m.BaseFeeRecipient)
+		copy(dAtA[i:], m.BaseFeeRecipient)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.BaseFeeRecipient)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.MinBaseFee != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MinBaseFee))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ElasticityMultiplier != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ElasticityMultiplier))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.BaseFeeChangeDenominator != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BaseFeeChangeDenominator))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.ChainConfig) > 0 {
 		i -= len(m.ChainConfig)
 		copy(dAtA[i:], m.ChainConfig)
@@ -205,6 +319,31 @@ func (m *Params) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.BaseFeeChangeDenominator != 0 {
+		n += 1 + sovParams(uint64(m.BaseFeeChangeDenominator))
+	}
+	if m.ElasticityMultiplier != 0 {
+		n += 1 + sovParams(uint64(m.ElasticityMultiplier))
+	}
+	if m.MinBaseFee != 0 {
+		n += 1 + sovParams(uint64(m.MinBaseFee))
+	}
+	l = len(m.BaseFeeRecipient)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if len(m.PrecompileAllowlist) > 0 {
+		for _, s := range m.PrecompileAllowlist {
+			l = len(s)
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	if len(m.PrecompileDenylist) > 0 {
+		for _, s := range m.PrecompileDenylist {
+			l = len(s)
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -383,6 +522,159 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 			}
 			m.ChainConfig = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseFeeChangeDenominator", wireType)
+			}
+			m.BaseFeeChangeDenominator = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseFeeChangeDenominator |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElasticityMultiplier", wireType)
+			}
+			m.ElasticityMultiplier = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ElasticityMultiplier |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBaseFee", wireType)
+			}
+			m.MinBaseFee = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBaseFee |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseFeeRecipient", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BaseFeeRecipient = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecompileAllowlist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrecompileAllowlist = append(m.PrecompileAllowlist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecompileDenylist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrecompileDenylist = append(m.PrecompileDenylist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -23,6 +23,8 @@ package types
 import (
 	"math/big"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -33,4 +35,47 @@ var _ = Describe("Test Params", func() {
 		ethConfig := params.EthereumChainConfig()
 		Expect(ethConfig.ChainID).To(Equal(big.NewInt(69420)))
 	})
+
+	It("should validate the base fee params", func() {
+		params := DefaultParams()
+		Expect(params.ValidateBasic()).To(Succeed())
+
+		params.BaseFeeChangeDenominator = 0
+		Expect(params.ValidateBasic()).To(MatchError(ErrInvalidBaseFeeChangeDenominator))
+
+		params = DefaultParams()
+		params.ElasticityMultiplier = 0
+		Expect(params.ValidateBasic()).To(MatchError(ErrInvalidElasticityMultiplier))
+	})
+
+	It("should validate the precompile access rules", func() {
+		params := DefaultParams()
+		params.PrecompileAllowlist = []string{"0x0000000000000000000000000000000000000001:0x12345678"}
+		params.PrecompileDenylist = []string{"0x0000000000000000000000000000000000000002"}
+		Expect(params.ValidateBasic()).To(Succeed())
+
+		params.PrecompileDenylist = []string{"0x0000000000000000000000000000000000000002:0x12"}
+		Expect(params.ValidateBasic()).To(MatchError(ErrInvalidPrecompileAccessRule))
+	})
+
+	It("should validate the base fee recipient", func() {
+		moduleAddress := func(name string) sdk.AccAddress {
+			if name == "distribution" {
+				return sdk.AccAddress("distribution")
+			}
+			return nil
+		}
+		params := DefaultParams()
+		Expect(params.ValidateBaseFeeRecipient(moduleAddress)).To(Succeed())
+
+		params.BaseFeeRecipient = "distribution"
+		Expect(params.ValidateBasic()).To(Succeed())
+		Expect(params.ValidateBaseFeeRecipient(moduleAddress)).To(Succeed())
+
+		params.BaseFeeRecipient = "treasury"
+		Expect(params.ValidateBaseFeeRecipient(moduleAddress)).To(MatchError(ErrInvalidBaseFeeRecipient))
+
+		params.BaseFeeRecipient = "fee collector"
+		Expect(params.ValidateBasic()).To(MatchError(ErrInvalidBaseFeeRecipient))
+	})
 })
@@ -33,14 +33,19 @@ type (
 	ABI       = abi.ABI
 	Argument  = abi.Argument
 	Arguments = abi.Arguments
+	Error     = abi.Error
 	Event     = abi.Event
 	Method    = abi.Method
+	Type      = abi.Type
 )
 
 var (
-	MakeTopics = abi.MakeTopics
-	NewEvent   = abi.NewEvent
-	NewType    = abi.NewType
+	JSON         = abi.JSON
+	MakeTopics   = abi.MakeTopics
+	ToCamelCase  = abi.ToCamelCase
+	NewEvent     = abi.NewEvent
+	NewType      = abi.NewType
+	UnpackRevert = abi.UnpackRevert
 )
 
 // ToMixedCase converts a under_score formatted string to mixedCase format (camelCase with the
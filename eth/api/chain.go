@@ -31,4 +31,5 @@ type Chain interface {
 	core.ChainReader
 	core.ChainSubscriber
 	core.ChainResources
+	core.BlockBuilder
 }
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+// precompilegen generates a typed server interface, a precompile method adapter, and event
+// helpers from the JSON ABI of a Solidity interface. It is intended to be used with `go generate`:
+//
+//	//go:generate go run pkg.berachain.dev/polaris/eth/cmd/precompilegen --abi ./IFoo.abi.json --pkg foo --type Foo --out ./foo.precompile.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"pkg.berachain.dev/polaris/eth/core/precompile/gen"
+)
+
+func main() {
+	abiPath := flag.String("abi", "", "path to the JSON ABI of the Solidity interface")
+	pkg := flag.String("pkg", "", "name of the Go package of the generated file")
+	typ := flag.String("type", "", "name of the precompile, used as prefix")
+	out := flag.String("out", "", "path of the generated file (default: stdout)")
+	flag.Parse()
+
+	if err := run(*abiPath, *pkg, *typ, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "precompilegen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the precompile for the ABI at the given path and writes it to the given output.
+func run(abiPath, pkg, typ, out string) error {
+	abiJSON, err := os.ReadFile(abiPath) //#nosec: G304 // required.
+	if err != nil {
+		return err
+	}
+
+	code, err := gen.Generate(gen.Config{Package: pkg, Type: typ, ABI: string(abiJSON)})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	//#nosec:G306 // generated source files are meant to be world-readable.
+	return os.WriteFile(out, code, 0o644) //nolint:gomnd // file permissions.
+}
@@ -25,6 +25,13 @@ import (
 )
 
 type (
-	Bytes = hexutil.Bytes
-	Uint  = hexutil.Uint
+	Big    = hexutil.Big
+	Bytes  = hexutil.Bytes
+	Uint   = hexutil.Uint
+	Uint64 = hexutil.Uint64
+)
+
+var (
+	Decode = hexutil.Decode
+	Encode = hexutil.Encode
 )
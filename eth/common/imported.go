@@ -41,6 +41,8 @@ var (
 	HexToAddress   = common.HexToAddress
 	Hex2Bytes      = common.Hex2Bytes
 	HexToHash      = common.HexToHash
+	IsHexAddress   = common.IsHexAddress
 
-	LeftPadBytes = common.LeftPadBytes
+	LeftPadBytes   = common.LeftPadBytes
+	TrimLeftZeroes = common.TrimLeftZeroes
 )
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+// BlockBuilder defines methods that are used to build blocks from the transactions of the
+// transaction pool.
+type BlockBuilder interface {
+	// SelectTransactions selects the transactions of the tx pool to include in the block at the
+	// given height, without executing them. It returns the selected txs in the order that they
+	// should be processed, along with the txs that were rejected.
+	SelectTransactions(context.Context, int64) (types.Transactions, []*RejectedTx, error)
+	// BuildBlock prepares, processes and finalizes the block at the given height with the
+	// transactions selected from the tx pool. It returns the block, along with the txs that were
+	// rejected.
+	BuildBlock(context.Context, int64) (*types.Block, []*RejectedTx, error)
+}
+
+// RejectedTx is a transaction of the tx pool that was not included in a block.
+type RejectedTx struct {
+	Tx *types.Transaction
+	// Err is the reason that the transaction was rejected.
+	Err error
+}
+
+// =========================================================================
+// Block Building
+// =========================================================================
+
+// SelectTransactions selects the pending and private transactions of the tx pool by effective tip,
+// in nonce order for each sender, until the block gas limit is reached. Transactions are checked
+// against the state at the end of the previous block: a tx with a nonce that is too low is
+// skipped, while a tx with a nonce that is too high, a fee cap below the base fee, or a cost that
+// the sender can not afford is rejected along with the rest of the sender's txs.
+func (bc *blockchain) SelectTransactions(
+	_ context.Context, height int64,
+) (types.Transactions, []*RejectedTx, error) {
+	var parent *types.Header
+	if height > 1 {
+		var err error
+		if parent, err = bc.bp.GetHeaderByNumber(height - 1); err != nil {
+			return nil, nil, err
+		}
+	}
+	state, err := bc.sp.GetStateByNumber(height - 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	selected, rejected := bc.selectTransactions(
+		state, types.MakeSigner(bc.cp.ChainConfig(), big.NewInt(height)),
+		bc.bp.BaseFee(parent), bc.gp.BlockGasLimit(), true,
+	)
+	return selected, rejected, nil
+}
+
+// selectTransactions selects the pending transactions of the tx pool, as described by
+// `SelectTransactions`, against the given state, signer, base fee and block gas limit. The private
+// transactions are only selected if `private` is set, as they must not be exposed outside of the
+// blocks proposed by this node.
+func (bc *blockchain) selectTransactions(
+	state StatePlugin, signer types.Signer, baseFee *big.Int, gasLimit uint64, private bool,
+) (types.Transactions, []*RejectedTx) {
+	var pending map[common.Address]types.Transactions
+	if private {
+		pending = bc.tp.Executable()
+	} else {
+		pending, _ = bc.tp.Content()
+	}
+
+	// Drop the txs that can not pay the base fee, as they would otherwise be silently dropped
+	// when ordering the txs.
+	var rejected []*RejectedTx
+	if baseFee != nil {
+		for sender, txs := range pending {
+			for i, tx := range txs {
+				if tx.GasFeeCapIntCmp(baseFee) >= 0 {
+					continue
+				}
+				rejected = append(rejected, &RejectedTx{Tx: tx, Err: fmt.Errorf(
+					"%w: maxFeePerGas: %s baseFee: %s", ErrFeeCapTooLow, tx.GasFeeCap(), baseFee,
+				)})
+				if i == 0 {
+					delete(pending, sender)
+				} else {
+					pending[sender] = txs[:i]
+				}
+				break
+			}
+		}
+	}
+
+	var (
+		selected     types.Transactions
+		ordered      = types.NewTransactionsByPriceAndNonce(signer, pending, baseFee)
+		gasRemaining = gasLimit
+		nonces       = make(map[common.Address]uint64)
+		balances     = make(map[common.Address]*big.Int)
+	)
+	for gasRemaining >= params.TxGas {
+		tx := ordered.Peek()
+		if tx == nil {
+			break
+		}
+		from, err := signer.Sender(tx)
+		if err != nil {
+			rejected = append(rejected, &RejectedTx{Tx: tx, Err: err})
+			ordered.Pop()
+			continue
+		}
+		if _, ok := nonces[from]; !ok {
+			nonces[from] = state.GetNonce(from)
+			balances[from] = new(big.Int).Set(state.GetBalance(from))
+		}
+
+		switch nonce, cost := nonces[from], tx.Cost(); {
+		case tx.Gas() > gasRemaining:
+			rejected = append(rejected, &RejectedTx{Tx: tx, Err: fmt.Errorf(
+				"%w: have %d, want %d", ErrGasLimitReached, gasRemaining, tx.Gas(),
+			)})
+			ordered.Pop()
+		case tx.Nonce() < nonce:
+			rejected = append(rejected, &RejectedTx{Tx: tx, Err: fmt.Errorf(
+				"%w: address %v, tx: %d state: %d", ErrNonceTooLow, from.Hex(), tx.Nonce(), nonce,
+			)})
+			ordered.Shift()
+		case tx.Nonce() > nonce:
+			rejected = append(rejected, &RejectedTx{Tx: tx, Err: fmt.Errorf(
+				"%w: address %v, tx: %d state: %d", ErrNonceTooHigh, from.Hex(), tx.Nonce(), nonce,
+			)})
+			ordered.Pop()
+		case balances[from].Cmp(cost) < 0:
+			rejected = append(rejected, &RejectedTx{Tx: tx, Err: fmt.Errorf(
+				"%w: address %v have %v want %v", ErrInsufficientFunds, from.Hex(), balances[from], cost,
+			)})
+			ordered.Pop()
+		default:
+			selected = append(selected, tx)
+			nonces[from]++
+			balances[from].Sub(balances[from], cost)
+			gasRemaining -= tx.Gas()
+			ordered.Shift()
+		}
+	}
+
+	return selected, rejected
+}
+
+// BuildBlock builds the block at the given height with the transactions selected by
+// `SelectTransactions`. A selected tx that fails to be processed is rejected without failing the
+// block.
+func (bc *blockchain) BuildBlock(
+	ctx context.Context, height int64,
+) (*types.Block, []*RejectedTx, error) {
+	txs, rejected, err := bc.SelectTransactions(ctx, height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bc.Prepare(ctx, height)
+	if err = bc.SpeculateTransactions(ctx, txs); err != nil {
+		bc.logger.Error("failed to speculate transactions", "height", height, "err", err)
+	}
+	for _, tx := range txs {
+		if _, err = bc.ProcessTransaction(ctx, tx); err != nil {
+			rejected = append(rejected, &RejectedTx{Tx: tx, Err: err})
+		}
+	}
+	if err = bc.Finalize(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	block, err := bc.CurrentBlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, rejected, nil
+}
@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Block Builder", func() {
+	var (
+		alice, bob *ecdsa.PrivateKey
+		state      *builderStatePlugin
+		tp         *builderTxPoolPlugin
+		bc         *blockchain
+	)
+
+	signer := types.LatestSignerForChainID(params.DefaultChainConfig.ChainID)
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, tip int64, gas uint64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.DefaultChainConfig.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(100 + tip),
+			Gas:       gas,
+			To:        &common.Address{1},
+		})
+	}
+
+	BeforeEach(func() {
+		alice, _ = crypto.GenerateEthKey()
+		bob, _ = crypto.GenerateEthKey()
+		state = &builderStatePlugin{
+			nonces:   make(map[common.Address]uint64),
+			balances: make(map[common.Address]*big.Int),
+		}
+		state.balances[crypto.PubkeyToAddress(alice.PublicKey)] = big.NewInt(1e18)
+		state.balances[crypto.PubkeyToAddress(bob.PublicKey)] = big.NewInt(1e18)
+		tp = &builderTxPoolPlugin{
+			pending: make(map[common.Address]types.Transactions),
+			private: make(map[common.Address]types.Transactions),
+		}
+		bc = &blockchain{
+			bp: &builderBlockPlugin{baseFee: big.NewInt(100)},
+			cp: &builderConfigPlugin{},
+			gp: &builderGasPlugin{limit: 100000},
+			sp: state,
+			tp: tp,
+		}
+	})
+
+	addPending := func(key *ecdsa.PrivateKey, txs ...*types.Transaction) {
+		tp.pending[crypto.PubkeyToAddress(key.PublicKey)] = txs
+	}
+
+	It("should order the txs by effective tip and nonce", func() {
+		a0, a1 := newTx(alice, 0, 1, 21000), newTx(alice, 1, 5, 21000)
+		b0 := newTx(bob, 0, 3, 21000)
+		addPending(alice, a0, a1)
+		addPending(bob, b0)
+
+		txs, rejected, err := bc.SelectTransactions(context.Background(), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rejected).To(BeEmpty())
+		Expect(txs).To(Equal(types.Transactions{b0, a0, a1}))
+	})
+
+	It("should skip txs with a nonce that is too low", func() {
+		state.nonces[crypto.PubkeyToAddress(alice.PublicKey)] = 1
+		a0, a1 := newTx(alice, 0, 1, 21000), newTx(alice, 1, 1, 21000)
+		addPending(alice, a0, a1)
+
+		txs, rejected, err := bc.SelectTransactions(context.Background(), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(txs).To(Equal(types.Transactions{a1}))
+		Expect(rejected).To(HaveLen(1))
+		Expect(rejected[0].Tx).To(Equal(a0))
+		Expect(errors.Is(rejected[0].Err, ErrNonceTooLow)).To(BeTrue())
+	})
+
+	It("should reject the txs of a sender that can not afford them", func() {
+		state.balances[crypto.PubkeyToAddress(alice.PublicKey)] = big.NewInt(21000 * 101)
+		a0, a1 := newTx(alice, 0, 1, 21000), newTx(alice, 1, 1, 21000)
+		addPending(alice, a0, a1)
+
+		txs, rejected, err := bc.SelectTransactions(context.Background(), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(txs).To(Equal(types.Transactions{a0}))
+		Expect(rejected).To(HaveLen(1))
+		Expect(rejected[0].Tx).To(Equal(a1))
+		Expect(errors.Is(rejected[0].Err, ErrInsufficientFunds)).To(BeTrue())
+	})
+
+	It("should reject txs that can not pay the base fee", func() {
+		a0 := types.MustSignNewTx(alice, signer, &types.DynamicFeeTx{
+			ChainID:   params.DefaultChainConfig.ChainID,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(99),
+			Gas:       21000,
+		})
+		addPending(alice, a0)
+
+		txs, rejected, err := bc.SelectTransactions(context.Background(), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(txs).To(BeEmpty())
+		Expect(rejected).To(HaveLen(1))
+		Expect(errors.Is(rejected[0].Err, ErrFeeCapTooLow)).To(BeTrue())
+	})
+
+	It("should stop at the block gas limit", func() {
+		a0, b0 := newTx(alice, 0, 2, 60000), newTx(bob, 0, 1, 50000)
+		addPending(alice, a0)
+		addPending(bob, b0)
+
+		txs, rejected, err := bc.SelectTransactions(context.Background(), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(txs).To(Equal(types.Transactions{a0}))
+		Expect(rejected).To(HaveLen(1))
+		Expect(rejected[0].Tx).To(Equal(b0))
+		Expect(errors.Is(rejected[0].Err, ErrGasLimitReached)).To(BeTrue())
+	})
+
+	It("should only select the private txs for the proposed blocks", func() {
+		a0, b0 := newTx(alice, 0, 2, 21000), newTx(bob, 0, 1, 21000)
+		addPending(alice, a0)
+		tp.private[crypto.PubkeyToAddress(bob.PublicKey)] = types.Transactions{b0}
+
+		txs, rejected, err := bc.SelectTransactions(context.Background(), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rejected).To(BeEmpty())
+		Expect(txs).To(Equal(types.Transactions{a0, b0}))
+
+		txs, rejected = bc.selectTransactions(state, signer, big.NewInt(100), 100000, false)
+		Expect(rejected).To(BeEmpty())
+		Expect(txs).To(Equal(types.Transactions{a0}))
+	})
+})
+
+type builderBlockPlugin struct {
+	BlockPlugin
+	baseFee *big.Int
+}
+
+func (bp *builderBlockPlugin) GetHeaderByNumber(n int64) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(n)}, nil
+}
+
+func (bp *builderBlockPlugin) BaseFee(*types.Header) *big.Int {
+	return bp.baseFee
+}
+
+type builderConfigPlugin struct {
+	ConfigurationPlugin
+	baseFeeCollector *common.Address
+}
+
+func (cp *builderConfigPlugin) ChainConfig() *params.ChainConfig {
+	return params.DefaultChainConfig
+}
+
+func (cp *builderConfigPlugin) ChainConfigAt(int64) (*params.ChainConfig, error) {
+	return params.DefaultChainConfig, nil
+}
+
+func (cp *builderConfigPlugin) ExtraEips() []int {
+	return nil
+}
+
+func (cp *builderConfigPlugin) FeeCollector() *common.Address {
+	return nil
+}
+
+func (cp *builderConfigPlugin) BaseFeeCollector() *common.Address {
+	return cp.baseFeeCollector
+}
+
+type builderGasPlugin struct {
+	GasPlugin
+	limit uint64
+}
+
+func (gp *builderGasPlugin) BlockGasLimit() uint64 {
+	return gp.limit
+}
+
+// builderStatePlugin is an in-memory state of nonces and balances.
+type builderStatePlugin struct {
+	StatePlugin
+	nonces   map[common.Address]uint64
+	balances map[common.Address]*big.Int
+}
+
+func (sp *builderStatePlugin) GetStateByNumber(int64) (StatePlugin, error) {
+	return sp, nil
+}
+
+func (sp *builderStatePlugin) RegistryKey() string {
+	return "builder"
+}
+
+func (sp *builderStatePlugin) Exist(addr common.Address) bool {
+	_, ok := sp.balances[addr]
+	return ok
+}
+
+func (sp *builderStatePlugin) GetCode(common.Address) []byte {
+	return nil
+}
+
+func (sp *builderStatePlugin) GetCodeHash(common.Address) common.Hash {
+	return common.Hash{}
+}
+
+func (sp *builderStatePlugin) GetNonce(addr common.Address) uint64 {
+	return sp.nonces[addr]
+}
+
+func (sp *builderStatePlugin) GetBalance(addr common.Address) *big.Int {
+	if balance, ok := sp.balances[addr]; ok {
+		return balance
+	}
+	return new(big.Int)
+}
+
+type builderTxPoolPlugin struct {
+	TxPoolPlugin
+	pending map[common.Address]types.Transactions
+	private map[common.Address]types.Transactions
+}
+
+func (tp *builderTxPoolPlugin) Executable() map[common.Address]types.Transactions {
+	executable := make(map[common.Address]types.Transactions)
+	for sender, txs := range tp.pending {
+		executable[sender] = txs
+	}
+	for sender, txs := range tp.private {
+		executable[sender] = append(executable[sender], txs...)
+	}
+	return executable
+}
+
+func (tp *builderTxPoolPlugin) Content() (
+	map[common.Address]types.Transactions, map[common.Address]types.Transactions,
+) {
+	return tp.pending, nil
+}
@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/bitutil"
+	"github.com/ethereum/go-ethereum/core/bloombits"
+
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/log"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+const (
+	// bloomServiceThreads is the number of goroutines used per filter session to service bloombits
+	// lookups from the historical plugin.
+	bloomServiceThreads = 16
+
+	// bloomFilterThreads is the number of goroutines used per filter session to multiplex
+	// requests onto the service goroutines.
+	bloomFilterThreads = 3
+
+	// bloomRetrievalBatch is the maximum number of bloom bit retrievals to service in a single
+	// batch.
+	bloomRetrievalBatch = 16
+
+	// bloomRetrievalWait is the maximum time to wait for enough bloom bit requests to accumulate
+	// before servicing a batch.
+	bloomRetrievalWait = 0
+
+	// bloomBackfillLimit is the maximum number of missing blocks that are indexed when processing
+	// a single chain event, so that catching up on an unindexed chain does not stall block
+	// production.
+	bloomBackfillLimit = params.BloomBitsBlocks
+)
+
+// bloomIndexer indexes the header blooms of the chain into bloom bits sections of
+// `params.BloomBitsBlocks` blocks, which are persisted via the historical plugin. The sections
+// are used by the filter system to only fetch the receipts of blocks that may contain matching
+// logs, instead of scanning every block in the range.
+type bloomIndexer struct {
+	bp     BlockPlugin
+	hp     HistoricalPlugin
+	logger log.Logger
+
+	// sections is the number of fully indexed sections, it is read concurrently by the rpc.
+	sections atomic.Uint64
+
+	// the fields below are only accessed while processing chain events.
+	initialized bool
+	next        uint64
+	gen         *bloombits.Generator
+}
+
+// newBloomIndexer creates a new bloom indexer on top of the given plugins.
+func newBloomIndexer(bp BlockPlugin, hp HistoricalPlugin, logger log.Logger) *bloomIndexer {
+	return &bloomIndexer{
+		bp:     bp,
+		hp:     hp,
+		logger: logger,
+	}
+}
+
+// Process adds the bloom of the block of the given chain event to the index. If the indexer is
+// lagging behind (e.g. after a restart in the middle of a section), the blooms of the missing
+// blocks are first loaded from the block plugin, at most `bloomBackfillLimit` per call.
+func (bi *bloomIndexer) Process(ev ChainEvent) error {
+	if !bi.initialized {
+		sections, err := bi.hp.GetBloomSections()
+		if err != nil {
+			return err
+		}
+		bi.sections.Store(sections)
+		bi.next = sections * params.BloomBitsBlocks
+		bi.gen = nil
+		bi.initialized = true
+	}
+
+	number := ev.Block.NumberU64()
+	for budget := bloomBackfillLimit; bi.next < number && budget > 0; budget-- {
+		if err := bi.addBloom(bi.historicalBloom(bi.next)); err != nil {
+			return bi.reset(err)
+		}
+	}
+	if bi.next != number {
+		return nil
+	}
+	return bi.reset(bi.addBloom(ev.Block.Bloom()))
+}
+
+// Rewind drops the indexed sections that contain blocks above the given block number, so that
+// they are indexed again as the chain progresses.
+func (bi *bloomIndexer) Rewind(number uint64) error {
+	sections, err := bi.hp.GetBloomSections()
+	if err != nil {
+		return bi.reset(err)
+	}
+	if keep := (number + 1) / params.BloomBitsBlocks; keep < sections {
+		if err = bi.hp.StoreBloomSections(keep); err != nil {
+			return bi.reset(err)
+		}
+		sections = keep
+	}
+	bi.sections.Store(sections)
+
+	// force the in-memory section to be rebuilt from the new head.
+	bi.initialized = false
+	return nil
+}
+
+// BloomStatus returns the section size and the number of fully indexed sections.
+func (bi *bloomIndexer) BloomStatus() (uint64, uint64) {
+	return params.BloomBitsBlocks, bi.sections.Load()
+}
+
+// ServiceFilter starts the goroutines that retrieve the bloom bits requested by the given
+// matcher session. The goroutines exit once the session is closed.
+func (bi *bloomIndexer) ServiceFilter(_ context.Context, session *bloombits.MatcherSession) {
+	requests := make(chan chan *bloombits.Retrieval)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(bloomFilterThreads)
+	for i := 0; i < bloomFilterThreads; i++ {
+		go func() {
+			defer wg.Done()
+			session.Multiplex(bloomRetrievalBatch, bloomRetrievalWait, requests)
+		}()
+	}
+	for i := 0; i < bloomServiceThreads; i++ {
+		go bi.serveRetrievals(requests, done)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+}
+
+// serveRetrievals services bloom bits retrievals from the historical plugin until done is closed.
+func (bi *bloomIndexer) serveRetrievals(
+	requests chan chan *bloombits.Retrieval, done <-chan struct{},
+) {
+	for {
+		select {
+		case <-done:
+			return
+		case request := <-requests:
+			task := <-request
+			task.Bitsets = make([][]byte, len(task.Sections))
+			for i, section := range task.Sections {
+				compVector, err := bi.hp.GetBloomBits(task.Bit, section)
+				if err != nil {
+					task.Error = err
+					continue
+				}
+				blob, err := bitutil.DecompressBytes(compVector, int(params.BloomBitsBlocks)/8)
+				if err != nil {
+					task.Error = err
+					continue
+				}
+				task.Bitsets[i] = blob
+			}
+			request <- task
+		}
+	}
+}
+
+// addBloom adds the bloom of the next block to the current section, committing the section once
+// it is complete.
+func (bi *bloomIndexer) addBloom(bloom types.Bloom) error {
+	if bi.gen == nil {
+		gen, err := bloombits.NewGenerator(uint(params.BloomBitsBlocks))
+		if err != nil {
+			return err
+		}
+		bi.gen = gen
+	}
+
+	if err := bi.gen.AddBloom(uint(bi.next%params.BloomBitsBlocks), bloom); err != nil {
+		return err
+	}
+	bi.next++
+
+	if bi.next%params.BloomBitsBlocks == 0 {
+		return bi.commitSection(bi.next/params.BloomBitsBlocks - 1)
+	}
+	return nil
+}
+
+// commitSection persists the bloom bits of the given, complete, section.
+func (bi *bloomIndexer) commitSection(section uint64) error {
+	for i := 0; i < types.BloomBitLength; i++ {
+		bits, err := bi.gen.Bitset(uint(i))
+		if err != nil {
+			return err
+		}
+		if err = bi.hp.StoreBloomBits(uint(i), section, bitutil.CompressBytes(bits)); err != nil {
+			return err
+		}
+	}
+	bi.gen = nil
+
+	if err := bi.hp.StoreBloomSections(section + 1); err != nil {
+		return err
+	}
+	bi.sections.Store(section + 1)
+	bi.logger.Info("indexed bloom bits section", "section", section)
+	return nil
+}
+
+// historicalBloom returns the bloom of the block at the given number. Blocks that are not
+// available (e.g. genesis or pruned blocks) are indexed with an empty bloom.
+func (bi *bloomIndexer) historicalBloom(number uint64) types.Bloom {
+	header, err := bi.bp.GetHeaderByNumber(int64(number))
+	if err != nil || header == nil {
+		bi.logger.Debug("bloom indexer: header not found", "number", number, "err", err)
+		return types.Bloom{}
+	}
+	return header.Bloom
+}
+
+// reset forces the indexer to reload its progress from the historical plugin if the given error
+// is non-nil, as the in-memory section may be partially built.
+func (bi *bloomIndexer) reset(err error) error {
+	if err != nil {
+		bi.initialized = false
+	}
+	return err
+}
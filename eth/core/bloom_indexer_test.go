@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/bloombits"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/log"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("bloomIndexer", func() {
+	var (
+		hp *bloomHistoricalPlugin
+		bi *bloomIndexer
+	)
+
+	newBlock := func(number uint64, addrs ...common.Address) *types.Block {
+		var bloom types.Bloom
+		for _, addr := range addrs {
+			bloom.Add(addr.Bytes())
+		}
+		return types.NewBlock(
+			&types.Header{Number: new(big.Int).SetUint64(number), Bloom: bloom}, nil, nil, nil, nil,
+		)
+	}
+
+	BeforeEach(func() {
+		hp = &bloomHistoricalPlugin{bits: make(map[[2]uint64][]byte)}
+		bi = newBloomIndexer(&bloomBlockPlugin{}, hp, log.Root())
+	})
+
+	It("should index and serve a full section", func() {
+		addr := common.HexToAddress("0x1234")
+		for n := uint64(1); n < params.BloomBitsBlocks; n++ {
+			block := newBlock(n)
+			if n == 10 {
+				block = newBlock(n, addr)
+			}
+			Expect(bi.Process(ChainEvent{Block: block, Hash: block.Hash()})).To(Succeed())
+		}
+
+		size, sections := bi.BloomStatus()
+		Expect(size).To(Equal(params.BloomBitsBlocks))
+		Expect(sections).To(Equal(uint64(1)))
+		Expect(hp.sections).To(Equal(uint64(1)))
+
+		matcher := bloombits.NewMatcher(params.BloomBitsBlocks, [][][]byte{{addr.Bytes()}})
+		results := make(chan uint64, 16)
+		session, err := matcher.Start(context.Background(), 0, params.BloomBitsBlocks-1, results)
+		Expect(err).ToNot(HaveOccurred())
+		defer session.Close()
+		bi.ServiceFilter(context.Background(), session)
+
+		var matches []uint64
+		for n := range results {
+			matches = append(matches, n)
+		}
+		Expect(matches).To(Equal([]uint64{10}))
+	})
+
+	It("should resume from the persisted sections", func() {
+		hp.sections = 1
+		block := newBlock(params.BloomBitsBlocks + 2)
+		Expect(bi.Process(ChainEvent{Block: block, Hash: block.Hash()})).To(Succeed())
+		Expect(bi.next).To(Equal(params.BloomBitsBlocks + 3))
+		_, sections := bi.BloomStatus()
+		Expect(sections).To(Equal(uint64(1)))
+	})
+})
+
+// bloomBlockPlugin is a block plugin without any historical headers.
+type bloomBlockPlugin struct {
+	BlockPlugin
+}
+
+func (bp *bloomBlockPlugin) GetHeaderByNumber(int64) (*types.Header, error) {
+	return nil, errors.New("not found")
+}
+
+// bloomHistoricalPlugin is an in-memory store of bloom bits.
+type bloomHistoricalPlugin struct {
+	HistoricalPlugin
+	bits     map[[2]uint64][]byte
+	sections uint64
+}
+
+func (hp *bloomHistoricalPlugin) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	bits, ok := hp.bits[[2]uint64{uint64(bit), section}]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return bits, nil
+}
+
+func (hp *bloomHistoricalPlugin) StoreBloomBits(bit uint, section uint64, bits []byte) error {
+	hp.bits[[2]uint64{uint64(bit), section}] = bits
+	return nil
+}
+
+func (hp *bloomHistoricalPlugin) GetBloomSections() (uint64, error) {
+	return hp.sections, nil
+}
+
+func (hp *bloomHistoricalPlugin) StoreBloomSections(sections uint64) error {
+	hp.sections = sections
+	return nil
+}
@@ -42,6 +42,7 @@ var (
 	_ ChainReader     = (*blockchain)(nil)
 	_ ChainSubscriber = (*blockchain)(nil)
 	_ ChainResources  = (*blockchain)(nil)
+	_ BlockBuilder    = (*blockchain)(nil)
 )
 
 // blockchain is the canonical, persistent object that operates the Polaris EVM.
@@ -53,6 +54,8 @@ type blockchain struct {
 	gp GasPlugin
 	sp StatePlugin
 	tp TxPoolPlugin
+	// rhc is the OPTIONAL hook used to roll back the host chain when the chain is rewound.
+	rhc RewindableHostChain
 
 	// StateProcessor is the canonical, persistent state processor that runs the EVM.
 	processor *StateProcessor
@@ -60,6 +63,8 @@ type blockchain struct {
 	statedb vm.PolarisStateDB
 	// vmConfig is the configuration used to create the EVM.
 	vmConfig *vm.Config
+	// bloomIndexer indexes the bloom bits of the chain, if the historical plugin is supported.
+	bloomIndexer *bloomIndexer
 
 	// currentBlock is the current/pending block.
 	currentBlock atomic.Pointer[types.Block]
@@ -69,6 +74,10 @@ type blockchain struct {
 	currentReceipts atomic.Value
 	// currentLogs is the current/pending logs.
 	currentLogs atomic.Value
+	// pending is the pending block built on top of the current block by the pending worker.
+	pending atomic.Pointer[pendingBlock]
+	// pendingWorker is the worker that builds the pending block, if it is running.
+	pendingWorker atomic.Pointer[pendingWorker]
 
 	// receiptsCache is a cache of the receipts for the last `defaultCacheSizeBytes` bytes of
 	// blocks. blockHash -> receipts
@@ -91,8 +100,8 @@ type blockchain struct {
 	chainHeadFeed   event.Feed
 	logsFeed        event.Feed
 	pendingLogsFeed event.Feed
-	rmLogsFeed      event.Feed // currently never used
-	chainSideFeed   event.Feed // currently never used
+	rmLogsFeed      event.Feed
+	chainSideFeed   event.Feed
 }
 
 // =========================================================================
@@ -121,6 +130,15 @@ func NewChain(host PolarisHostChain) *blockchain { //nolint:revive // only used
 	bc.processor = NewStateProcessor(
 		bc.cp, bc.gp, host.GetPrecompilePlugin(), bc.statedb, bc.vmConfig,
 	)
+	if rhc, ok := host.(RewindableHostChain); ok {
+		bc.rhc = rhc
+	}
+	if phc, ok := host.(ParallelHostChain); ok && phc.ParallelExecutionWorkers() > 0 {
+		bc.processor.EnableParallelExecution(bc.sp, phc.ParallelExecutionWorkers())
+	}
+	if bc.hp != nil {
+		bc.bloomIndexer = newBloomIndexer(bc.bp, bc.hp, bc.logger)
+	}
 	bc.currentBlock.Store(nil)
 	bc.finalizedBlock.Store(nil)
 
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+)
+
+// ChainPendingReader defines methods that are used to read the pending block, which is built on
+// top of the chain head with the transactions of the tx pool.
+type ChainPendingReader interface {
+	// PendingBlockAndReceipts returns the pending block and its receipts. If the pending block
+	// has not been built yet, the current block is returned.
+	PendingBlockAndReceipts() (*types.Block, types.Receipts, error)
+	// PendingStateAndHeader returns a statedb of the state after the pending block, along with
+	// the header of the pending block. If the pending block has not been built yet, the state
+	// and header of the current block are returned.
+	PendingStateAndHeader() (vm.GethStateDB, *types.Header, error)
+}
+
+// pendingBlock is the pending block, along with its receipts and the state after it.
+type pendingBlock struct {
+	block    *types.Block
+	receipts types.Receipts
+	// state is the read-only state after the block, which is shared by the readers.
+	state state.Plugin
+}
+
+// pendingWorker passes the new chain heads to the goroutine that builds the pending block.
+type pendingWorker struct {
+	// heads holds the latest head that the pending block has not been built on yet.
+	heads chan *pendingHead
+}
+
+// pendingHead is a chain head that the pending block is built on top of.
+type pendingHead struct {
+	block *types.Block
+	// baseFee is the base fee of the block following the head. It is computed by the goroutine
+	// that processes blocks, as the block plugin reads from the live state of the host chain.
+	baseFee *big.Int
+}
+
+// =========================================================================
+// Pending Worker
+// =========================================================================
+
+// StartPendingWorker starts the worker that builds the pending block on top of every new head,
+// until the given context is done. The new heads are coalesced, so that processing blocks is never
+// blocked by the worker, and the pending block is rebuilt at most once every `recommit` interval.
+// The pending block is built by executing the txs selected from the tx pool on an in-memory
+// overlay of the state at the head, read at the height of the head, so it never touches the live
+// state of the host chain.
+func (bc *blockchain) StartPendingWorker(ctx context.Context, recommit time.Duration) {
+	w := &pendingWorker{heads: make(chan *pendingHead, 1)}
+	if !bc.pendingWorker.CompareAndSwap(nil, w) {
+		return
+	}
+
+	go func() {
+		defer func() {
+			bc.pendingWorker.CompareAndSwap(w, nil)
+			bc.pending.Store(nil)
+		}()
+
+		var last time.Time
+		for {
+			select {
+			case head := <-w.heads:
+				// Wait for the recommit interval, picking up the heads that arrive meanwhile.
+				if wait := recommit - time.Since(last); wait > 0 {
+					select {
+					case <-time.After(wait):
+					case <-ctx.Done():
+						return
+					}
+					select {
+					case head = <-w.heads:
+					default:
+					}
+				}
+				last = time.Now()
+				if err := bc.buildPendingBlock(ctx, head); err != nil {
+					bc.logger.Error("failed to build pending block", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// schedulePendingBlock passes the given new head to the pending worker, if it is running,
+// replacing the head that the worker has not picked up yet. It must be called by the goroutine
+// that processes blocks.
+func (bc *blockchain) schedulePendingBlock(head *types.Block) {
+	w := bc.pendingWorker.Load()
+	if w == nil {
+		return
+	}
+	select {
+	case <-w.heads:
+	default:
+	}
+	w.heads <- &pendingHead{block: head, baseFee: bc.bp.BaseFee(head.Header())}
+}
+
+// buildPendingBlock builds the pending block on top of the given head. Only the historical views
+// of the plugins at the height of the head are used, as the pending block is built concurrently
+// with the processing of blocks. If no tx of the tx pool is selected, the pending block is cleared
+// and the readers fall back to the current block.
+func (bc *blockchain) buildPendingBlock(ctx context.Context, head *pendingHead) error {
+	parent := head.block.Header()
+	height := parent.Number.Int64() + 1
+	sp, err := bc.sp.GetStateByNumber(parent.Number.Int64())
+	if err != nil {
+		return err
+	}
+	chainCfg, err := bc.cp.ChainConfigAt(height)
+	if err != nil {
+		return err
+	}
+	txs, _ := bc.selectTransactions(
+		sp, types.MakeSigner(chainCfg, big.NewInt(height)), head.baseFee, parent.GasLimit, false,
+	)
+	if len(txs) == 0 {
+		bc.pending.Store(nil)
+		return nil
+	}
+
+	// The pending block is built on an overlay of the state at the head, so that the state of
+	// the pending block can be read after it is built.
+	overlay := state.NewOverlay(sp)
+	statedb := state.NewStateDB(overlay)
+	timestamp := uint64(time.Now().Unix())
+	if parent.Time >= timestamp {
+		timestamp = parent.Time + 1
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   parent.Coinbase,
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(height),
+		GasLimit:   parent.GasLimit,
+		Time:       timestamp,
+		Extra:      []byte{},
+		BaseFee:    head.baseFee,
+	}
+
+	gp := newReplayGasPlugin(header.GasLimit)
+	processor := NewStateProcessor(
+		&historicalConfigPlugin{bc.cp, chainCfg}, gp, bc.replayPrecompilePlugin(), statedb,
+		&vm.Config{},
+	)
+	evm := bc.newEVM(vm.TxContext{}, statedb, header, chainCfg, processor.vmConfig, processor.pp)
+	processor.Prepare(ctx, evm, header)
+	for _, tx := range txs {
+		gp.Reset(ctx)
+		if _, err = processor.ProcessTransaction(ctx, tx); err != nil {
+			bc.logger.Debug("skipping pending transaction", "tx hash", tx.Hash().Hex(), "err", err)
+		}
+	}
+	gp.Reset(ctx)
+	block, receipts, _, err := processor.Finalize(ctx)
+	if err != nil {
+		return err
+	}
+
+	bc.pending.Store(&pendingBlock{
+		block:    block,
+		receipts: receipts,
+		state:    &lockedPlugin{Plugin: overlay},
+	})
+	return nil
+}
+
+// =========================================================================
+// Pending Reader
+// =========================================================================
+
+// PendingBlockAndReceipts implements `ChainPendingReader`.
+func (bc *blockchain) PendingBlockAndReceipts() (*types.Block, types.Receipts, error) {
+	if pending := bc.currentPending(); pending != nil {
+		return pending.block, pending.receipts, nil
+	}
+	return bc.CurrentBlockAndReceipts()
+}
+
+// PendingStateAndHeader implements `ChainPendingReader`. Every call returns a new statedb on top
+// of the pending state, so that the callers can not modify the pending state.
+func (bc *blockchain) PendingStateAndHeader() (vm.GethStateDB, *types.Header, error) {
+	if pending := bc.currentPending(); pending != nil {
+		return state.NewStateDB(state.NewQueryOverlay(pending.state)), pending.block.Header(), nil
+	}
+	block, err := bc.CurrentBlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	statedb, err := bc.GetStateByNumber(block.Number().Int64())
+	if err != nil {
+		return nil, nil, err
+	}
+	return statedb, block.Header(), nil
+}
+
+// currentPending returns the pending block if it is built on top of the current block, or nil.
+func (bc *blockchain) currentPending() *pendingBlock {
+	pending := bc.pending.Load()
+	if pending == nil {
+		return nil
+	}
+	current, err := bc.CurrentBlock()
+	if err != nil || pending.block.ParentHash() != current.Hash() {
+		return nil
+	}
+	return pending
+}
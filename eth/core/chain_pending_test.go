@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"math/big"
+
+	lru "github.com/ethereum/go-ethereum/common/lru"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/log"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pending Block", func() {
+	var (
+		state *builderStatePlugin
+		tp    *builderTxPoolPlugin
+		bc    *blockchain
+		head  *types.Block
+	)
+	key, _ := crypto.GenerateEthKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	recipient := common.Address{1}
+
+	BeforeEach(func() {
+		state = &builderStatePlugin{
+			nonces:   map[common.Address]uint64{},
+			balances: map[common.Address]*big.Int{sender: big.NewInt(1e18)},
+		}
+		tp = &builderTxPoolPlugin{pending: make(map[common.Address]types.Transactions)}
+		bc = &blockchain{
+			bp:             &builderBlockPlugin{baseFee: big.NewInt(100)},
+			cp:             &builderConfigPlugin{},
+			gp:             &builderGasPlugin{limit: 100000},
+			sp:             state,
+			tp:             tp,
+			receiptsCache:  lru.NewCache[common.Hash, types.Receipts](defaultCacheSizeBytes),
+			blockNumCache:  lru.NewCache[int64, *types.Block](defaultCacheSizeBytes),
+			blockHashCache: lru.NewCache[common.Hash, *types.Block](defaultCacheSizeBytes),
+			logger:         log.Root(),
+		}
+		bc.processor = NewStateProcessor(bc.cp, bc.gp, nil, nil, &vm.Config{})
+
+		head = types.NewBlockWithHeader(&types.Header{
+			Number: big.NewInt(1), GasLimit: 100000, BaseFee: big.NewInt(100),
+		})
+		bc.currentBlock.Store(head)
+	})
+
+	It("should fall back to the current block before the pending block is built", func() {
+		block, _, err := bc.PendingBlockAndReceipts()
+		Expect(err).To(HaveOccurred())
+		Expect(block).To(BeNil())
+
+		bc.currentReceipts.Store(types.Receipts{})
+		block, _, err = bc.PendingBlockAndReceipts()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(block).To(Equal(head))
+	})
+
+	It("should build the pending block with the txs of the tx pool", func() {
+		tx := types.MustSignNewTx(key, types.LatestSignerForChainID(params.DefaultChainConfig.ChainID),
+			&types.DynamicFeeTx{
+				ChainID:   params.DefaultChainConfig.ChainID,
+				GasTipCap: big.NewInt(1),
+				GasFeeCap: big.NewInt(101),
+				Gas:       21000,
+				To:        &recipient,
+				Value:     big.NewInt(7),
+			})
+		tp.pending[sender] = types.Transactions{tx}
+		Expect(bc.buildPendingBlock(
+			context.Background(), &pendingHead{block: head, baseFee: big.NewInt(100)},
+		)).To(Succeed())
+
+		block, receipts, err := bc.PendingBlockAndReceipts()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(block.NumberU64()).To(Equal(uint64(2)))
+		Expect(block.ParentHash()).To(Equal(head.Hash()))
+		Expect(block.Transactions()).To(HaveLen(1))
+		Expect(receipts).To(HaveLen(1))
+		Expect(receipts[0].Status).To(Equal(types.ReceiptStatusSuccessful))
+
+		statedb, header, err := bc.PendingStateAndHeader()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Number.Int64()).To(Equal(int64(2)))
+		Expect(statedb.GetBalance(recipient)).To(Equal(big.NewInt(7)))
+		Expect(statedb.GetNonce(sender)).To(Equal(uint64(1)))
+
+		// the state of the host chain is never written to.
+		Expect(state.GetNonce(sender)).To(Equal(uint64(0)))
+		Expect(state.GetBalance(recipient).Sign()).To(Equal(0))
+
+		// the pending block is cleared once the tx pool is empty.
+		delete(tp.pending, sender)
+		Expect(bc.buildPendingBlock(
+			context.Background(), &pendingHead{block: head, baseFee: big.NewInt(100)},
+		)).To(Succeed())
+		Expect(bc.pending.Load()).To(BeNil())
+	})
+
+	It("should build the pending block on the new heads until the worker is stopped", func() {
+		// no head is passed to the worker before it is started.
+		bc.schedulePendingBlock(head)
+		Expect(bc.pendingWorker.Load()).To(BeNil())
+
+		tx := types.MustSignNewTx(key, types.LatestSignerForChainID(params.DefaultChainConfig.ChainID),
+			&types.LegacyTx{Gas: 21000, GasPrice: big.NewInt(101), To: &recipient})
+		tp.pending[sender] = types.Transactions{tx}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		bc.StartPendingWorker(ctx, 0)
+		Expect(bc.pendingWorker.Load()).ToNot(BeNil())
+		bc.schedulePendingBlock(head)
+		Eventually(func() *pendingBlock { return bc.pending.Load() }).ShouldNot(BeNil())
+		Expect(bc.pending.Load().block.Transactions()).To(HaveLen(1))
+
+		cancel()
+		Eventually(func() *pendingWorker { return bc.pendingWorker.Load() }).Should(BeNil())
+		Expect(bc.pending.Load()).To(BeNil())
+	})
+})
@@ -21,8 +21,11 @@
 package core
 
 import (
+	"context"
 	"errors"
 
+	"github.com/ethereum/go-ethereum/core/bloombits"
+
 	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core/types"
 	"pkg.berachain.dev/polaris/eth/params"
@@ -33,8 +36,11 @@ import (
 type ChainReader interface {
 	ChainBlockReader
 	ChainTxPoolReader
+	ChainBloomReader
+	ChainPendingReader
 	ChainSubscriber
 	ChainConfig() *params.ChainConfig
+	ChainConfigAt(int64) (*params.ChainConfig, error)
 }
 
 // ChainBlockReader defines methods that are used to read information about blocks in the chain.
@@ -54,6 +60,15 @@ type ChainTxPoolReader interface {
 	GetPoolTransactions() (types.Transactions, error)
 	GetPoolTransaction(common.Hash) *types.Transaction
 	GetPoolNonce(common.Address) (uint64, error)
+	GetPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	GetPoolContentFrom(common.Address) (types.Transactions, types.Transactions)
+	GetPoolStats() (int, int)
+}
+
+// ChainBloomReader defines methods that are used to read the bloom bits index of the chain.
+type ChainBloomReader interface {
+	BloomStatus() (uint64, uint64)
+	ServiceFilter(context.Context, *bloombits.MatcherSession)
 }
 
 // =========================================================================
@@ -65,6 +80,11 @@ func (bc *blockchain) ChainConfig() *params.ChainConfig {
 	return bc.cp.ChainConfig()
 }
 
+// ChainConfigAt returns the Ethereum chain config that was active at the given block number.
+func (bc *blockchain) ChainConfigAt(number int64) (*params.ChainConfig, error) {
+	return bc.cp.ChainConfigAt(number)
+}
+
 // =========================================================================
 // BlockReader
 // =========================================================================
@@ -184,7 +204,7 @@ func (bc *blockchain) GetPolarisBlockByNumber(number int64) (*types.Block, error
 	// Cache the found block for next time and return
 	bc.blockNumCache.Add(number, block)
 	bc.blockHashCache.Add(block.Hash(), block)
-	return nil, ErrBlockNotFound
+	return block, nil
 }
 
 // GetBlockByHash retrieves a block from the database by hash, caching it if found.
@@ -228,14 +248,57 @@ func (bc *blockchain) GetPoolTransaction(hash common.Hash) *types.Transaction {
 	return bc.tp.GetTransaction(hash)
 }
 
-// TODO: define behaviour for this function.
+// GetPoolNonce returns the next nonce of the given address, taking into account the pending
+// transactions of the address in the mempool. If the mempool is unaware of the address, the
+// state nonce is returned.
 func (bc *blockchain) GetPoolNonce(addr common.Address) (uint64, error) {
-	// nonce, err := bc.tp.GetNonce(addr)
-	// defer bc.logger.Info("called eth.rpc.backend.GetPoolNonce", "addr", addr, "nonce", nonce)
-	// return nonce, err
-	// nonce, err := bc.tp.GetNonce(addr)
-	// TODO: FIX THIS
-	nonce := bc.sp.GetNonce(addr)
-	defer bc.logger.Info("called eth.rpc.backend.GetPoolNonce", "addr", addr, "nonce", nonce)
+	nonce, err := bc.tp.GetNonce(addr)
+	if err != nil {
+		return 0, err
+	}
+	if stateNonce := bc.sp.GetNonce(addr); stateNonce > nonce {
+		nonce = stateNonce
+	}
 	return nonce, nil
 }
+
+// GetPoolContent returns the pending and queued transactions of the mempool, grouped by sender.
+func (bc *blockchain) GetPoolContent() (
+	map[common.Address]types.Transactions, map[common.Address]types.Transactions,
+) {
+	return bc.tp.Content()
+}
+
+// GetPoolContentFrom returns the pending and queued transactions of the given sender in the
+// mempool.
+func (bc *blockchain) GetPoolContentFrom(
+	addr common.Address,
+) (types.Transactions, types.Transactions) {
+	return bc.tp.ContentFrom(addr)
+}
+
+// GetPoolStats returns the number of pending and queued transactions in the mempool.
+func (bc *blockchain) GetPoolStats() (int, int) {
+	return bc.tp.Stats()
+}
+
+// =========================================================================
+// BloomReader
+// =========================================================================
+
+// BloomStatus returns the section size and the number of fully indexed bloom bits sections.
+func (bc *blockchain) BloomStatus() (uint64, uint64) {
+	if bc.bloomIndexer == nil {
+		return params.BloomBitsBlocks, 0
+	}
+	return bc.bloomIndexer.BloomStatus()
+}
+
+// ServiceFilter services the bloom bits retrievals of the given matcher session.
+func (bc *blockchain) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {
+	if bc.bloomIndexer == nil {
+		bc.logger.Debug("historical plugin not supported by host chain")
+		return
+	}
+	bc.bloomIndexer.ServiceFilter(ctx, session)
+}
@@ -22,42 +22,178 @@ package core
 
 import (
 	"context"
+	"errors"
 
+	"pkg.berachain.dev/polaris/eth/core/precompile"
 	"pkg.berachain.dev/polaris/eth/core/state"
 	"pkg.berachain.dev/polaris/eth/core/types"
 	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/params"
 )
 
 // ChainResources is the interface that defines functions for code paths within the chain to acquire
 // resources to use in execution such as StateDBss and EVMss.
 type ChainResources interface {
 	GetStateByNumber(int64) (vm.GethStateDB, error)
-	GetEVM(context.Context, vm.TxContext, vm.PolarisStateDB, *types.Header, *vm.Config) *vm.GethEVM
+	StateAtTransaction(context.Context, *types.Block, int) (*Message, vm.PolarisStateDB, error)
+	ReplayAtTransaction(context.Context, *types.Block, int) (*Message, Replay, error)
+	GetEVM(
+		context.Context, vm.TxContext, vm.PolarisStateDB, *types.Header, *vm.Config,
+	) (*vm.GethEVM, error)
 }
 
 // GetStateByNumber returns a statedb configured to read what the state of the blockchain is/was
-// at a given block number.
+// at a given block number. The statedb is backed by an in-memory overlay, so that writes to it,
+// such as state overrides, never reach the stores of the host chain.
 func (bc *blockchain) GetStateByNumber(number int64) (vm.GethStateDB, error) {
 	sp, err := bc.sp.GetStateByNumber(number)
 	if err != nil {
 		return nil, err
 	}
-	return state.NewStateDB(sp), nil
+	return state.NewStateDB(state.NewQueryOverlay(sp)), nil
+}
+
+// Replay is a block whose transactions are replayed on top of the state at the end of its parent
+// block, in the same environment that the `StateProcessor` executed them in.
+type Replay interface {
+	// StateDB returns the statedb that the transactions are replayed on.
+	StateDB() vm.PolarisStateDB
+	// GetEVM returns an EVM that executes on the statedb of the replay and runs the precompiles
+	// of the replay, which are never the ones of the precompile plugin that processes blocks.
+	GetEVM(vm.TxContext, *vm.Config) *vm.GethEVM
+	// Finalize finalizes the state changes of a transaction that was executed with an EVM of the
+	// replay, after crediting the base fee collector like `StateProcessor.ProcessTransaction`.
+	Finalize(*ExecutionResult)
+}
+
+// StateAtTransaction returns the message of the transaction at index `txIndex` in the given
+// block, along with a statedb that reflects the state of the chain right before that transaction
+// was executed.
+func (bc *blockchain) StateAtTransaction(
+	ctx context.Context, block *types.Block, txIndex int,
+) (*Message, vm.PolarisStateDB, error) {
+	msg, r, err := bc.ReplayAtTransaction(ctx, block, txIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, r.StateDB(), nil
+}
+
+// ReplayAtTransaction returns the message of the transaction at index `txIndex` in the given
+// block, along with a replay of the block whose statedb reflects the state of the chain right
+// before that transaction was executed. The state is built by replaying all of the preceding
+// transactions in the block through a `StateProcessor` on top of the state at the end of the
+// parent block.
+func (bc *blockchain) ReplayAtTransaction(
+	ctx context.Context, block *types.Block, txIndex int,
+) (*Message, Replay, error) {
+	if block.NumberU64() == 0 {
+		return nil, nil, errors.New("no transactions in genesis")
+	}
+	txs := block.Transactions()
+	if txIndex < 0 || txIndex >= len(txs) {
+		return nil, nil, ErrTxNotFound
+	}
+
+	// Load the state at the end of the parent block, behind an overlay so that replaying the
+	// transactions does not write to the stores of the host chain.
+	sp, err := bc.sp.GetStateByNumber(block.Number().Int64() - 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	statedb := state.NewStateDB(state.NewQueryOverlay(sp))
+
+	// Build a processor that uses an in-memory gas plugin, so that replaying the transactions
+	// does not interfere with the gas meters of the host chain, and the chain config that was
+	// active at the given block.
+	header := block.Header()
+	chainCfg, err := bc.cp.ChainConfigAt(header.Number.Int64())
+	if err != nil {
+		return nil, nil, err
+	}
+	gp := newReplayGasPlugin(header.GasLimit)
+	processor := NewStateProcessor(
+		&historicalConfigPlugin{bc.cp, chainCfg}, gp, bc.replayPrecompilePlugin(), statedb,
+		&vm.Config{},
+	)
+	evm := bc.newEVM(vm.TxContext{}, statedb, header, chainCfg, processor.vmConfig, processor.pp)
+	processor.Prepare(ctx, evm, header)
+
+	// Replay all of the transactions prior to `txIndex`.
+	for _, tx := range txs[:txIndex] {
+		gp.Reset(ctx)
+		if _, err = processor.ProcessTransaction(ctx, tx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	msg, err := TransactionToMessage(txs[txIndex], processor.signer, header.BaseFee)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, &replay{bc: bc, processor: processor, chainCfg: chainCfg}, nil
 }
 
 // GetEVM returns an EVM ready to be used for executing transactions. It is used by both the StateProcessor
 // to acquire a new EVM at the start of every block. As well as by the backend to acquire an EVM for running
-// gas estimations, eth_call etc.
+// gas estimations, eth_call etc. The EVM uses the chain config that was active at the header's height.
 func (bc *blockchain) GetEVM(
 	_ context.Context, txContext vm.TxContext, state vm.PolarisStateDB,
 	header *types.Header, vmConfig *vm.Config,
+) (*vm.GethEVM, error) {
+	chainCfg, err := bc.cp.ChainConfigAt(header.Number.Int64())
+	if err != nil {
+		return nil, err
+	}
+	return bc.newEVM(txContext, state, header, chainCfg, vmConfig, bc.processor.pp), nil
+}
+
+// newEVM returns an EVM with the given chain config that runs the precompiles of the given
+// precompile plugin.
+func (bc *blockchain) newEVM(
+	txContext vm.TxContext, state vm.PolarisStateDB, header *types.Header,
+	chainCfg *params.ChainConfig, vmConfig *vm.Config, pp PrecompilePlugin,
 ) *vm.GethEVM {
-	chainCfg := bc.processor.cp.ChainConfig() // TODO: get chain config at height.
 	return vm.NewGethEVMWithPrecompiles(
-		bc.NewEVMBlockContext(header), txContext, state, chainCfg, *vmConfig, bc.processor.pp,
+		bc.NewEVMBlockContext(header), txContext, state, chainCfg, *vmConfig, pp,
 	)
 }
 
+// replayPrecompilePlugin returns the precompile plugin that replays of blocks run with. It is a
+// fresh instance of the precompile plugin of the host chain if it can be cloned, so that replays
+// do not register precompiles in, or share any state with, the plugin that processes blocks.
+func (bc *blockchain) replayPrecompilePlugin() PrecompilePlugin {
+	if pp, ok := bc.processor.pp.(precompile.ClonablePlugin); ok {
+		return pp.Clone()
+	}
+	return bc.processor.pp
+}
+
+// replay is a `Replay` on top of the processor that replayed the transactions of a block.
+type replay struct {
+	bc        *blockchain
+	processor *StateProcessor
+	chainCfg  *params.ChainConfig
+}
+
+// StateDB implements `Replay`.
+func (r *replay) StateDB() vm.PolarisStateDB {
+	return r.processor.statedb
+}
+
+// GetEVM implements `Replay`.
+func (r *replay) GetEVM(txContext vm.TxContext, vmConfig *vm.Config) *vm.GethEVM {
+	return r.bc.newEVM(
+		txContext, r.processor.statedb, r.processor.header, r.chainCfg, vmConfig, r.processor.pp,
+	)
+}
+
+// Finalize implements `Replay`.
+func (r *replay) Finalize(result *ExecutionResult) {
+	r.processor.creditBaseFee(result.UsedGas)
+	r.processor.statedb.Finalize()
+}
+
 // NewEVMBlockContext creates a new block context for use in the EVM.
 func (bc *blockchain) NewEVMBlockContext(header *types.Header) vm.BlockContext {
 	feeCollector := bc.cp.FeeCollector()
@@ -66,3 +202,15 @@ func (bc *blockchain) NewEVMBlockContext(header *types.Header) vm.BlockContext {
 	}
 	return NewEVMBlockContext(header, &chainContext{bc}, feeCollector)
 }
+
+// historicalConfigPlugin is a `ConfigurationPlugin` that returns the chain config that was active at
+// a historical block, used for replaying the transactions of that block.
+type historicalConfigPlugin struct {
+	ConfigurationPlugin
+	chainConfig *params.ChainConfig
+}
+
+// ChainConfig implements `ConfigurationPlugin`.
+func (hcp *historicalConfigPlugin) ChainConfig() *params.ChainConfig {
+	return hcp.chainConfig
+}
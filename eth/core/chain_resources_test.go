@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/trie"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/precompile"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateAtTransaction", func() {
+	var (
+		state *builderStatePlugin
+		pp    precompile.Plugin
+		bc    *blockchain
+		block *types.Block
+		alice common.Address
+		bob   = common.Address{2}
+	)
+
+	BeforeEach(func() {
+		key, _ := crypto.GenerateEthKey()
+		alice = crypto.PubkeyToAddress(key.PublicKey)
+		state = &builderStatePlugin{
+			nonces:   make(map[common.Address]uint64),
+			balances: map[common.Address]*big.Int{alice: big.NewInt(1e18)},
+		}
+		pp = precompile.NewDefaultPlugin()
+		bc = &blockchain{
+			bp:        &builderBlockPlugin{baseFee: big.NewInt(1)},
+			cp:        &builderConfigPlugin{},
+			sp:        state,
+			processor: &StateProcessor{pp: pp},
+		}
+
+		signer := types.LatestSignerForChainID(params.DefaultChainConfig.ChainID)
+		txs := make(types.Transactions, 3)
+		for i := range txs {
+			txs[i] = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+				ChainID:   params.DefaultChainConfig.ChainID,
+				Nonce:     uint64(i),
+				GasTipCap: big.NewInt(1),
+				GasFeeCap: big.NewInt(2),
+				Gas:       params.TxGas,
+				To:        &bob,
+				Value:     big.NewInt(1000),
+			})
+		}
+		block = types.NewBlock(&types.Header{
+			Number:     big.NewInt(1),
+			GasLimit:   1e6,
+			BaseFee:    big.NewInt(1),
+			Difficulty: new(big.Int),
+		}, txs, nil, nil, trie.NewStackTrie(nil))
+	})
+
+	It("should replay the transactions before the given index", func() {
+		msg, statedb, err := bc.StateAtTransaction(context.Background(), block, 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(msg.Nonce).To(Equal(uint64(2)))
+		Expect(statedb.GetNonce(alice)).To(Equal(uint64(2)))
+		Expect(statedb.GetBalance(bob)).To(Equal(big.NewInt(2000)))
+
+		// The state of the host chain is never written to.
+		Expect(state.nonces[alice]).To(BeZero())
+		Expect(state.balances).ToNot(HaveKey(bob))
+	})
+
+	It("should not register precompiles in the precompile plugin of the chain", func() {
+		_, _, err := bc.StateAtTransaction(context.Background(), block, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pp.Has(common.BytesToAddress([]byte{1}))).To(BeFalse())
+	})
+
+	It("should replay the transactions like the state processor", func() {
+		collector := common.Address{3}
+		bc.cp = &builderConfigPlugin{baseFeeCollector: &collector}
+		msg, replay, err := bc.ReplayAtTransaction(context.Background(), block, 2)
+		Expect(err).ToNot(HaveOccurred())
+		statedb := replay.StateDB()
+		Expect(statedb.GetBalance(collector)).To(Equal(big.NewInt(2 * int64(params.TxGas))))
+
+		// The transaction is executed with the precompiles of the replay, and finalized with the
+		// base fee credited to the collector.
+		result, err := ApplyMessage(
+			replay.GetEVM(NewEVMTxContext(msg), &vm.Config{}), msg, new(GasPool).AddGas(msg.GasLimit),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		replay.Finalize(result)
+		Expect(statedb.GetBalance(collector)).To(Equal(big.NewInt(3 * int64(params.TxGas))))
+		Expect(statedb.GetBalance(bob)).To(Equal(big.NewInt(3000)))
+		Expect(pp.Has(common.BytesToAddress([]byte{1}))).To(BeFalse())
+	})
+
+	It("should reject out of range indexes", func() {
+		_, _, err := bc.StateAtTransaction(context.Background(), block, 3)
+		Expect(err).To(MatchError(ErrTxNotFound))
+	})
+})
+
+func (sp *builderStatePlugin) Empty(addr common.Address) bool {
+	return !sp.Exist(addr)
+}
+
+func (sp *builderStatePlugin) GetState(common.Address, common.Hash) common.Hash {
+	return common.Hash{}
+}
+
+func (sp *builderStatePlugin) GetCommittedState(common.Address, common.Hash) common.Hash {
+	return common.Hash{}
+}
@@ -27,12 +27,13 @@ import (
 )
 
 type ChainSubscriber interface {
-	SubscribeRemovedLogsEvent(chan<- RemovedLogsEvent) event.Subscription // currently not used
+	SubscribeRemovedLogsEvent(chan<- RemovedLogsEvent) event.Subscription
 	SubscribeChainEvent(chan<- ChainEvent) event.Subscription
 	SubscribeChainHeadEvent(chan<- ChainHeadEvent) event.Subscription
-	SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Subscription // currently not used
+	SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription
+	SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscription
 }
 
 // SubscribeRemovedLogsEvent registers a subscription of RemovedLogsEvent.
@@ -64,3 +65,8 @@ func (bc *blockchain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 func (bc *blockchain) SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return bc.scope.Track(bc.pendingLogsFeed.Subscribe(ch))
 }
+
+// SubscribeNewTxsEvent registers a subscription of NewTxsEvent.
+func (bc *blockchain) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscription {
+	return bc.scope.Track(bc.tp.SubscribeNewTxsEvent(ch))
+}
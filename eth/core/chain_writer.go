@@ -22,7 +22,9 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/vm"
 
@@ -38,10 +40,27 @@ type ChainWriter interface {
 	// ProcessTransaction processes the given transaction and returns the receipt after applying
 	// the state transition. This method is called for each tx in the block.
 	ProcessTransaction(context.Context, *types.Transaction) (*ExecutionResult, error)
+	// SpeculateTransactions starts the speculative, parallel, execution of the given txs, which
+	// are expected to be processed next in the block. It is a no-op unless the host chain opts
+	// into parallel execution.
+	SpeculateTransactions(context.Context, types.Transactions) error
 	// Finalize is called after the last tx in the block.
 	Finalize(context.Context) error
+	// Discard discards the block that is being processed or, if there is none, the last
+	// finalized block that has not been committed by the host chain.
+	Discard(context.Context) error
 	// SendTx sends the given transaction to the tx pool.
 	SendTx(ctx context.Context, signedTx *types.Transaction) error
+	// SendPrivTx sends the given transaction to the local tx pool, without gossiping it, until
+	// the given block number.
+	SendPrivTx(ctx context.Context, signedTx *types.Transaction, maxBlockNumber uint64) error
+	// CancelPrivTx cancels the private transaction with the given hash.
+	CancelPrivTx(ctx context.Context, hash common.Hash) bool
+	// SetHead rewinds the chain to the given block number, dropping all of the blocks above it.
+	SetHead(int64) error
+	// StartPendingWorker starts building the pending block on top of every new chain head, at
+	// most once every given recommit interval, until the given context is done.
+	StartPendingWorker(context.Context, time.Duration)
 }
 
 // =========================================================================
@@ -63,9 +82,13 @@ func (bc *blockchain) Prepare(ctx context.Context, height int64) {
 	bc.logger.Info("Preparing block", "height", height, "coinbase", coinbase.Hex(), "timestamp", timestamp)
 
 	// Build the new block header.
-	var parentHash common.Hash
+	var (
+		parent     *types.Header
+		parentHash common.Hash
+		err        error
+	)
 	if height > 1 {
-		parent, err := bc.bp.GetHeaderByNumber(height - 1)
+		parent, err = bc.bp.GetHeaderByNumber(height - 1)
 		if err != nil {
 			panic(err)
 		}
@@ -75,7 +98,7 @@ func (bc *blockchain) Prepare(ctx context.Context, height int64) {
 		ParentHash: parentHash,
 		UncleHash:  types.EmptyUncleHash,
 		Coinbase:   coinbase,
-		Root:       common.Hash{}, // Polaris does not use the Ethereum state root.
+		Root:       bc.bp.GetStateRoot(height),
 		Difficulty: big.NewInt(0),
 		Number:     big.NewInt(height),
 		GasLimit:   bc.gp.BlockGasLimit(),
@@ -83,14 +106,14 @@ func (bc *blockchain) Prepare(ctx context.Context, height int64) {
 		Extra:      []byte{}, // Polaris does not set the Extra field.
 		MixDigest:  common.Hash{},
 		Nonce:      types.BlockNonce{},
-		BaseFee:    big.NewInt(int64(bc.bp.BaseFee())), // TODO: change for EIP-1559.
+		BaseFee:    bc.bp.BaseFee(parent),
 	}
 
-	bc.processor.Prepare(
-		ctx,
-		bc.GetEVM(ctx, vm.TxContext{}, bc.statedb, header, bc.vmConfig),
-		header,
-	)
+	evm, err := bc.GetEVM(ctx, vm.TxContext{}, bc.statedb, header, bc.vmConfig)
+	if err != nil {
+		panic(err)
+	}
+	bc.processor.Prepare(ctx, evm, header)
 }
 
 // ProcessTransaction processes the given transaction and returns the receipt.
@@ -104,6 +127,11 @@ func (bc *blockchain) ProcessTransaction(ctx context.Context, tx *types.Transact
 	return bc.processor.ProcessTransaction(ctx, tx)
 }
 
+// SpeculateTransactions starts the speculative execution of the given transactions.
+func (bc *blockchain) SpeculateTransactions(ctx context.Context, txs types.Transactions) error {
+	return bc.processor.SpeculateTransactions(ctx, txs)
+}
+
 // Finalize finalizes the current block.
 func (bc *blockchain) Finalize(ctx context.Context) error {
 	block, receipts, logs, err := bc.processor.Finalize(ctx)
@@ -167,9 +195,18 @@ func (bc *blockchain) Finalize(ctx context.Context) error {
 		}
 	}
 
+	// Index the bloom of the block, a failure to do so should not halt the chain.
+	chainEvent := ChainEvent{Block: block, Hash: blockHash, Logs: logs}
+	if bc.bloomIndexer != nil {
+		if err = bc.bloomIndexer.Process(chainEvent); err != nil {
+			bc.logger.Error("failed to index block bloom", "block", blockNum, "err", err)
+		}
+	}
+
 	// Send chain events.
-	bc.chainFeed.Send(ChainEvent{Block: block, Hash: blockHash, Logs: logs})
+	bc.chainFeed.Send(chainEvent)
 	bc.chainHeadFeed.Send(ChainHeadEvent{Block: block})
+	bc.schedulePendingBlock(block)
 
 	return nil
 }
@@ -177,3 +214,174 @@ func (bc *blockchain) Finalize(ctx context.Context) error {
 func (bc *blockchain) SendTx(_ context.Context, signedTx *types.Transaction) error {
 	return bc.tp.SendTx(signedTx)
 }
+
+func (bc *blockchain) SendPrivTx(
+	_ context.Context, signedTx *types.Transaction, maxBlockNumber uint64,
+) error {
+	return bc.tp.SendPrivTx(signedTx, maxBlockNumber)
+}
+
+func (bc *blockchain) CancelPrivTx(_ context.Context, hash common.Hash) bool {
+	return bc.tp.CancelPrivTx(hash)
+}
+
+// =========================================================================
+// Chain Rewind
+// =========================================================================
+
+// Discard discards the block that is being processed, if `Prepare` was called without `Finalize`.
+// As nothing about the block has been sent to subscribers yet, no events are sent. Otherwise, the
+// last finalized block is discarded, which must not have been committed by the host chain yet:
+// the chain is rewound to its parent, without rolling back the host chain, and a `ChainSideEvent`
+// and `RemovedLogsEvent` are sent so that subscribers can drop the logs of the block. Discarding
+// the first block rewinds the chain to genesis, after which there is no current block until the
+// next block is finalized.
+func (bc *blockchain) Discard(ctx context.Context) error {
+	if block := bc.processor.Discard(ctx); block != nil {
+		bc.logger.Info(
+			"Discarding block", "height", block.Number(), "num txs", len(block.Transactions()),
+		)
+		return nil
+	}
+
+	head, err := bc.CurrentBlock()
+	if err != nil {
+		return err
+	}
+	bc.logger.Info("Discarding finalized block", "block", head.Hash().Hex(), "height", head.Number())
+	return bc.rewind(head.Number().Int64()-1, false)
+}
+
+// SetHead rewinds the chain to the given block number. The host chain is rolled back via its
+// `RewindableHostChain` hook, the historical data and cached data of the dropped blocks are
+// removed, and a `ChainSideEvent` and `RemovedLogsEvent` are sent for each dropped block, starting
+// from the old head. `ErrRewindNotSupported` is returned, without modifying the chain, if the host
+// chain does not implement the hook. SetHead must not be called while a block is being processed.
+func (bc *blockchain) SetHead(number int64) error {
+	if bc.rhc == nil {
+		return ErrRewindNotSupported
+	}
+	// The host chain can not be rolled back to genesis.
+	if number < 1 {
+		return fmt.Errorf("%w: %d, cannot roll back the host chain to genesis", ErrInvalidHead, number)
+	}
+	return bc.rewind(number, true)
+}
+
+// rewind rewinds the chain to the given block number, rolling back the host chain if requested.
+// Polaris does not store a genesis block, so rewinding to genesis (0) leaves the chain without a
+// current block.
+func (bc *blockchain) rewind(number int64, rollbackHost bool) error {
+	head, err := bc.CurrentBlock()
+	if err != nil {
+		return err
+	}
+	headNum := head.Number().Int64()
+	if number < 0 || number > headNum {
+		return fmt.Errorf("%w: %d, current head is %d", ErrInvalidHead, number, headNum)
+	}
+	if number == headNum {
+		return nil
+	}
+	bc.logger.Info("Rewinding chain", "from", headNum, "to", number)
+
+	// Load the new head and the dropped blocks before any data is removed from the host chain.
+	var (
+		newHead     *types.Block
+		newReceipts types.Receipts
+		newLogs     = make([]*types.Log, 0)
+	)
+	if number > 0 {
+		if newHead, err = bc.GetPolarisBlockByNumber(number); err != nil {
+			return err
+		}
+		if newReceipts, err = bc.GetReceipts(newHead.Hash()); err != nil {
+			return err
+		}
+		newLogs = blockLogs(newHead, newReceipts, false)
+	}
+	dropped := make([]*types.Block, 0, headNum-number)
+	droppedReceipts := make([]types.Receipts, 0, headNum-number)
+	for n := headNum; n > number; n-- {
+		var (
+			block    *types.Block
+			receipts types.Receipts
+		)
+		if block, err = bc.GetPolarisBlockByNumber(n); err != nil {
+			return err
+		}
+		if receipts, err = bc.GetReceipts(block.Hash()); err != nil {
+			return err
+		}
+		dropped = append(dropped, block)
+		droppedReceipts = append(droppedReceipts, receipts)
+	}
+
+	// Roll back the host chain and its historical data.
+	if rollbackHost {
+		if err = bc.rhc.Rollback(number); err != nil {
+			return err
+		}
+	}
+	if bc.hp != nil {
+		if err = bc.hp.TruncateAbove(number, dropped); err != nil {
+			return err
+		}
+		// A failure to rewind the bloom index should not halt the chain.
+		if err = bc.bloomIndexer.Rewind(uint64(number)); err != nil {
+			bc.logger.Error("failed to rewind bloom index", "block", number, "err", err)
+		}
+	}
+
+	// Mark the new head and remove the dropped blocks from the caches.
+	bc.currentBlock.Store(newHead)
+	bc.finalizedBlock.Store(newHead)
+	bc.currentReceipts.Store(newReceipts)
+	bc.currentLogs.Store(newLogs)
+	for i, block := range dropped {
+		bc.blockNumCache.Remove(block.Number().Int64())
+		bc.blockHashCache.Remove(block.Hash())
+		bc.receiptsCache.Remove(block.Hash())
+		for _, tx := range block.Transactions() {
+			bc.txLookupCache.Remove(tx.Hash())
+		}
+
+		// Send the events for the dropped block.
+		bc.chainSideFeed.Send(ChainSideEvent{Block: block})
+		if logs := blockLogs(block, droppedReceipts[i], true); len(logs) > 0 {
+			bc.rmLogsFeed.Send(RemovedLogsEvent{Logs: logs})
+		}
+	}
+	if newHead != nil {
+		bc.chainHeadFeed.Send(ChainHeadEvent{Block: newHead})
+		bc.schedulePendingBlock(newHead)
+	}
+
+	return nil
+}
+
+// blockLogs returns copies of the logs of the given block and receipts, with the derived fields
+// of the logs set, as they are not persisted by the historical plugin.
+func blockLogs(block *types.Block, receipts types.Receipts, removed bool) []*types.Log {
+	var (
+		txs      = block.Transactions()
+		logs     = make([]*types.Log, 0)
+		logIndex uint
+	)
+	for txIndex, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			log := *l
+			log.BlockNumber = block.NumberU64()
+			log.BlockHash = block.Hash()
+			if txIndex < len(txs) {
+				log.TxHash = txs[txIndex].Hash()
+			}
+			log.TxIndex = uint(txIndex)
+			log.Index = logIndex
+			log.Removed = removed
+			logs = append(logs, &log)
+			logIndex++
+		}
+	}
+	return logs
+}
@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"math/big"
+
+	lru "github.com/ethereum/go-ethereum/common/lru"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Chain Rewind", func() {
+	var (
+		hp     *rewindHistoricalPlugin
+		rhc    *rewindHostChain
+		bc     *blockchain
+		blocks []*types.Block
+	)
+
+	BeforeEach(func() {
+		hp = &rewindHistoricalPlugin{
+			bloomHistoricalPlugin: &bloomHistoricalPlugin{bits: make(map[[2]uint64][]byte)},
+			blocks:                make(map[int64]*types.Block),
+			receipts:              make(map[common.Hash]types.Receipts),
+			truncated:             -1,
+		}
+		rhc = &rewindHostChain{number: -1}
+		bc = &blockchain{
+			hp:             hp,
+			rhc:            rhc,
+			receiptsCache:  lru.NewCache[common.Hash, types.Receipts](defaultCacheSizeBytes),
+			blockNumCache:  lru.NewCache[int64, *types.Block](defaultCacheSizeBytes),
+			blockHashCache: lru.NewCache[common.Hash, *types.Block](defaultCacheSizeBytes),
+			txLookupCache:  lru.NewCache[common.Hash, *types.TxLookupEntry](defaultCacheSizeBytes),
+			logger:         log.Root(),
+		}
+		bc.processor = &StateProcessor{}
+		bc.bloomIndexer = newBloomIndexer(&bloomBlockPlugin{}, hp, bc.logger)
+
+		blocks = nil
+		for n := int64(1); n <= 3; n++ {
+			tx := types.NewTx(&types.LegacyTx{Nonce: uint64(n), GasPrice: big.NewInt(1)})
+			block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(n)}).
+				WithBody(types.Transactions{tx}, nil)
+			receipts := types.Receipts{
+				{Logs: []*types.Log{{Address: common.BytesToAddress([]byte{byte(n)})}}},
+			}
+			hp.blocks[n] = block
+			hp.receipts[block.Hash()] = receipts
+
+			// the last two blocks are cached, as if they were just finalized.
+			if n > 1 {
+				bc.blockNumCache.Add(n, block)
+				bc.blockHashCache.Add(block.Hash(), block)
+				bc.receiptsCache.Add(block.Hash(), receipts)
+				bc.txLookupCache.Add(tx.Hash(), &types.TxLookupEntry{Tx: tx, BlockNum: uint64(n)})
+			}
+			blocks = append(blocks, block)
+		}
+		bc.currentBlock.Store(blocks[2])
+		bc.finalizedBlock.Store(blocks[2])
+	})
+
+	It("should rewind the chain and send events for the dropped blocks", func() {
+		sideCh := make(chan ChainSideEvent, 2)
+		rmLogsCh := make(chan RemovedLogsEvent, 2)
+		defer bc.SubscribeChainSideEvent(sideCh).Unsubscribe()
+		defer bc.SubscribeRemovedLogsEvent(rmLogsCh).Unsubscribe()
+
+		Expect(bc.SetHead(1)).To(Succeed())
+		Expect(rhc.number).To(Equal(int64(1)))
+		Expect(hp.truncated).To(Equal(int64(1)))
+		Expect(hp.dropped).To(Equal([]*types.Block{blocks[2], blocks[1]}))
+
+		head, err := bc.CurrentBlock()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(head.Hash()).To(Equal(blocks[0].Hash()))
+		for _, block := range blocks[1:] {
+			Expect(bc.blockNumCache.Contains(block.Number().Int64())).To(BeFalse())
+			Expect(bc.blockHashCache.Contains(block.Hash())).To(BeFalse())
+			Expect(bc.receiptsCache.Contains(block.Hash())).To(BeFalse())
+			Expect(bc.txLookupCache.Contains(block.Transactions()[0].Hash())).To(BeFalse())
+		}
+
+		// the dropped blocks are sent starting from the old head.
+		for _, block := range []*types.Block{blocks[2], blocks[1]} {
+			Expect((<-sideCh).Block.Hash()).To(Equal(block.Hash()))
+			logs := (<-rmLogsCh).Logs
+			Expect(logs).To(HaveLen(1))
+			Expect(logs[0].Removed).To(BeTrue())
+			Expect(logs[0].BlockHash).To(Equal(block.Hash()))
+			Expect(logs[0].TxHash).To(Equal(block.Transactions()[0].Hash()))
+		}
+	})
+
+	It("should discard the last finalized block without rolling back the host", func() {
+		sideCh := make(chan ChainSideEvent, 1)
+		rmLogsCh := make(chan RemovedLogsEvent, 1)
+		defer bc.SubscribeChainSideEvent(sideCh).Unsubscribe()
+		defer bc.SubscribeRemovedLogsEvent(rmLogsCh).Unsubscribe()
+
+		Expect(bc.Discard(context.Background())).To(Succeed())
+		Expect(rhc.number).To(Equal(int64(-1)))
+		Expect(hp.truncated).To(Equal(int64(2)))
+
+		head, err := bc.CurrentBlock()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(head.Hash()).To(Equal(blocks[1].Hash()))
+		Expect((<-sideCh).Block.Hash()).To(Equal(blocks[2].Hash()))
+		logs := (<-rmLogsCh).Logs
+		Expect(logs).To(HaveLen(1))
+		Expect(logs[0].Removed).To(BeTrue())
+		Expect(logs[0].BlockNumber).To(Equal(uint64(3)))
+	})
+
+	It("should discard the first block by rewinding to genesis", func() {
+		Expect(bc.SetHead(1)).To(Succeed())
+		sideCh := make(chan ChainSideEvent, 1)
+		defer bc.SubscribeChainSideEvent(sideCh).Unsubscribe()
+
+		Expect(bc.Discard(context.Background())).To(Succeed())
+		Expect(rhc.number).To(Equal(int64(1)))
+		Expect(hp.truncated).To(Equal(int64(0)))
+		Expect((<-sideCh).Block.Hash()).To(Equal(blocks[0].Hash()))
+
+		_, err := bc.CurrentBlock()
+		Expect(err).To(HaveOccurred())
+		_, err = bc.FinalizedBlock()
+		Expect(err).To(HaveOccurred())
+		Expect(bc.blockNumCache.Contains(int64(1))).To(BeFalse())
+	})
+
+	It("should not rewind above the current head", func() {
+		Expect(bc.SetHead(4)).To(MatchError(ErrInvalidHead))
+		Expect(bc.SetHead(0)).To(MatchError(ErrInvalidHead))
+		Expect(bc.SetHead(3)).To(Succeed())
+		Expect(rhc.number).To(Equal(int64(-1)))
+	})
+
+	It("should return and cache the blocks read from the historical plugin", func() {
+		Expect(bc.blockNumCache.Contains(int64(1))).To(BeFalse())
+		block, err := bc.GetPolarisBlockByNumber(1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(block.Hash()).To(Equal(blocks[0].Hash()))
+		Expect(bc.blockNumCache.Contains(int64(1))).To(BeTrue())
+		Expect(bc.blockHashCache.Contains(blocks[0].Hash())).To(BeTrue())
+	})
+
+	It("should not rewind if the host chain cannot be rolled back", func() {
+		bc.rhc = nil
+		Expect(bc.SetHead(1)).To(MatchError(ErrRewindNotSupported))
+		Expect(hp.truncated).To(Equal(int64(-1)))
+
+		head, err := bc.CurrentBlock()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(head.Hash()).To(Equal(blocks[2].Hash()))
+		Expect(bc.blockNumCache.Contains(int64(3))).To(BeTrue())
+	})
+})
+
+// rewindHostChain records the block number that the host chain was rolled back to.
+type rewindHostChain struct {
+	number int64
+}
+
+func (hc *rewindHostChain) Rollback(number int64) error {
+	hc.number = number
+	return nil
+}
+
+// rewindHistoricalPlugin is an in-memory store of blocks and receipts.
+type rewindHistoricalPlugin struct {
+	*bloomHistoricalPlugin
+	blocks    map[int64]*types.Block
+	receipts  map[common.Hash]types.Receipts
+	truncated int64
+	dropped   []*types.Block
+}
+
+func (hp *rewindHistoricalPlugin) GetBlockByNumber(number int64) (*types.Block, error) {
+	block, ok := hp.blocks[number]
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+	return block, nil
+}
+
+func (hp *rewindHistoricalPlugin) GetReceiptsByHash(hash common.Hash) (types.Receipts, error) {
+	receipts, ok := hp.receipts[hash]
+	if !ok {
+		return nil, ErrReceiptsNotFound
+	}
+	return receipts, nil
+}
+
+func (hp *rewindHistoricalPlugin) TruncateAbove(number int64, dropped []*types.Block) error {
+	hp.truncated = number
+	hp.dropped = dropped
+	return nil
+}
@@ -23,8 +23,10 @@ package core
 import "errors"
 
 var (
-	ErrBlockOutOfGas    = errors.New("block is out of gas")
-	ErrBlockNotFound    = errors.New("block not found")
-	ErrReceiptsNotFound = errors.New("receipts not found")
-	ErrTxNotFound       = errors.New("transaction not found")
+	ErrBlockOutOfGas      = errors.New("block is out of gas")
+	ErrBlockNotFound      = errors.New("block not found")
+	ErrInvalidHead        = errors.New("invalid head block number")
+	ErrReceiptsNotFound   = errors.New("receipts not found")
+	ErrRewindNotSupported = errors.New("host chain does not support rewinding")
+	ErrTxNotFound         = errors.New("transaction not found")
 )
@@ -21,6 +21,10 @@
 package core
 
 import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/event"
+
 	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core/precompile"
 	"pkg.berachain.dev/polaris/eth/core/state"
@@ -47,6 +51,23 @@ type PolarisHostChain interface {
 	GetTxPoolPlugin() TxPoolPlugin
 }
 
+// RewindableHostChain defines the OPTIONAL hook that a Polaris host chain can implement in order
+// to roll back its own state when the chain is rewound via `ChainWriter.SetHead`. The chain can
+// only be rewound via `ChainWriter.SetHead` if the host chain implements this hook.
+type RewindableHostChain interface {
+	// Rollback rolls back the state of the host chain to the end of the given block number.
+	Rollback(int64) error
+}
+
+// ParallelHostChain defines the OPTIONAL hook that a Polaris host chain can implement in order to
+// opt into the speculative, parallel, execution of transactions. The `StatePlugin` of the host
+// chain must support `GetStateByNumber` for the parent of the block that is being processed.
+type ParallelHostChain interface {
+	// ParallelExecutionWorkers returns the number of transactions that are executed in parallel.
+	// Parallel execution is disabled if it is not positive.
+	ParallelExecutionWorkers() int
+}
+
 // =============================================================================
 // Mandatory Plugins
 // =============================================================================
@@ -63,12 +84,18 @@ type (
 		// GetNewBlockMetadata returns a new block metadata (coinbase, timestamp) for the given
 		// block number.
 		GetNewBlockMetadata(int64) (common.Address, uint64)
+		// GetStateRoot returns the commitment of the host chain to its state at the end of the
+		// parent of the given block number (e.g. the app hash). It is used as the root of the
+		// block header, so the state proofs at a block are verified against the root of the
+		// header of the following block.
+		GetStateRoot(int64) common.Hash
 		// GetHeaderByNumber returns the block header at the given block number.
 		GetHeaderByNumber(int64) (*types.Header, error)
 		// SetHeaderByNumber sets the block header at the given block number.
 		SetHeaderByNumber(int64, *types.Header) error
-		// BaseFee returns the base fee of the current block.
-		BaseFee() uint64
+		// BaseFee returns the base fee of the block built on top of the given parent header, as
+		// defined by EIP-1559. The parent is nil when building the first block.
+		BaseFee(*types.Header) *big.Int
 	}
 
 	// ConfigurationPlugin defines the methods that the chain running Polaris EVM should
@@ -79,6 +106,9 @@ type (
 		libtypes.Preparable
 		// ChainConfig returns the current chain configuration of the Polaris EVM.
 		ChainConfig() *params.ChainConfig
+		// ChainConfigAt returns the chain configuration of the Polaris EVM that was active at the
+		// given block height.
+		ChainConfigAt(int64) (*params.ChainConfig, error)
 		// ExtraEips returns the extra EIPs that the Polaris EVM supports.
 		ExtraEips() []int
 		// `The fee collector is utilized on chains that have a fee collector account. This was added
@@ -86,6 +116,9 @@ type (
 		// to be the operator address of the proposer, but we want the coinbase in the BlockContext
 		// to be the FeeCollectorAccount.
 		FeeCollector() *common.Address
+		// BaseFeeCollector returns the address that receives the base fee portion of transaction
+		// fees. If nil, the base fee is burned, as defined by EIP-1559.
+		BaseFeeCollector() *common.Address
 	}
 
 	// GasPlugin is an interface that allows the Polaris EVM to consume gas on the host chain.
@@ -125,12 +158,35 @@ type (
 	TxPoolPlugin interface {
 		// SendTx submits the tx to the transaction pool.
 		SendTx(tx *types.Transaction) error
+		// SendPrivTx submits the tx to the local transaction pool, without gossiping it to peers.
+		// The tx is hidden from the other methods of the transaction pool, except `Executable`,
+		// and is dropped if it is not included in a block by the given block number, unless the
+		// block number is 0.
+		SendPrivTx(tx *types.Transaction, maxBlockNumber uint64) error
+		// CancelPrivTx drops the private tx with the given hash from the transaction pool. It
+		// returns whether the tx was a pending private tx.
+		CancelPrivTx(common.Hash) bool
 		// GetAllTransactions returns all transactions in the transaction pool.
 		GetAllTransactions() (types.Transactions, error)
 		// GetTransaction returns the transaction from the pool with the given hash.
 		GetTransaction(common.Hash) *types.Transaction
 		// GetNonce returns the nonce of the given address in the transaction pool.
 		GetNonce(common.Address) (uint64, error)
+		// Content returns the pending and queued transactions of the transaction pool, grouped
+		// by sender. A transaction is pending if it is executable against the current state
+		// nonce of its sender and queued if it is waiting on a nonce gap to be filled.
+		Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+		// Executable returns the pending transactions of the transaction pool, along with the
+		// private transactions, grouped by sender. It is only used to build the blocks proposed
+		// by this node.
+		Executable() map[common.Address]types.Transactions
+		// ContentFrom returns the pending and queued transactions of the given sender.
+		ContentFrom(common.Address) (types.Transactions, types.Transactions)
+		// Stats returns the number of pending and queued transactions in the transaction pool.
+		Stats() (int, int)
+		// SubscribeNewTxsEvent registers a subscription for `NewTxsEvent`s, which are emitted
+		// whenever new transactions are added to the transaction pool.
+		SubscribeNewTxsEvent(chan<- NewTxsEvent) event.Subscription
 	}
 )
 
@@ -162,6 +218,19 @@ type (
 		StoreReceipts(common.Hash, types.Receipts) error
 		// StoreTransactions stores the transactions for the given block hash.
 		StoreTransactions(int64, common.Hash, types.Transactions) error
+		// TruncateAbove deletes the given blocks, which must be all of the blocks stored above
+		// the given block number, along with their receipts and transactions.
+		TruncateAbove(number int64, dropped []*types.Block) error
+		// GetBloomBits returns the compressed bloom bits vector of the given bloom bit for the
+		// given section.
+		GetBloomBits(bit uint, section uint64) ([]byte, error)
+		// StoreBloomBits stores the compressed bloom bits vector of the given bloom bit for the
+		// given section.
+		StoreBloomBits(bit uint, section uint64, bits []byte) error
+		// GetBloomSections returns the number of fully indexed bloom bits sections.
+		GetBloomSections() (uint64, error)
+		// StoreBloomSections stores the number of fully indexed bloom bits sections.
+		StoreBloomSections(uint64) error
 	}
 
 	// PrecompilePlugin defines the methods that the chain running Polaris EVM should implement
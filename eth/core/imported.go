@@ -56,9 +56,32 @@ var (
 	GetHashFn = core.GetHashFn
 	// TransactionToMessage converts a transaction to a message.
 	TransactionToMessage = core.TransactionToMessage
+	// IntrinsicGas computes the intrinsic gas of a transaction with the given data.
+	IntrinsicGas = core.IntrinsicGas
 )
 
 var (
 	// ErrInsufficientBalanceForGas is the error return when gas required to execute a transaction overflows.
 	ErrGasUintOverflow = core.ErrGasUintOverflow
+	// ErrNonceTooLow is returned if the nonce of a transaction is lower than the one present in
+	// the local chain.
+	ErrNonceTooLow = core.ErrNonceTooLow
+	// ErrNonceTooHigh is returned if the nonce of a transaction is higher than the next one
+	// expected based on the local chain.
+	ErrNonceTooHigh = core.ErrNonceTooHigh
+	// ErrInsufficientFunds is returned if the total cost of executing a transaction is higher
+	// than the balance of the user's account.
+	ErrInsufficientFunds = core.ErrInsufficientFunds
+	// ErrGasLimitReached is returned by the gas pool if the amount of gas required by a
+	// transaction is higher than what's left in the block.
+	ErrGasLimitReached = core.ErrGasLimitReached
+	// ErrFeeCapTooLow is returned if the transaction fee cap is less than the base fee of the
+	// block.
+	ErrFeeCapTooLow = core.ErrFeeCapTooLow
+	// ErrIntrinsicGas is returned if the transaction is specified to use less gas than required
+	// to start the invocation.
+	ErrIntrinsicGas = core.ErrIntrinsicGas
+	// ErrInsufficientFundsForTransfer is returned if the transaction sender doesn't have enough
+	// funds for transfer.
+	ErrInsufficientFundsForTransfer = core.ErrInsufficientFundsForTransfer
 )
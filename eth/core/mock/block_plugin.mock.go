@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
 	"pkg.berachain.dev/polaris/eth/core"
 	"sync"
 )
@@ -21,7 +22,7 @@ var _ core.BlockPlugin = &BlockPluginMock{}
 //
 //		// make and configure a mocked core.BlockPlugin
 //		mockedBlockPlugin := &BlockPluginMock{
-//			BaseFeeFunc: func() uint64 {
+//			BaseFeeFunc: func(header *types.Header) *big.Int {
 //				panic("mock out the BaseFee method")
 //			},
 //			GetHeaderByNumberFunc: func(n int64) (*types.Header, error) {
@@ -30,6 +31,9 @@ var _ core.BlockPlugin = &BlockPluginMock{}
 //			GetNewBlockMetadataFunc: func(n int64) (common.Address, uint64) {
 //				panic("mock out the GetNewBlockMetadata method")
 //			},
+//			GetStateRootFunc: func(n int64) common.Hash {
+//				panic("mock out the GetStateRoot method")
+//			},
 //			PrepareFunc: func(contextMoqParam context.Context)  {
 //				panic("mock out the Prepare method")
 //			},
@@ -44,7 +48,7 @@ var _ core.BlockPlugin = &BlockPluginMock{}
 //	}
 type BlockPluginMock struct {
 	// BaseFeeFunc mocks the BaseFee method.
-	BaseFeeFunc func() uint64
+	BaseFeeFunc func(header *types.Header) *big.Int
 
 	// GetHeaderByNumberFunc mocks the GetHeaderByNumber method.
 	GetHeaderByNumberFunc func(n int64) (*types.Header, error)
@@ -52,6 +56,9 @@ type BlockPluginMock struct {
 	// GetNewBlockMetadataFunc mocks the GetNewBlockMetadata method.
 	GetNewBlockMetadataFunc func(n int64) (common.Address, uint64)
 
+	// GetStateRootFunc mocks the GetStateRoot method.
+	GetStateRootFunc func(n int64) common.Hash
+
 	// PrepareFunc mocks the Prepare method.
 	PrepareFunc func(contextMoqParam context.Context)
 
@@ -62,6 +69,8 @@ type BlockPluginMock struct {
 	calls struct {
 		// BaseFee holds details about calls to the BaseFee method.
 		BaseFee []struct {
+			// Header is the header argument value.
+			Header *types.Header
 		}
 		// GetHeaderByNumber holds details about calls to the GetHeaderByNumber method.
 		GetHeaderByNumber []struct {
@@ -73,6 +82,11 @@ type BlockPluginMock struct {
 			// N is the n argument value.
 			N int64
 		}
+		// GetStateRoot holds details about calls to the GetStateRoot method.
+		GetStateRoot []struct {
+			// N is the n argument value.
+			N int64
+		}
 		// Prepare holds details about calls to the Prepare method.
 		Prepare []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -89,21 +103,25 @@ type BlockPluginMock struct {
 	lockBaseFee             sync.RWMutex
 	lockGetHeaderByNumber   sync.RWMutex
 	lockGetNewBlockMetadata sync.RWMutex
+	lockGetStateRoot        sync.RWMutex
 	lockPrepare             sync.RWMutex
 	lockSetHeaderByNumber   sync.RWMutex
 }
 
 // BaseFee calls BaseFeeFunc.
-func (mock *BlockPluginMock) BaseFee() uint64 {
+func (mock *BlockPluginMock) BaseFee(header *types.Header) *big.Int {
 	if mock.BaseFeeFunc == nil {
 		panic("BlockPluginMock.BaseFeeFunc: method is nil but BlockPlugin.BaseFee was just called")
 	}
 	callInfo := struct {
-	}{}
+		Header *types.Header
+	}{
+		Header: header,
+	}
 	mock.lockBaseFee.Lock()
 	mock.calls.BaseFee = append(mock.calls.BaseFee, callInfo)
 	mock.lockBaseFee.Unlock()
-	return mock.BaseFeeFunc()
+	return mock.BaseFeeFunc(header)
 }
 
 // BaseFeeCalls gets all the calls that were made to BaseFee.
@@ -111,8 +129,10 @@ func (mock *BlockPluginMock) BaseFee() uint64 {
 //
 //	len(mockedBlockPlugin.BaseFeeCalls())
 func (mock *BlockPluginMock) BaseFeeCalls() []struct {
+	Header *types.Header
 } {
 	var calls []struct {
+		Header *types.Header
 	}
 	mock.lockBaseFee.RLock()
 	calls = mock.calls.BaseFee
@@ -184,6 +204,38 @@ func (mock *BlockPluginMock) GetNewBlockMetadataCalls() []struct {
 	return calls
 }
 
+// GetStateRoot calls GetStateRootFunc.
+func (mock *BlockPluginMock) GetStateRoot(n int64) common.Hash {
+	if mock.GetStateRootFunc == nil {
+		panic("BlockPluginMock.GetStateRootFunc: method is nil but BlockPlugin.GetStateRoot was just called")
+	}
+	callInfo := struct {
+		N int64
+	}{
+		N: n,
+	}
+	mock.lockGetStateRoot.Lock()
+	mock.calls.GetStateRoot = append(mock.calls.GetStateRoot, callInfo)
+	mock.lockGetStateRoot.Unlock()
+	return mock.GetStateRootFunc(n)
+}
+
+// GetStateRootCalls gets all the calls that were made to GetStateRoot.
+// Check the length with:
+//
+//	len(mockedBlockPlugin.GetStateRootCalls())
+func (mock *BlockPluginMock) GetStateRootCalls() []struct {
+	N int64
+} {
+	var calls []struct {
+		N int64
+	}
+	mock.lockGetStateRoot.RLock()
+	calls = mock.calls.GetStateRoot
+	mock.lockGetStateRoot.RUnlock()
+	return calls
+}
+
 // Prepare calls PrepareFunc.
 func (mock *BlockPluginMock) Prepare(contextMoqParam context.Context) {
 	if mock.PrepareFunc == nil {
@@ -34,9 +34,15 @@ import (
 func NewConfigurationPluginMock() *ConfigurationPluginMock {
 	// make and configure a mocked core.ConfigurationPlugin
 	mockedConfigurationPlugin := &ConfigurationPluginMock{
+		BaseFeeCollectorFunc: func() *common.Address {
+			return nil
+		},
 		ChainConfigFunc: func() *params.ChainConfig {
 			return params.DefaultChainConfig
 		},
+		ChainConfigAtFunc: func(n int64) (*params.ChainConfig, error) {
+			return params.DefaultChainConfig, nil
+		},
 		ExtraEipsFunc: func() []int {
 			return []int{}
 		},
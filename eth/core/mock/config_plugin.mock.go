@@ -21,9 +21,15 @@ var _ core.ConfigurationPlugin = &ConfigurationPluginMock{}
 //
 //		// make and configure a mocked core.ConfigurationPlugin
 //		mockedConfigurationPlugin := &ConfigurationPluginMock{
+//			BaseFeeCollectorFunc: func() *common.Address {
+//				panic("mock out the BaseFeeCollector method")
+//			},
 //			ChainConfigFunc: func() *params.ChainConfig {
 //				panic("mock out the ChainConfig method")
 //			},
+//			ChainConfigAtFunc: func(n int64) (*params.ChainConfig, error) {
+//				panic("mock out the ChainConfigAt method")
+//			},
 //			ExtraEipsFunc: func() []int {
 //				panic("mock out the ExtraEips method")
 //			},
@@ -40,9 +46,15 @@ var _ core.ConfigurationPlugin = &ConfigurationPluginMock{}
 //
 //	}
 type ConfigurationPluginMock struct {
+	// BaseFeeCollectorFunc mocks the BaseFeeCollector method.
+	BaseFeeCollectorFunc func() *common.Address
+
 	// ChainConfigFunc mocks the ChainConfig method.
 	ChainConfigFunc func() *params.ChainConfig
 
+	// ChainConfigAtFunc mocks the ChainConfigAt method.
+	ChainConfigAtFunc func(n int64) (*params.ChainConfig, error)
+
 	// ExtraEipsFunc mocks the ExtraEips method.
 	ExtraEipsFunc func() []int
 
@@ -54,9 +66,17 @@ type ConfigurationPluginMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// BaseFeeCollector holds details about calls to the BaseFeeCollector method.
+		BaseFeeCollector []struct {
+		}
 		// ChainConfig holds details about calls to the ChainConfig method.
 		ChainConfig []struct {
 		}
+		// ChainConfigAt holds details about calls to the ChainConfigAt method.
+		ChainConfigAt []struct {
+			// N is the n argument value.
+			N int64
+		}
 		// ExtraEips holds details about calls to the ExtraEips method.
 		ExtraEips []struct {
 		}
@@ -69,10 +89,39 @@ type ConfigurationPluginMock struct {
 			ContextMoqParam context.Context
 		}
 	}
-	lockChainConfig  sync.RWMutex
-	lockExtraEips    sync.RWMutex
-	lockFeeCollector sync.RWMutex
-	lockPrepare      sync.RWMutex
+	lockBaseFeeCollector sync.RWMutex
+	lockChainConfig      sync.RWMutex
+	lockChainConfigAt    sync.RWMutex
+	lockExtraEips        sync.RWMutex
+	lockFeeCollector     sync.RWMutex
+	lockPrepare          sync.RWMutex
+}
+
+// BaseFeeCollector calls BaseFeeCollectorFunc.
+func (mock *ConfigurationPluginMock) BaseFeeCollector() *common.Address {
+	if mock.BaseFeeCollectorFunc == nil {
+		panic("ConfigurationPluginMock.BaseFeeCollectorFunc: method is nil but ConfigurationPlugin.BaseFeeCollector was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBaseFeeCollector.Lock()
+	mock.calls.BaseFeeCollector = append(mock.calls.BaseFeeCollector, callInfo)
+	mock.lockBaseFeeCollector.Unlock()
+	return mock.BaseFeeCollectorFunc()
+}
+
+// BaseFeeCollectorCalls gets all the calls that were made to BaseFeeCollector.
+// Check the length with:
+//
+//	len(mockedConfigurationPlugin.BaseFeeCollectorCalls())
+func (mock *ConfigurationPluginMock) BaseFeeCollectorCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBaseFeeCollector.RLock()
+	calls = mock.calls.BaseFeeCollector
+	mock.lockBaseFeeCollector.RUnlock()
+	return calls
 }
 
 // ChainConfig calls ChainConfigFunc.
@@ -102,6 +151,38 @@ func (mock *ConfigurationPluginMock) ChainConfigCalls() []struct {
 	return calls
 }
 
+// ChainConfigAt calls ChainConfigAtFunc.
+func (mock *ConfigurationPluginMock) ChainConfigAt(n int64) (*params.ChainConfig, error) {
+	if mock.ChainConfigAtFunc == nil {
+		panic("ConfigurationPluginMock.ChainConfigAtFunc: method is nil but ConfigurationPlugin.ChainConfigAt was just called")
+	}
+	callInfo := struct {
+		N int64
+	}{
+		N: n,
+	}
+	mock.lockChainConfigAt.Lock()
+	mock.calls.ChainConfigAt = append(mock.calls.ChainConfigAt, callInfo)
+	mock.lockChainConfigAt.Unlock()
+	return mock.ChainConfigAtFunc(n)
+}
+
+// ChainConfigAtCalls gets all the calls that were made to ChainConfigAt.
+// Check the length with:
+//
+//	len(mockedConfigurationPlugin.ChainConfigAtCalls())
+func (mock *ConfigurationPluginMock) ChainConfigAtCalls() []struct {
+	N int64
+} {
+	var calls []struct {
+		N int64
+	}
+	mock.lockChainConfigAt.RLock()
+	calls = mock.calls.ChainConfigAt
+	mock.lockChainConfigAt.RUnlock()
+	return calls
+}
+
 // ExtraEips calls ExtraEipsFunc.
 func (mock *ConfigurationPluginMock) ExtraEips() []int {
 	if mock.ExtraEipsFunc == nil {
@@ -28,6 +28,12 @@ var _ core.HistoricalPlugin = &HistoricalPluginMock{}
 //			GetBlockByNumberFunc: func(n int64) (*ethereumcoretypes.Block, error) {
 //				panic("mock out the GetBlockByNumber method")
 //			},
+//			GetBloomBitsFunc: func(bit uint, section uint64) ([]byte, error) {
+//				panic("mock out the GetBloomBits method")
+//			},
+//			GetBloomSectionsFunc: func() (uint64, error) {
+//				panic("mock out the GetBloomSections method")
+//			},
 //			GetReceiptsByHashFunc: func(hash common.Hash) (ethereumcoretypes.Receipts, error) {
 //				panic("mock out the GetReceiptsByHash method")
 //			},
@@ -40,12 +46,21 @@ var _ core.HistoricalPlugin = &HistoricalPluginMock{}
 //			StoreBlockFunc: func(block *ethereumcoretypes.Block) error {
 //				panic("mock out the StoreBlock method")
 //			},
+//			StoreBloomBitsFunc: func(bit uint, section uint64, bits []byte) error {
+//				panic("mock out the StoreBloomBits method")
+//			},
+//			StoreBloomSectionsFunc: func(n uint64) error {
+//				panic("mock out the StoreBloomSections method")
+//			},
 //			StoreReceiptsFunc: func(hash common.Hash, receipts ethereumcoretypes.Receipts) error {
 //				panic("mock out the StoreReceipts method")
 //			},
 //			StoreTransactionsFunc: func(n int64, hash common.Hash, transactions ethereumcoretypes.Transactions) error {
 //				panic("mock out the StoreTransactions method")
 //			},
+//			TruncateAboveFunc: func(number int64, dropped []*ethereumcoretypes.Block) error {
+//				panic("mock out the TruncateAbove method")
+//			},
 //		}
 //
 //		// use mockedHistoricalPlugin in code that requires core.HistoricalPlugin
@@ -59,6 +74,12 @@ type HistoricalPluginMock struct {
 	// GetBlockByNumberFunc mocks the GetBlockByNumber method.
 	GetBlockByNumberFunc func(n int64) (*ethereumcoretypes.Block, error)
 
+	// GetBloomBitsFunc mocks the GetBloomBits method.
+	GetBloomBitsFunc func(bit uint, section uint64) ([]byte, error)
+
+	// GetBloomSectionsFunc mocks the GetBloomSections method.
+	GetBloomSectionsFunc func() (uint64, error)
+
 	// GetReceiptsByHashFunc mocks the GetReceiptsByHash method.
 	GetReceiptsByHashFunc func(hash common.Hash) (ethereumcoretypes.Receipts, error)
 
@@ -71,12 +92,21 @@ type HistoricalPluginMock struct {
 	// StoreBlockFunc mocks the StoreBlock method.
 	StoreBlockFunc func(block *ethereumcoretypes.Block) error
 
+	// StoreBloomBitsFunc mocks the StoreBloomBits method.
+	StoreBloomBitsFunc func(bit uint, section uint64, bits []byte) error
+
+	// StoreBloomSectionsFunc mocks the StoreBloomSections method.
+	StoreBloomSectionsFunc func(n uint64) error
+
 	// StoreReceiptsFunc mocks the StoreReceipts method.
 	StoreReceiptsFunc func(hash common.Hash, receipts ethereumcoretypes.Receipts) error
 
 	// StoreTransactionsFunc mocks the StoreTransactions method.
 	StoreTransactionsFunc func(n int64, hash common.Hash, transactions ethereumcoretypes.Transactions) error
 
+	// TruncateAboveFunc mocks the TruncateAbove method.
+	TruncateAboveFunc func(number int64, dropped []*ethereumcoretypes.Block) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetBlockByHash holds details about calls to the GetBlockByHash method.
@@ -89,6 +119,16 @@ type HistoricalPluginMock struct {
 			// N is the n argument value.
 			N int64
 		}
+		// GetBloomBits holds details about calls to the GetBloomBits method.
+		GetBloomBits []struct {
+			// Bit is the bit argument value.
+			Bit uint
+			// Section is the section argument value.
+			Section uint64
+		}
+		// GetBloomSections holds details about calls to the GetBloomSections method.
+		GetBloomSections []struct {
+		}
 		// GetReceiptsByHash holds details about calls to the GetReceiptsByHash method.
 		GetReceiptsByHash []struct {
 			// Hash is the hash argument value.
@@ -109,6 +149,20 @@ type HistoricalPluginMock struct {
 			// Block is the block argument value.
 			Block *ethereumcoretypes.Block
 		}
+		// StoreBloomBits holds details about calls to the StoreBloomBits method.
+		StoreBloomBits []struct {
+			// Bit is the bit argument value.
+			Bit uint
+			// Section is the section argument value.
+			Section uint64
+			// Bits is the bits argument value.
+			Bits []byte
+		}
+		// StoreBloomSections holds details about calls to the StoreBloomSections method.
+		StoreBloomSections []struct {
+			// N is the n argument value.
+			N uint64
+		}
 		// StoreReceipts holds details about calls to the StoreReceipts method.
 		StoreReceipts []struct {
 			// Hash is the hash argument value.
@@ -125,15 +179,27 @@ type HistoricalPluginMock struct {
 			// Transactions is the transactions argument value.
 			Transactions ethereumcoretypes.Transactions
 		}
+		// TruncateAbove holds details about calls to the TruncateAbove method.
+		TruncateAbove []struct {
+			// Number is the number argument value.
+			Number int64
+			// Dropped is the dropped argument value.
+			Dropped []*ethereumcoretypes.Block
+		}
 	}
 	lockGetBlockByHash       sync.RWMutex
 	lockGetBlockByNumber     sync.RWMutex
+	lockGetBloomBits         sync.RWMutex
+	lockGetBloomSections     sync.RWMutex
 	lockGetReceiptsByHash    sync.RWMutex
 	lockGetTransactionByHash sync.RWMutex
 	lockPrepare              sync.RWMutex
 	lockStoreBlock           sync.RWMutex
+	lockStoreBloomBits       sync.RWMutex
+	lockStoreBloomSections   sync.RWMutex
 	lockStoreReceipts        sync.RWMutex
 	lockStoreTransactions    sync.RWMutex
+	lockTruncateAbove        sync.RWMutex
 }
 
 // GetBlockByHash calls GetBlockByHashFunc.
@@ -200,6 +266,69 @@ func (mock *HistoricalPluginMock) GetBlockByNumberCalls() []struct {
 	return calls
 }
 
+// GetBloomBits calls GetBloomBitsFunc.
+func (mock *HistoricalPluginMock) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	if mock.GetBloomBitsFunc == nil {
+		panic("HistoricalPluginMock.GetBloomBitsFunc: method is nil but HistoricalPlugin.GetBloomBits was just called")
+	}
+	callInfo := struct {
+		Bit     uint
+		Section uint64
+	}{
+		Bit:     bit,
+		Section: section,
+	}
+	mock.lockGetBloomBits.Lock()
+	mock.calls.GetBloomBits = append(mock.calls.GetBloomBits, callInfo)
+	mock.lockGetBloomBits.Unlock()
+	return mock.GetBloomBitsFunc(bit, section)
+}
+
+// GetBloomBitsCalls gets all the calls that were made to GetBloomBits.
+// Check the length with:
+//
+//	len(mockedHistoricalPlugin.GetBloomBitsCalls())
+func (mock *HistoricalPluginMock) GetBloomBitsCalls() []struct {
+	Bit     uint
+	Section uint64
+} {
+	var calls []struct {
+		Bit     uint
+		Section uint64
+	}
+	mock.lockGetBloomBits.RLock()
+	calls = mock.calls.GetBloomBits
+	mock.lockGetBloomBits.RUnlock()
+	return calls
+}
+
+// GetBloomSections calls GetBloomSectionsFunc.
+func (mock *HistoricalPluginMock) GetBloomSections() (uint64, error) {
+	if mock.GetBloomSectionsFunc == nil {
+		panic("HistoricalPluginMock.GetBloomSectionsFunc: method is nil but HistoricalPlugin.GetBloomSections was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetBloomSections.Lock()
+	mock.calls.GetBloomSections = append(mock.calls.GetBloomSections, callInfo)
+	mock.lockGetBloomSections.Unlock()
+	return mock.GetBloomSectionsFunc()
+}
+
+// GetBloomSectionsCalls gets all the calls that were made to GetBloomSections.
+// Check the length with:
+//
+//	len(mockedHistoricalPlugin.GetBloomSectionsCalls())
+func (mock *HistoricalPluginMock) GetBloomSectionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetBloomSections.RLock()
+	calls = mock.calls.GetBloomSections
+	mock.lockGetBloomSections.RUnlock()
+	return calls
+}
+
 // GetReceiptsByHash calls GetReceiptsByHashFunc.
 func (mock *HistoricalPluginMock) GetReceiptsByHash(hash common.Hash) (ethereumcoretypes.Receipts, error) {
 	if mock.GetReceiptsByHashFunc == nil {
@@ -328,6 +457,78 @@ func (mock *HistoricalPluginMock) StoreBlockCalls() []struct {
 	return calls
 }
 
+// StoreBloomBits calls StoreBloomBitsFunc.
+func (mock *HistoricalPluginMock) StoreBloomBits(bit uint, section uint64, bits []byte) error {
+	if mock.StoreBloomBitsFunc == nil {
+		panic("HistoricalPluginMock.StoreBloomBitsFunc: method is nil but HistoricalPlugin.StoreBloomBits was just called")
+	}
+	callInfo := struct {
+		Bit     uint
+		Section uint64
+		Bits    []byte
+	}{
+		Bit:     bit,
+		Section: section,
+		Bits:    bits,
+	}
+	mock.lockStoreBloomBits.Lock()
+	mock.calls.StoreBloomBits = append(mock.calls.StoreBloomBits, callInfo)
+	mock.lockStoreBloomBits.Unlock()
+	return mock.StoreBloomBitsFunc(bit, section, bits)
+}
+
+// StoreBloomBitsCalls gets all the calls that were made to StoreBloomBits.
+// Check the length with:
+//
+//	len(mockedHistoricalPlugin.StoreBloomBitsCalls())
+func (mock *HistoricalPluginMock) StoreBloomBitsCalls() []struct {
+	Bit     uint
+	Section uint64
+	Bits    []byte
+} {
+	var calls []struct {
+		Bit     uint
+		Section uint64
+		Bits    []byte
+	}
+	mock.lockStoreBloomBits.RLock()
+	calls = mock.calls.StoreBloomBits
+	mock.lockStoreBloomBits.RUnlock()
+	return calls
+}
+
+// StoreBloomSections calls StoreBloomSectionsFunc.
+func (mock *HistoricalPluginMock) StoreBloomSections(n uint64) error {
+	if mock.StoreBloomSectionsFunc == nil {
+		panic("HistoricalPluginMock.StoreBloomSectionsFunc: method is nil but HistoricalPlugin.StoreBloomSections was just called")
+	}
+	callInfo := struct {
+		N uint64
+	}{
+		N: n,
+	}
+	mock.lockStoreBloomSections.Lock()
+	mock.calls.StoreBloomSections = append(mock.calls.StoreBloomSections, callInfo)
+	mock.lockStoreBloomSections.Unlock()
+	return mock.StoreBloomSectionsFunc(n)
+}
+
+// StoreBloomSectionsCalls gets all the calls that were made to StoreBloomSections.
+// Check the length with:
+//
+//	len(mockedHistoricalPlugin.StoreBloomSectionsCalls())
+func (mock *HistoricalPluginMock) StoreBloomSectionsCalls() []struct {
+	N uint64
+} {
+	var calls []struct {
+		N uint64
+	}
+	mock.lockStoreBloomSections.RLock()
+	calls = mock.calls.StoreBloomSections
+	mock.lockStoreBloomSections.RUnlock()
+	return calls
+}
+
 // StoreReceipts calls StoreReceiptsFunc.
 func (mock *HistoricalPluginMock) StoreReceipts(hash common.Hash, receipts ethereumcoretypes.Receipts) error {
 	if mock.StoreReceiptsFunc == nil {
@@ -403,3 +604,39 @@ func (mock *HistoricalPluginMock) StoreTransactionsCalls() []struct {
 	mock.lockStoreTransactions.RUnlock()
 	return calls
 }
+
+// TruncateAbove calls TruncateAboveFunc.
+func (mock *HistoricalPluginMock) TruncateAbove(number int64, dropped []*ethereumcoretypes.Block) error {
+	if mock.TruncateAboveFunc == nil {
+		panic("HistoricalPluginMock.TruncateAboveFunc: method is nil but HistoricalPlugin.TruncateAbove was just called")
+	}
+	callInfo := struct {
+		Number  int64
+		Dropped []*ethereumcoretypes.Block
+	}{
+		Number:  number,
+		Dropped: dropped,
+	}
+	mock.lockTruncateAbove.Lock()
+	mock.calls.TruncateAbove = append(mock.calls.TruncateAbove, callInfo)
+	mock.lockTruncateAbove.Unlock()
+	return mock.TruncateAboveFunc(number, dropped)
+}
+
+// TruncateAboveCalls gets all the calls that were made to TruncateAbove.
+// Check the length with:
+//
+//	len(mockedHistoricalPlugin.TruncateAboveCalls())
+func (mock *HistoricalPluginMock) TruncateAboveCalls() []struct {
+	Number  int64
+	Dropped []*ethereumcoretypes.Block
+} {
+	var calls []struct {
+		Number  int64
+		Dropped []*ethereumcoretypes.Block
+	}
+	mock.lockTruncateAbove.RLock()
+	calls = mock.calls.TruncateAbove
+	mock.lockTruncateAbove.RUnlock()
+	return calls
+}
@@ -0,0 +1,404 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/precompile"
+	"pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/log"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+// errSpeculationAborted is returned by the precompiles of a speculative execution that can not be
+// run speculatively.
+var errSpeculationAborted = errors.New("speculative execution aborted")
+
+// parallelExecutor speculatively executes the transactions of a block in parallel, each on its
+// own `state.Overlay` on top of the state at the start of the block. When the transaction is
+// processed, the calls that the speculative execution made to its overlay are replayed on the live
+// state plugin. Every read of the replay must return the same result as during the speculation,
+// which means that the speculative execution did not conflict with the transactions before it and
+// that the resulting state is the same as if the transaction was executed sequentially. Otherwise,
+// the replay is reverted (through the snapshots of the state plugin, i.e. the snapmulti store of
+// the Cosmos host chain) and the transaction is re-executed sequentially.
+//
+// Before a replay, the state read by the speculative execution is checked against the state
+// written by the transactions before it in the block, which is known exactly for replayed
+// transactions and approximated by the access list journal for the sequentially executed ones.
+// A speculative execution that read such state is known to conflict, so it is re-executed without
+// replaying it first. As host chain precompiles can write state outside of the access list, the
+// replay remains the source of truth.
+type parallelExecutor struct {
+	// sp is the live state plugin that the speculative executions are replayed on.
+	sp StatePlugin
+	// workers is the number of transactions that are executed in parallel.
+	workers int
+
+	// results holds the speculative execution of each transaction by transaction hash.
+	results map[common.Hash]*speculativeResult
+	// written holds the state written by the transactions processed so far in the block.
+	written *state.AccessSet
+	// replayed and sequential count the transactions of the block that were processed by replaying
+	// their speculative execution, and by executing them sequentially.
+	replayed, sequential int
+	// stopped is set when the block is finalized or discarded, to stop any pending speculation.
+	stopped atomic.Bool
+	// wg waits for the workers to exit.
+	wg sync.WaitGroup
+}
+
+// speculativeResult is the result of the speculative execution of a transaction.
+type speculativeResult struct {
+	tx *types.Transaction
+	// done is closed when the speculative execution has finished.
+	done chan struct{}
+
+	result *ExecutionResult
+	err    error
+	// aborted is set when the speculative execution could not be completed.
+	aborted bool
+	// overlay holds the calls that the speculative execution made to the state plugin.
+	overlay *state.Overlay
+	// reads and writes are the state read and written by the speculative execution.
+	reads, writes *state.AccessSet
+	logs          []*types.Log
+}
+
+// newParallelExecutor returns a parallel executor that replays on the given live state plugin.
+func newParallelExecutor(sp StatePlugin, workers int) *parallelExecutor {
+	return &parallelExecutor{
+		sp:      sp,
+		workers: workers,
+		written: state.NewAccessSet(),
+	}
+}
+
+// speculate starts the speculative execution of the given transactions on the state at the start
+// of the block that is being processed.
+func (pe *parallelExecutor) speculate(
+	processor *StateProcessor, txs types.Transactions,
+) error {
+	if processor.vmConfig.Tracer != nil {
+		// traced executions must be observed in order, so they are never speculated.
+		return nil
+	}
+	view, err := pe.sp.GetStateByNumber(processor.header.Number.Int64() - 1)
+	if err != nil {
+		return err
+	}
+
+	w := &speculativeWorker{
+		header:      processor.header,
+		signer:      processor.signer,
+		blockCtx:    processor.evm.Context,
+		chainConfig: processor.evm.ChainConfig(),
+		vmConfig:    *processor.vmConfig,
+		view:        &lockedPlugin{Plugin: view},
+		precompiles: newSpeculativePrecompiles(processor.evm, processor.pp),
+	}
+
+	pe.stopped.Store(false)
+	pe.results = make(map[common.Hash]*speculativeResult, len(txs))
+	jobs := make(chan *speculativeResult, len(txs))
+	for _, tx := range txs {
+		if _, ok := pe.results[tx.Hash()]; ok {
+			continue
+		}
+		res := &speculativeResult{tx: tx, done: make(chan struct{})}
+		pe.results[tx.Hash()] = res
+		jobs <- res
+	}
+	close(jobs)
+
+	for i := 0; i < pe.workers; i++ {
+		pe.wg.Add(1)
+		go func() {
+			defer pe.wg.Done()
+			for res := range jobs {
+				if !pe.stopped.Load() {
+					w.execute(res)
+				} else {
+					res.aborted = true
+				}
+				close(res.done)
+			}
+		}()
+	}
+	return nil
+}
+
+// result returns the finished speculative execution of the given transaction, if any.
+func (pe *parallelExecutor) result(txHash common.Hash) *speculativeResult {
+	res, ok := pe.results[txHash]
+	if !ok {
+		return nil
+	}
+	// each speculative execution is only used once.
+	delete(pe.results, txHash)
+	<-res.done
+	if res.err != nil || res.aborted {
+		return nil
+	}
+	return res
+}
+
+// conflicts returns whether the given speculative execution read state that was written by the
+// transactions processed before it in the block.
+func (pe *parallelExecutor) conflicts(res *speculativeResult) bool {
+	return pe.written.Intersects(res.reads)
+}
+
+// recordWrites adds the state written by the transaction that was just processed to the state
+// written in the block, if transactions are being speculated. The writes of a replayed speculative
+// execution are known exactly, while the writes of a sequential execution are approximated by the
+// access list of the statedb, without the precompiles. The given accounts are credited by the
+// block, outside of the access list.
+func (pe *parallelExecutor) recordWrites(
+	res *speculativeResult, statedb vm.PolarisStateDB,
+	isPrecompile func(common.Address) bool, credited ...common.Address,
+) {
+	if pe.results == nil {
+		return
+	}
+	for _, addr := range credited {
+		pe.written.AddAccount(addr)
+	}
+	if res != nil {
+		pe.replayed++
+		pe.written.Merge(res.writes)
+		return
+	}
+
+	pe.sequential++
+	tsdb, ok := statedb.(touchedStateDB)
+	if !ok {
+		return
+	}
+	tsdb.ForEachTouched(func(addr common.Address, slots []common.Hash) {
+		if isPrecompile(addr) {
+			return
+		}
+		pe.written.AddAccount(addr)
+		for _, slot := range slots {
+			pe.written.AddSlot(addr, slot)
+		}
+	})
+}
+
+// stop stops the pending speculative executions and clears the results.
+func (pe *parallelExecutor) stop() {
+	pe.stopped.Store(true)
+	pe.wg.Wait()
+	pe.results = nil
+}
+
+// reset stops the pending speculative executions and clears the state written in the block, once
+// the block is finalized or discarded.
+func (pe *parallelExecutor) reset() {
+	pe.stop()
+	if pe.replayed > 0 || pe.sequential > 0 {
+		log.Root().Debug(
+			"parallel execution", "replayed", pe.replayed, "sequential", pe.sequential,
+		)
+	}
+	pe.written = state.NewAccessSet()
+	pe.replayed, pe.sequential = 0, 0
+}
+
+// touchedStateDB is a statedb that exposes the addresses and slots of its access list.
+type touchedStateDB interface {
+	ForEachTouched(func(common.Address, []common.Hash))
+}
+
+// speculativeWorker executes transactions on top of a read-only view of the state.
+type speculativeWorker struct {
+	header      *types.Header
+	signer      types.Signer
+	blockCtx    vm.BlockContext
+	chainConfig *params.ChainConfig
+	vmConfig    vm.Config
+	view        state.Plugin
+	precompiles *speculativePrecompiles
+}
+
+// execute executes the transaction of the given result on a new overlay.
+func (w *speculativeWorker) execute(res *speculativeResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			res.err = fmt.Errorf("speculative execution panicked: %v", r)
+		}
+	}()
+
+	msg, err := TransactionToMessage(res.tx, w.signer, w.header.BaseFee)
+	if err != nil {
+		res.err = err
+		return
+	}
+
+	res.overlay = state.NewOverlay(w.view)
+	statedb := state.NewStateDB(res.overlay)
+	statedb.Reset(res.tx.Hash(), 0)
+	precompiles := &speculativePrecompiles{
+		PrecompileManager: w.precompiles.PrecompileManager,
+		stateless:         w.precompiles.stateless,
+		aborted:           &res.aborted,
+	}
+	// The block hashes are read from the host chain, which is not safe for concurrent use, so
+	// reading a block hash aborts the speculative execution.
+	blockCtx := w.blockCtx
+	blockCtx.GetHash = func(uint64) common.Hash {
+		res.aborted = true
+		return common.Hash{}
+	}
+	evm := vm.NewGethEVMWithPrecompiles(
+		blockCtx, NewEVMTxContext(msg), statedb, w.chainConfig, w.vmConfig, precompiles,
+	)
+
+	gasPool := GasPool(w.header.GasLimit)
+	if res.result, res.err = ApplyMessage(evm, msg, &gasPool); res.err != nil {
+		return
+	}
+	res.logs = statedb.Logs()
+	// Finalizing deletes the suicided accounts through the overlay, so that it is replayed.
+	statedb.Finalize()
+	res.reads, res.writes = res.overlay.Reads(), res.overlay.Writes()
+}
+
+// speculativePrecompiles only runs the default stateless precompiles, which do not depend on the
+// host chain state. Running any other precompile aborts the speculative execution.
+type speculativePrecompiles struct {
+	vm.PrecompileManager
+	// stateless are the addresses of the default stateless precompiles.
+	stateless map[common.Address]struct{}
+	aborted   *bool
+}
+
+// newSpeculativePrecompiles returns the precompiles that can be run speculatively for the rules of
+// the given EVM.
+func newSpeculativePrecompiles(
+	evm *vm.GethEVM, pm vm.PrecompileManager,
+) *speculativePrecompiles {
+	rules := evm.ChainConfig().Rules(evm.Context.BlockNumber, true, evm.Context.Time)
+	stateless := make(map[common.Address]struct{})
+	for _, pc := range precompile.GetDefaultPrecompiles(&rules) {
+		stateless[pc.RegistryKey()] = struct{}{}
+	}
+	return &speculativePrecompiles{PrecompileManager: pm, stateless: stateless}
+}
+
+// Run implements `vm.PrecompileManager`.
+func (sp *speculativePrecompiles) Run(
+	evm precompile.EVM, pc vm.PrecompileContainer, input []byte,
+	caller common.Address, value *big.Int, suppliedGas uint64, readonly bool,
+) ([]byte, uint64, error) {
+	if _, ok := sp.stateless[pc.RegistryKey()]; !ok {
+		*sp.aborted = true
+		return nil, 0, errSpeculationAborted
+	}
+
+	gasCost := pc.RequiredGas(input)
+	if gasCost > suppliedGas {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	output, err := pc.Run(context.Background(), evm, input, caller, value, readonly)
+	return output, suppliedGas - gasCost, err
+}
+
+// lockedPlugin synchronizes the reads of a state plugin that is shared by the workers.
+type lockedPlugin struct {
+	state.Plugin
+	mtx sync.Mutex
+}
+
+// Exist implements `state.Plugin`.
+func (lp *lockedPlugin) Exist(addr common.Address) bool {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.Exist(addr)
+}
+
+// Empty implements `state.Plugin`.
+func (lp *lockedPlugin) Empty(addr common.Address) bool {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.Empty(addr)
+}
+
+// GetBalance implements `state.Plugin`.
+func (lp *lockedPlugin) GetBalance(addr common.Address) *big.Int {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.GetBalance(addr)
+}
+
+// GetNonce implements `state.Plugin`.
+func (lp *lockedPlugin) GetNonce(addr common.Address) uint64 {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.GetNonce(addr)
+}
+
+// GetCodeHash implements `state.Plugin`.
+func (lp *lockedPlugin) GetCodeHash(addr common.Address) common.Hash {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.GetCodeHash(addr)
+}
+
+// GetCode implements `state.Plugin`.
+func (lp *lockedPlugin) GetCode(addr common.Address) []byte {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.GetCode(addr)
+}
+
+// GetCommittedState implements `state.Plugin`.
+func (lp *lockedPlugin) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.GetCommittedState(addr, key)
+}
+
+// GetState implements `state.Plugin`.
+func (lp *lockedPlugin) GetState(addr common.Address, key common.Hash) common.Hash {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.GetState(addr, key)
+}
+
+// ForEachStorage implements `state.Plugin`.
+func (lp *lockedPlugin) ForEachStorage(
+	addr common.Address, cb func(common.Hash, common.Hash) bool,
+) error {
+	lp.mtx.Lock()
+	defer lp.mtx.Unlock()
+	return lp.Plugin.ForEachStorage(addr, cb)
+}
@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	// counterCode increments the value of the first storage slot.
+	counterCode = common.FromHex("0x60005460010160005500")
+	// blockHashCode stores the hash of the parent block in the second storage slot.
+	blockHashCode = common.FromHex("0x43600190034060015500")
+)
+
+var _ = Describe("Parallel Execution", func() {
+	var (
+		ctx          = context.Background()
+		keys         []*ecdsa.PrivateKey
+		counter      = common.BytesToAddress([]byte("counter"))
+		blockHash    = common.BytesToAddress([]byte("blockHash"))
+		identity     = common.BytesToAddress([]byte{4})
+		coinbase     = common.BytesToAddress([]byte("coinbase"))
+		baseFeeSink  = common.BytesToAddress([]byte("baseFeeCollector"))
+		recipients   []common.Address
+		genesis      func() *state.Overlay
+		txs          types.Transactions
+		signer       = types.LatestSignerForChainID(params.DefaultChainConfig.ChainID)
+		nonces       map[common.Address]uint64
+		newTx        func(key *ecdsa.PrivateKey, to common.Address, value int64, data []byte)
+		allAddresses func() []common.Address
+	)
+
+	BeforeEach(func() {
+		keys, recipients = nil, nil
+		for i := 0; i < 8; i++ {
+			key, err := crypto.GenerateEthKey()
+			Expect(err).ToNot(HaveOccurred())
+			keys = append(keys, key)
+			recipients = append(recipients, common.BytesToAddress([]byte{0xee, byte(i)}))
+		}
+		genesis = func() *state.Overlay {
+			o := state.NewQueryOverlay(emptyStatePlugin{})
+			for _, key := range keys {
+				addr := crypto.PubkeyToAddress(key.PublicKey)
+				o.CreateAccount(addr)
+				o.SetBalance(addr, big.NewInt(params.Ether))
+			}
+			for addr, code := range map[common.Address][]byte{
+				counter: counterCode, blockHash: blockHashCode,
+			} {
+				o.CreateAccount(addr)
+				o.SetCode(addr, code)
+			}
+			o.Finalize()
+			return o
+		}
+
+		txs, nonces = nil, make(map[common.Address]uint64)
+		newTx = func(key *ecdsa.PrivateKey, to common.Address, value int64, data []byte) {
+			from := crypto.PubkeyToAddress(key.PublicKey)
+			txs = append(txs, types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    nonces[from],
+				To:       &to,
+				Value:    big.NewInt(value),
+				Gas:      100000,
+				GasPrice: big.NewInt(2),
+				Data:     data,
+			}))
+			nonces[from]++
+		}
+		allAddresses = func() []common.Address {
+			addrs := []common.Address{counter, blockHash, identity, coinbase, baseFeeSink}
+			for _, key := range keys {
+				addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+			}
+			return append(addrs, recipients...)
+		}
+	})
+
+	// run processes the block of txs, executing them in parallel with the given number of workers,
+	// and returns the result.
+	run := func(workers int) *blockRun {
+		live := &testStatePlugin{Overlay: state.NewQueryOverlay(genesis()), genesis: genesis}
+		statedb := state.NewStateDB(live)
+		gp := &testGasPlugin{limit: 10000000}
+		cp := &testConfigPlugin{collector: baseFeeSink}
+		sp := NewStateProcessor(cp, gp, nil, statedb, &vm.Config{})
+		if workers > 0 {
+			sp.EnableParallelExecution(live, workers)
+		}
+
+		header := &types.Header{
+			Number:     big.NewInt(1),
+			GasLimit:   gp.limit,
+			BaseFee:    big.NewInt(1),
+			Coinbase:   coinbase,
+			Difficulty: big.NewInt(0),
+			Time:       1,
+		}
+		blockCtx := NewEVMBlockContext(header, nil, &coinbase)
+		blockCtx.GetHash = func(n uint64) common.Hash {
+			return crypto.Keccak256Hash(new(big.Int).SetUint64(n).Bytes())
+		}
+		evm := vm.NewGethEVMWithPrecompiles(
+			blockCtx, vm.TxContext{}, statedb, params.DefaultChainConfig, vm.Config{}, sp.pp,
+		)
+
+		gp.Prepare(ctx)
+		sp.Prepare(ctx, evm, header)
+		Expect(sp.SpeculateTransactions(ctx, txs)).To(Succeed())
+		for _, tx := range txs {
+			gp.Reset(ctx)
+			_, err := sp.ProcessTransaction(ctx, tx)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		gp.Reset(ctx)
+
+		res := &blockRun{}
+		if sp.pe != nil {
+			res.replayed, res.sequential = sp.pe.replayed, sp.pe.sequential
+		}
+		block, receipts, _, err := sp.Finalize(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		res.blockHash = block.Hash()
+		res.receipts, err = json.Marshal(receipts)
+		Expect(err).ToNot(HaveOccurred())
+		for _, receipt := range receipts {
+			var bz []byte
+			bz, err = receipt.MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			res.receipts = append(res.receipts, bz...)
+		}
+		res.state = stateDigest(live, allAddresses())
+		return res
+	}
+
+	It("should produce the same block and state as sequential execution", func() {
+		newTx(keys[0], recipients[0], 1, nil)
+		newTx(keys[1], recipients[1], 2, nil)
+		// the second tx of a sender conflicts with its first tx.
+		newTx(keys[0], recipients[2], 3, nil)
+		// the txs that increment the counter conflict with each other.
+		newTx(keys[2], counter, 0, nil)
+		newTx(keys[3], counter, 0, nil)
+		// reading a block hash aborts the speculative execution.
+		newTx(keys[4], blockHash, 0, nil)
+		// the stateless precompiles are run speculatively.
+		newTx(keys[5], identity, 0, []byte("identity"))
+		newTx(keys[6], recipients[3], 4, nil)
+		// a transfer to the sender of a previous tx.
+		newTx(keys[7], crypto.PubkeyToAddress(keys[1].PublicKey), 5, nil)
+		newTx(keys[2], counter, 0, nil)
+
+		sequential := run(0)
+		for _, workers := range []int{1, 4, 16} {
+			parallel := run(workers)
+			Expect(parallel.blockHash).To(Equal(sequential.blockHash))
+			Expect(parallel.receipts).To(Equal(sequential.receipts))
+			Expect(parallel.state).To(Equal(sequential.state))
+
+			// both the replay and the sequential execution were exercised.
+			Expect(parallel.replayed).To(BeNumerically(">", 0))
+			Expect(parallel.sequential).To(BeNumerically(">", 0))
+			Expect(parallel.replayed + parallel.sequential).To(Equal(len(txs)))
+		}
+	})
+
+	It("should produce the same block and state for independent transactions", func() {
+		for i, key := range keys {
+			newTx(key, recipients[i], int64(i+1), nil)
+		}
+
+		sequential := run(0)
+		parallel := run(4)
+		Expect(parallel.blockHash).To(Equal(sequential.blockHash))
+		Expect(parallel.receipts).To(Equal(sequential.receipts))
+		Expect(parallel.state).To(Equal(sequential.state))
+		Expect(parallel.replayed).To(Equal(len(txs)))
+	})
+})
+
+// blockRun is the result of processing a block.
+type blockRun struct {
+	blockHash            common.Hash
+	receipts             []byte
+	state                common.Hash
+	replayed, sequential int
+}
+
+// stateDigest returns a digest of the accounts, and their first two storage slots, with the given
+// addresses.
+func stateDigest(p state.Plugin, addrs []common.Address) common.Hash {
+	var bz []byte
+	for _, addr := range addrs {
+		bz = append(bz, addr.Bytes()...)
+		bz = append(bz, p.GetBalance(addr).Bytes()...)
+		bz = append(bz, new(big.Int).SetUint64(p.GetNonce(addr)).Bytes()...)
+		bz = append(bz, p.GetCodeHash(addr).Bytes()...)
+		for _, slot := range []common.Hash{{}, common.BigToHash(big.NewInt(1))} {
+			bz = append(bz, p.GetState(addr, slot).Bytes()...)
+		}
+	}
+	return crypto.Keccak256Hash(bz)
+}
+
+// testStatePlugin is an in-memory state plugin. The state of the parent block is a fresh copy of
+// the genesis state, so that it can be read concurrently with the live state.
+type testStatePlugin struct {
+	*state.Overlay
+	genesis func() *state.Overlay
+}
+
+func (p *testStatePlugin) GetStateByNumber(int64) (StatePlugin, error) {
+	return &testStatePlugin{Overlay: p.genesis(), genesis: p.genesis}, nil
+}
+
+// emptyStatePlugin is a state plugin without any accounts.
+type emptyStatePlugin struct {
+	state.Plugin
+}
+
+func (emptyStatePlugin) RegistryKey() string                    { return "state" }
+func (emptyStatePlugin) GetContext() context.Context            { return context.Background() }
+func (emptyStatePlugin) Exist(common.Address) bool              { return false }
+func (emptyStatePlugin) Empty(common.Address) bool              { return true }
+func (emptyStatePlugin) GetBalance(common.Address) *big.Int     { return new(big.Int) }
+func (emptyStatePlugin) GetNonce(common.Address) uint64         { return 0 }
+func (emptyStatePlugin) GetCodeHash(common.Address) common.Hash { return common.Hash{} }
+func (emptyStatePlugin) GetCode(common.Address) []byte          { return nil }
+
+func (emptyStatePlugin) GetCommittedState(common.Address, common.Hash) common.Hash {
+	return common.Hash{}
+}
+
+func (emptyStatePlugin) GetState(common.Address, common.Hash) common.Hash {
+	return common.Hash{}
+}
+
+func (emptyStatePlugin) ForEachStorage(common.Address, func(common.Hash, common.Hash) bool) error {
+	return nil
+}
+
+// testGasPlugin tracks the gas consumed by the txs of a block.
+type testGasPlugin struct {
+	limit, blockUsed, txUsed uint64
+}
+
+func (gp *testGasPlugin) Prepare(context.Context)  { gp.blockUsed, gp.txUsed = 0, 0 }
+func (gp *testGasPlugin) GasRemaining() uint64     { return gp.limit - gp.blockUsed - gp.txUsed }
+func (gp *testGasPlugin) GasConsumed() uint64      { return gp.txUsed }
+func (gp *testGasPlugin) BlockGasConsumed() uint64 { return gp.blockUsed }
+func (gp *testGasPlugin) BlockGasLimit() uint64    { return gp.limit }
+
+func (gp *testGasPlugin) Reset(context.Context) {
+	gp.blockUsed, gp.txUsed = gp.blockUsed+gp.txUsed, 0
+}
+
+func (gp *testGasPlugin) ConsumeGas(amount uint64) error {
+	if amount > gp.GasRemaining() {
+		return ErrBlockOutOfGas
+	}
+	gp.txUsed += amount
+	return nil
+}
+
+// testConfigPlugin is the configuration of the default chain, with a base fee collector.
+type testConfigPlugin struct {
+	collector common.Address
+}
+
+func (cp *testConfigPlugin) Prepare(context.Context)           {}
+func (cp *testConfigPlugin) ChainConfig() *params.ChainConfig  { return params.DefaultChainConfig }
+func (cp *testConfigPlugin) ExtraEips() []int                  { return nil }
+func (cp *testConfigPlugin) FeeCollector() *common.Address     { return nil }
+func (cp *testConfigPlugin) BaseFeeCollector() *common.Address { return &cp.collector }
+
+func (cp *testConfigPlugin) ChainConfigAt(int64) (*params.ChainConfig, error) {
+	return params.DefaultChainConfig, nil
+}
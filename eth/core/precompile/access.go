@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package precompile
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/lib/errors"
+)
+
+// AccessRule matches calls to a precompile. A rule without a selector matches all of the methods
+// of the precompile and a rule without a caller matches all of the callers.
+type AccessRule struct {
+	// Precompile is the address of the precompile.
+	Precompile common.Address
+	// Selector is the method ID of the method, or nil.
+	Selector []byte
+	// Caller is the address of the caller, or nil.
+	Caller *common.Address
+}
+
+// ParseAccessRule parses an access rule that is formatted as
+// `<precompile>[:<method selector>][@<caller>]`, e.g. `0x...:0xa9059cbb@0x...`.
+func ParseAccessRule(rule string) (*AccessRule, error) {
+	target, caller, hasCaller := strings.Cut(rule, "@")
+	precompile, selector, hasSelector := strings.Cut(target, ":")
+	if !common.IsHexAddress(precompile) {
+		return nil, errors.Wrapf(ErrInvalidAccessRule, "invalid precompile address in %q", rule)
+	}
+	r := &AccessRule{Precompile: common.HexToAddress(precompile)}
+	if hasSelector {
+		bz, err := hexutil.Decode(selector)
+		if err != nil || len(bz) != NumBytesMethodID {
+			return nil, errors.Wrapf(ErrInvalidAccessRule, "invalid method selector in %q", rule)
+		}
+		r.Selector = bz
+	}
+	if hasCaller {
+		if !common.IsHexAddress(caller) {
+			return nil, errors.Wrapf(ErrInvalidAccessRule, "invalid caller address in %q", rule)
+		}
+		addr := common.HexToAddress(caller)
+		r.Caller = &addr
+	}
+	return r, nil
+}
+
+// matchesMethod returns whether the rule matches the given method of the given precompile.
+func (r *AccessRule) matchesMethod(precompile common.Address, selector []byte) bool {
+	return r.Precompile == precompile && (r.Selector == nil || bytes.Equal(r.Selector, selector))
+}
+
+// matchesCaller returns whether the rule matches the given caller.
+func (r *AccessRule) matchesCaller(caller common.Address) bool {
+	return r.Caller == nil || *r.Caller == caller
+}
+
+// AccessController decides which callers may call which precompile methods, based on an allowlist
+// and a denylist of access rules. A call that is matched by a rule of the denylist is denied. A
+// precompile method that is matched by a rule of the allowlist may only be called by the callers
+// that are matched by the allowlist rules of that method. All other calls are allowed.
+type AccessController struct {
+	allow []*AccessRule
+	deny  []*AccessRule
+}
+
+// NewAccessController returns an access controller for the given allowlist and denylist of access
+// rules. It returns an error if any of the rules is malformed.
+func NewAccessController(allowlist, denylist []string) (*AccessController, error) {
+	ac := &AccessController{
+		allow: make([]*AccessRule, 0, len(allowlist)),
+		deny:  make([]*AccessRule, 0, len(denylist)),
+	}
+	for _, rule := range allowlist {
+		r, err := ParseAccessRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		ac.allow = append(ac.allow, r)
+	}
+	for _, rule := range denylist {
+		r, err := ParseAccessRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		ac.deny = append(ac.deny, r)
+	}
+	return ac, nil
+}
+
+// Check returns `ErrAccessDenied` if the given caller may not call the method of the given
+// precompile that is selected by the given input.
+func (ac *AccessController) Check(precompile, caller common.Address, input []byte) error {
+	var selector []byte
+	if len(input) >= NumBytesMethodID {
+		selector = input[:NumBytesMethodID]
+	}
+
+	for _, r := range ac.deny {
+		if r.matchesMethod(precompile, selector) && r.matchesCaller(caller) {
+			return ErrAccessDenied
+		}
+	}
+
+	restricted := false
+	for _, r := range ac.allow {
+		if !r.matchesMethod(precompile, selector) {
+			continue
+		}
+		if r.matchesCaller(caller) {
+			return nil
+		}
+		restricted = true
+	}
+	if restricted {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// revertSelector is the method ID of `Error(string)`, which is used to encode revert reasons.
+var revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// PackRevert returns the given reason encoded as the standard `Error(string)` revert data, which
+// Solidity callers can decode as a revert reason.
+func PackRevert(reason string) []byte {
+	padded := (len(reason) + wordSize - 1) / wordSize * wordSize
+	data := make([]byte, 0, NumBytesMethodID+2*wordSize+padded)
+	data = append(data, revertSelector...)
+	data = append(data, common.LeftPadBytes([]byte{wordSize}, wordSize)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(int64(len(reason))).Bytes(), wordSize)...)
+	data = append(data, reason...)
+	return append(data, make([]byte, padded-len(reason))...)
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package precompile_test
+
+import (
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/precompile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Access Controller", func() {
+	var (
+		pc       = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		other    = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		alice    = common.HexToAddress("0x2000000000000000000000000000000000000001")
+		bob      = common.HexToAddress("0x2000000000000000000000000000000000000002")
+		selector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+		input    = append(selector, 1, 2, 3)
+		unlisted = []byte{1, 2, 3, 4}
+	)
+
+	It("should reject malformed rules", func() {
+		for _, rule := range []string{
+			"", "0x12", pc.Hex() + ":0x12", pc.Hex() + ":0xa9059cbb@bob", pc.Hex() + "@",
+		} {
+			_, err := precompile.NewAccessController([]string{rule}, nil)
+			Expect(err).To(MatchError(precompile.ErrInvalidAccessRule), rule)
+		}
+	})
+
+	It("should allow every call without rules", func() {
+		ac, err := precompile.NewAccessController(nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ac.Check(pc, alice, input)).To(Succeed())
+	})
+
+	It("should deny calls that match the denylist", func() {
+		ac, err := precompile.NewAccessController(nil, []string{pc.Hex() + ":0xa9059cbb"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ac.Check(pc, alice, input)).To(MatchError(precompile.ErrAccessDenied))
+		Expect(ac.Check(pc, alice, unlisted)).To(Succeed())
+		Expect(ac.Check(other, alice, input)).To(Succeed())
+	})
+
+	It("should only allow the allowed callers of a restricted method", func() {
+		ac, err := precompile.NewAccessController([]string{pc.Hex() + ":0xa9059cbb@" + alice.Hex()}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ac.Check(pc, alice, input)).To(Succeed())
+		Expect(ac.Check(pc, bob, input)).To(MatchError(precompile.ErrAccessDenied))
+		Expect(ac.Check(pc, bob, unlisted)).To(Succeed())
+	})
+
+	It("should restrict every method of a precompile without a selector", func() {
+		ac, err := precompile.NewAccessController(
+			[]string{pc.Hex() + "@" + alice.Hex()}, []string{pc.Hex() + ":0xa9059cbb@" + alice.Hex()},
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ac.Check(pc, alice, unlisted)).To(Succeed())
+		Expect(ac.Check(pc, bob, unlisted)).To(MatchError(precompile.ErrAccessDenied))
+		// The denylist takes precedence over the allowlist.
+		Expect(ac.Check(pc, alice, input)).To(MatchError(precompile.ErrAccessDenied))
+	})
+
+	It("should pack a standard revert reason", func() {
+		reason, err := abi.UnpackRevert(precompile.PackRevert(precompile.ErrAccessDenied.Error()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(Equal(precompile.ErrAccessDenied.Error()))
+	})
+})
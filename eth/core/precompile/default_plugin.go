@@ -45,8 +45,21 @@ func NewDefaultPlugin() Plugin {
 	}
 }
 
+// Clone returns a new instance of the default precompile plugin.
+//
+// Clone implements `ClonablePlugin`.
+func (dp *defaultPlugin) Clone() Plugin {
+	return NewDefaultPlugin()
+}
+
+// GetPrecompiles returns the default precompiles for the given rules, which are registered for
+// every block. Without rules, there are no precompiles to return.
+//
 // GetPrecompiles implements `core.PrecompilePlugin`.
 func (dp *defaultPlugin) GetPrecompiles(rules *params.Rules) []Registrable {
+	if rules == nil {
+		return nil
+	}
 	return GetDefaultPrecompiles(rules)
 }
 
@@ -65,6 +78,15 @@ func (dp *defaultPlugin) Run(
 	suppliedGas -= gasCost
 	output, err := pc.Run(context.Background(), evm, input, caller, value, readonly)
 
+	// Charge the gas that depends on the output of the execution.
+	if dg, ok := pc.(DynamicGasContainer); ok && err == nil {
+		returnGas := dg.ReturnGas(input, output)
+		if returnGas > suppliedGas {
+			return nil, 0, vm.ErrOutOfGas
+		}
+		suppliedGas -= returnGas
+	}
+
 	return output, suppliedGas, err
 }
 
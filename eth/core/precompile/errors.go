@@ -49,4 +49,18 @@ var (
 	// ErrNoPrecompileMethodForABIMethod is returned when no precompile method is provided for a
 	// corresponding ABI method.
 	ErrNoPrecompileMethodForABIMethod = errors.New("this ABI method does not have a corresponding precompile method")
+
+	// ErrAccessDenied is returned when a caller is not allowed to call a precompile method.
+	ErrAccessDenied = errors.New("caller is not allowed to call this precompile method")
+
+	// ErrInvalidAccessRule is returned when a precompile access rule is malformed.
+	ErrInvalidAccessRule = errors.New("invalid precompile access rule")
+
+	// ErrInvalidArgument is returned when a precompile method, or event, receives an argument of
+	// an unexpected type or an unexpected number of arguments.
+	ErrInvalidArgument = errors.New("invalid argument to precompile method")
+
+	// ErrOverlayWrite is returned when a precompile method that modifies state is called on top
+	// of a `state.Overlay`, whose writes must not reach the stores of the host chain.
+	ErrOverlayWrite = errors.New("precompile method can not modify state on top of an overlay")
 )
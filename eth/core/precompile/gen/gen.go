@@ -0,0 +1,340 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
+)
+
+var (
+	// ErrMissingConfig is returned when a required field of the generator `Config` is empty.
+	ErrMissingConfig = errors.New("package and type are required to generate a precompile")
+
+	// ErrUnsupportedType is returned when an ABI type can not be represented as a Go type.
+	ErrUnsupportedType = errors.New("unsupported ABI type")
+)
+
+// reservedNames are the identifiers used by the generated code for the precompile call context
+// and the imported packages; ABI arguments with these names are renamed.
+var reservedNames = map[string]struct{}{
+	"ctx": {}, "evm": {}, "caller": {}, "value": {}, "readonly": {}, "args": {}, "ok": {},
+	"err": {}, "srv": {}, "addr": {}, "context": {}, "strings": {}, "big": {}, "abi": {},
+	"common": {}, "precompile": {}, "types": {}, "utils": {},
+}
+
+// importPaths maps the package paths of the Go types used by the ABI decoder to the packages
+// imported by the generated code.
+var importPaths = map[string]string{
+	"math/big":                               "math/big",
+	"github.com/ethereum/go-ethereum/common": "pkg.berachain.dev/polaris/eth/common",
+}
+
+// Config is the configuration of a precompile generated from a Solidity interface ABI.
+type Config struct {
+	// Package is the name of the Go package of the generated file.
+	Package string
+
+	// Type is the name of the precompile, which prefixes the generated declarations.
+	Type string
+
+	// ABI is the JSON ABI of the Solidity interface implemented by the precompile.
+	ABI string
+}
+
+// Generate returns the formatted Go source of the typed server interface, method adapter, and
+// event helpers for the precompile described by the given config.
+func Generate(cfg Config) ([]byte, error) {
+	if cfg.Package == "" || cfg.Type == "" {
+		return nil, ErrMissingConfig
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(cfg.ABI))
+	if err != nil {
+		return nil, err
+	}
+
+	data := &tmplData{
+		Package: cfg.Package,
+		Type:    cfg.Type,
+		ABI:     cfg.ABI,
+		Imports: map[string]struct{}{
+			"context":                              {},
+			"math/big":                             {},
+			"pkg.berachain.dev/polaris/eth/common": {},
+			"pkg.berachain.dev/polaris/eth/core/precompile": {},
+			"pkg.berachain.dev/polaris/lib/utils":           {},
+		},
+	}
+	// Overloaded methods and events are named after the types of their inputs, rather than their
+	// position in the ABI, so that the generated names are stable.
+	overloads := make(map[string]int)
+	for _, method := range contractABI.Methods {
+		overloads["method "+method.RawName]++
+	}
+	for _, event := range contractABI.Events {
+		overloads["event "+event.RawName]++
+	}
+
+	for _, name := range sortedKeys(contractABI.Methods) {
+		var (
+			method *tmplMethod
+			raw    = contractABI.Methods[name]
+		)
+		if method, err = data.newMethod(raw, overloads["method "+raw.RawName] > 1); err != nil {
+			return nil, err
+		}
+		data.Methods = append(data.Methods, method)
+	}
+	for _, name := range sortedKeys(contractABI.Events) {
+		var (
+			event *tmplEvent
+			raw   = contractABI.Events[name]
+		)
+		if event, err = data.newEvent(raw, overloads["event "+raw.RawName] > 1); err != nil {
+			return nil, err
+		}
+		data.Events = append(data.Events, event)
+	}
+	if len(data.Events) > 0 {
+		data.Imports["strings"] = struct{}{}
+		data.Imports["pkg.berachain.dev/polaris/eth/accounts/abi"] = struct{}{}
+		data.Imports["pkg.berachain.dev/polaris/eth/core/types"] = struct{}{}
+	}
+
+	var buf bytes.Buffer
+	if err = precompileTmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// ==============================================================================
+// Template Data
+// ==============================================================================
+
+// tmplData is the data used to execute the precompile template.
+type tmplData struct {
+	Package string
+	Type    string
+	ABI     string
+	Imports map[string]struct{}
+	Methods []*tmplMethod
+	Events  []*tmplEvent
+}
+
+// tmplMethod is a precompile method in the template.
+type tmplMethod struct {
+	Name    string
+	Key     string
+	Sig     string
+	Inputs  []*tmplArg
+	Outputs []*tmplArg
+}
+
+// tmplEvent is a contract event in the template.
+type tmplEvent struct {
+	Name   string
+	Key    string
+	Sig    string
+	Inputs []*tmplArg
+}
+
+// tmplArg is an argument, or return value, of a method or event in the template.
+type tmplArg struct {
+	Name string
+	Type string
+}
+
+// StdImports returns the standard library packages imported by the generated code.
+func (d *tmplData) StdImports() []string {
+	var imports []string
+	for _, importPath := range sortedKeys(d.Imports) {
+		if !strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			imports = append(imports, importPath)
+		}
+	}
+	return imports
+}
+
+// ModuleImports returns the non-standard library packages imported by the generated code.
+func (d *tmplData) ModuleImports() []string {
+	var imports []string
+	for _, importPath := range sortedKeys(d.Imports) {
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			imports = append(imports, importPath)
+		}
+	}
+	return imports
+}
+
+// newMethod returns the template method of the given ABI method. The name of an overloaded method
+// is suffixed with the types of its inputs.
+func (d *tmplData) newMethod(method abi.Method, overloaded bool) (*tmplMethod, error) {
+	inputs, err := d.newArgs(method.Inputs, "arg")
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := d.newArgs(method.Outputs, "ret")
+	if err != nil {
+		return nil, err
+	}
+	return &tmplMethod{
+		Name:    goName(method.RawName, method.Inputs, overloaded),
+		Key:     method.Name,
+		Sig:     method.Sig,
+		Inputs:  inputs,
+		Outputs: outputs,
+	}, nil
+}
+
+// newEvent returns the template event of the given ABI event. The name of an overloaded event is
+// suffixed with the types of its inputs.
+func (d *tmplData) newEvent(event abi.Event, overloaded bool) (*tmplEvent, error) {
+	inputs, err := d.newArgs(event.Inputs, "arg")
+	if err != nil {
+		return nil, err
+	}
+	return &tmplEvent{
+		Name:   goName(event.RawName, event.Inputs, overloaded),
+		Key:    event.Name,
+		Sig:    event.Sig,
+		Inputs: inputs,
+	}, nil
+}
+
+// newArgs returns the template arguments of the given ABI arguments. Unnamed, reserved, or
+// duplicate argument names are replaced by the given prefix followed by the argument index.
+func (d *tmplData) newArgs(args abi.Arguments, prefix string) ([]*tmplArg, error) {
+	seen := make(map[string]struct{}, len(args))
+	tmplArgs := make([]*tmplArg, len(args))
+	for i, arg := range args {
+		typ, err := d.goType(arg.Type.GetType())
+		if err != nil {
+			return nil, err
+		}
+
+		name := abi.ToMixedCase(arg.Name)
+		_, reserved := reservedNames[name]
+		_, duplicate := seen[name]
+		if !token.IsIdentifier(name) || reserved || duplicate {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		seen[name] = struct{}{}
+
+		tmplArgs[i] = &tmplArg{Name: name, Type: typ}
+	}
+	return tmplArgs, nil
+}
+
+// goType returns the Go source representation of the given type, as produced by the ABI decoder,
+// and records the packages that it requires.
+func (d *tmplData) goType(rt reflect.Type) (string, error) {
+	if rt.Name() != "" {
+		if rt.PkgPath() == "" {
+			return rt.Name(), nil
+		}
+		importPath, ok := importPaths[rt.PkgPath()]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedType, rt)
+		}
+		d.Imports[importPath] = struct{}{}
+		return path.Base(importPath) + "." + rt.Name(), nil
+	}
+
+	//nolint:exhaustive // the ABI decoder only produces these kinds of unnamed types.
+	switch rt.Kind() {
+	case reflect.Pointer:
+		elem, err := d.goType(rt.Elem())
+		return "*" + elem, err
+	case reflect.Slice:
+		elem, err := d.goType(rt.Elem())
+		return "[]" + elem, err
+	case reflect.Array:
+		elem, err := d.goType(rt.Elem())
+		return fmt.Sprintf("[%d]%s", rt.Len(), elem), err
+	case reflect.Struct:
+		fields := make([]string, rt.NumField())
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			typ, err := d.goType(field.Type)
+			if err != nil {
+				return "", err
+			}
+			fields[i] = fmt.Sprintf("%s %s %q", field.Name, typ, field.Tag)
+		}
+		return "struct { " + strings.Join(fields, "; ") + " }", nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedType, rt)
+	}
+}
+
+// goName returns the Go name of the method or event with the given name and inputs. The name of an
+// overloaded method or event is suffixed with the types of its inputs, e.g.
+// `DelegateAddressUint256` for `delegate(address,uint256)`.
+func goName(name string, inputs abi.Arguments, overloaded bool) string {
+	goName := abi.ToCamelCase(name)
+	if overloaded {
+		for _, input := range inputs {
+			goName += typeName(input.Type)
+		}
+	}
+	return goName
+}
+
+// typeName returns the name of the given ABI type, as used in the names of overloads, e.g.
+// `Uint256Array` for `uint256[]` and `TupleUint256String` for `(uint256,string)`.
+func typeName(typ abi.Type) string {
+	switch {
+	case typ.Elem != nil && typ.Size > 0:
+		return fmt.Sprintf("%sArray%d", typeName(*typ.Elem), typ.Size)
+	case typ.Elem != nil:
+		return typeName(*typ.Elem) + "Array"
+	case len(typ.TupleElems) > 0:
+		name := "Tuple"
+		for _, elem := range typ.TupleElems {
+			name += typeName(*elem)
+		}
+		return name
+	default:
+		return abi.ToCamelCase(typ.String())
+	}
+}
+
+// sortedKeys returns the keys of the given map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
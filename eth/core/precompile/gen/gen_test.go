@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package gen_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pkg.berachain.dev/polaris/eth/core/precompile/gen"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGen(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "eth/core/precompile/gen")
+}
+
+const testABI = `[
+	{"type":"function","name":"getBalance","stateMutability":"view",
+	 "inputs":[{"name":"account","type":"address"},{"name":"value","type":"string"}],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"send","stateMutability":"nonpayable",
+	 "inputs":[{"name":"coins","type":"tuple[]","components":[
+		{"name":"amount","type":"uint256"},{"name":"denom","type":"string"}]}],
+	 "outputs":[{"name":"","type":"bool"},{"name":"","type":"bytes32"}]},
+	{"type":"function","name":"noop","stateMutability":"nonpayable","inputs":[],"outputs":[]},
+	{"type":"event","name":"Sent","anonymous":false,
+	 "inputs":[{"name":"from","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false}]}
+]`
+
+// testServer implements the server generated for `testABI`, and uses the generated declarations.
+const testServer = `package foo
+
+import (
+	"context"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/precompile"
+)
+
+type server struct{}
+
+func (server) GetBalance(
+	context.Context, precompile.EVM, common.Address, *big.Int, bool, common.Address, string,
+) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+func (server) Noop(context.Context, precompile.EVM, common.Address, *big.Int, bool) error {
+	return nil
+}
+
+func (server) Send(
+	_ context.Context, _ precompile.EVM, _ common.Address, _ *big.Int, _ bool,
+	coins []struct {
+		Amount *big.Int ` + "`json:\"amount\"`" + `
+		Denom  string   ` + "`json:\"denom\"`" + `
+	},
+) (bool, [32]byte, error) {
+	return len(coins) > 0, [32]byte{}, nil
+}
+
+var (
+	_ precompile.Methods = FooMethods(server{})
+	_                    = NewFooSentLog
+)
+`
+
+var _ = Describe("Generate", func() {
+	It("should require a package and a type", func() {
+		_, err := gen.Generate(gen.Config{Package: "foo", ABI: testABI})
+		Expect(err).To(MatchError(gen.ErrMissingConfig))
+	})
+
+	It("should reject an invalid ABI", func() {
+		_, err := gen.Generate(gen.Config{Package: "foo", Type: "Foo", ABI: "{"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should generate the server, methods, and events of the precompile", func() {
+		code, err := gen.Generate(gen.Config{Package: "foo", Type: "Foo", ABI: testABI})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = parser.ParseFile(token.NewFileSet(), "foo.go", code, parser.AllErrors)
+		Expect(err).ToNot(HaveOccurred())
+
+		src := string(code)
+		Expect(src).To(ContainSubstring("package foo"))
+		Expect(src).To(ContainSubstring("type FooServer interface {"))
+		Expect(src).To(ContainSubstring("func FooMethods(srv FooServer) precompile.Methods {"))
+		Expect(src).To(ContainSubstring(`AbiSig: "getBalance(address,string)"`))
+		Expect(src).To(ContainSubstring(`AbiSig: "send((uint256,string)[])"`))
+		Expect(src).To(ContainSubstring(`AbiSig: "noop()"`))
+		Expect(src).To(ContainSubstring("account common.Address,\n\t\targ1 string,\n"))
+		Expect(src).To(ContainSubstring(") (*big.Int, error)"))
+		Expect(src).To(ContainSubstring("Amount *big.Int \"json:\\\"amount\\\"\""))
+		Expect(src).To(ContainSubstring(", [32]uint8, error)"))
+		Expect(src).To(ContainSubstring("func NewFooSentLog("))
+	})
+
+	It("should name overloaded methods after the types of their inputs", func() {
+		code, err := gen.Generate(gen.Config{Package: "foo", Type: "Foo", ABI: `[
+			{"type":"function","name":"delegate","stateMutability":"nonpayable",
+			 "inputs":[{"name":"validator","type":"address"},{"name":"amount","type":"uint256"}],
+			 "outputs":[]},
+			{"type":"function","name":"delegate","stateMutability":"nonpayable",
+			 "inputs":[{"name":"validator","type":"string"},{"name":"amount","type":"uint256"}],
+			 "outputs":[]},
+			{"type":"function","name":"undelegate","stateMutability":"nonpayable",
+			 "inputs":[{"name":"amounts","type":"uint256[2]"}],"outputs":[]}
+		]`})
+		Expect(err).ToNot(HaveOccurred())
+
+		src := string(code)
+		Expect(src).To(ContainSubstring("\tDelegateAddressUint256(\n"))
+		Expect(src).To(ContainSubstring("\tDelegateStringUint256(\n"))
+		Expect(src).To(ContainSubstring("\tUndelegate(\n"))
+		Expect(src).ToNot(ContainSubstring("Delegate0"))
+	})
+
+	It("should generate code that type-checks against the polaris packages", func() {
+		code, err := gen.Generate(gen.Config{Package: "foo", Type: "Foo", ABI: testABI})
+		Expect(err).ToNot(HaveOccurred())
+
+		// The files are placed in this directory, so that the imports resolve in this module.
+		dir, err := os.Getwd()
+		Expect(err).ToNot(HaveOccurred())
+		fset := token.NewFileSet()
+		files := make([]*ast.File, 2)
+		for i, src := range [][]byte{code, []byte(testServer)} {
+			name := filepath.Join(dir, []string{"foo.go", "server.go"}[i])
+			files[i], err = parser.ParseFile(fset, name, src, parser.AllErrors)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+		_, err = conf.Check("foo", fset, files, nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package gen
+
+import "text/template"
+
+// precompileTmpl is the template of the Go source generated for a precompile.
+var precompileTmpl = template.Must(template.New("precompile").Parse(
+	`// Code generated by precompilegen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .StdImports }}
+	"{{ . }}"
+{{- end }}
+{{ range .ModuleImports }}
+	"{{ . }}"
+{{- end }}
+)
+
+{{ $type := .Type -}}
+
+// {{ $type }}ABI is the JSON ABI of the {{ $type }} precompile's Solidity interface.
+const {{ $type }}ABI = {{ printf "%q" .ABI }}
+
+// {{ $type }}Server is the interface of the {{ $type }} precompile; it has one method for each
+// method of the Solidity interface.
+type {{ $type }}Server interface {
+{{- range .Methods }}
+	// {{ .Name }} implements the ` + "`{{ .Sig }}`" + ` method.
+	{{ .Name }}(
+		ctx context.Context,
+		evm precompile.EVM,
+		caller common.Address,
+		value *big.Int,
+		readonly bool,
+	{{- range .Inputs }}
+		{{ .Name }} {{ .Type }},
+	{{- end }}
+	) ({{ range .Outputs }}{{ .Type }}, {{ end }}error)
+{{- end }}
+}
+
+// {{ $type }}Methods returns the precompile methods of the given {{ $type }} server, which
+// decode the arguments of and encode the values returned by the server methods.
+func {{ $type }}Methods(srv {{ $type }}Server) precompile.Methods {
+	return precompile.Methods{
+{{- range .Methods }}
+		{
+			AbiSig: "{{ .Sig }}",
+			Execute: func(
+				ctx context.Context,
+				evm precompile.EVM,
+				caller common.Address,
+				value *big.Int,
+				readonly bool,
+				args ...any,
+			) ([]any, error) {
+				if len(args) != {{ len .Inputs }} {
+					return nil, precompile.ErrInvalidArgument
+				}
+			{{- range $i, $input := .Inputs }}
+				arg{{ $i }}, ok := utils.GetAs[{{ $input.Type }}](args[{{ $i }}])
+				if !ok {
+					return nil, precompile.ErrInvalidArgument
+				}
+			{{- end }}
+			{{- if .Outputs }}
+				{{ range $i, $output := .Outputs }}ret{{ $i }}, {{ end }}err := srv.{{ .Name }}(
+					ctx, evm, caller, value, readonly,{{ range $i, $input := .Inputs }} arg{{ $i }},{{ end }}
+				)
+				if err != nil {
+					return nil, err
+				}
+				return []any{
+					{{- range $i, $output := .Outputs }}{{ if $i }}, {{ end }}ret{{ $i }}{{ end -}}
+				}, nil
+			{{- else }}
+				return nil, srv.{{ .Name }}(
+					ctx, evm, caller, value, readonly,{{ range $i, $input := .Inputs }} arg{{ $i }},{{ end }}
+				)
+			{{- end }}
+			},
+		},
+{{- end }}
+	}
+}
+{{- if .Events }}
+
+// {{ $type }}Events is the parsed ABI of the {{ $type }} precompile's events.
+var {{ $type }}Events = func() map[string]abi.Event {
+	contractABI, err := abi.JSON(strings.NewReader({{ $type }}ABI))
+	if err != nil {
+		panic(err)
+	}
+	return contractABI.Events
+}()
+{{- range .Events }}
+
+// New{{ $type }}{{ .Name }}Log returns the log of the ` + "`{{ .Sig }}`" + ` event emitted by the
+// {{ $type }} precompile at the given address.
+func New{{ $type }}{{ .Name }}Log(
+	addr common.Address,
+{{- range .Inputs }}
+	{{ .Name }} {{ .Type }},
+{{- end }}
+) (*types.Log, error) {
+	return precompile.NewLog(
+		addr, {{ $type }}Events["{{ .Key }}"],{{ range .Inputs }} {{ .Name }},{{ end }}
+	)
+}
+{{- end }}
+{{- end }}
+`))
@@ -50,6 +50,40 @@ type (
 		// EVM.
 		DisableReentrancy(context.Context)
 	}
+
+	// ClonablePlugin is an optional extension of the `Plugin` for plugins that can create fresh
+	// instances of themselves, with an empty registry, so that executing transactions outside of
+	// block processing, such as replaying a block for tracing, does not share the plugin that
+	// processes blocks.
+	ClonablePlugin interface {
+		Plugin
+		// Clone returns a new plugin that runs the same precompiles, with an empty registry.
+		Clone() Plugin
+	}
+)
+
+type (
+	// DynamicGasContainer is a precompile container that charges gas after its execution, based
+	// on its output, in addition to the gas that is charged before its execution with
+	// `RequiredGas`. Precompile plugins must charge this gas from the gas supplied to the call, so
+	// that it is included in the gas used by the call in traces.
+	DynamicGasContainer interface {
+		vm.PrecompileContainer
+
+		// ReturnGas returns the amount of gas that is charged for the given output of the
+		// execution of the given input.
+		ReturnGas(input, output []byte) uint64
+	}
+
+	// ReadOnlyContainer is a precompile container that knows which of its calls do not modify
+	// state. Precompile plugins use it to refuse the calls that modify state when the stores of
+	// the host chain must not be written to, e.g. on top of a `state.Overlay`.
+	ReadOnlyContainer interface {
+		vm.PrecompileContainer
+
+		// IsReadOnly returns whether the execution of the given input does not modify state.
+		IsReadOnly(input []byte) bool
+	}
 )
 
 type (
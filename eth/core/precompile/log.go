@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package precompile
+
+import (
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/types"
+)
+
+// NewLog returns the Ethereum log of the given ABI event, emitted by the contract at the given
+// address, with the given arguments. The arguments must be provided in the same order as the
+// event's inputs; indexed arguments are hashed into topics and the rest are packed as data.
+func NewLog(addr common.Address, event abi.Event, args ...any) (*types.Log, error) {
+	if len(args) != len(event.Inputs) {
+		return nil, ErrInvalidArgument
+	}
+
+	var indexed [][]any
+	var nonIndexed []any
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, []any{args[i]})
+		} else {
+			nonIndexed = append(nonIndexed, args[i])
+		}
+	}
+
+	var topics []common.Hash
+	if !event.Anonymous {
+		topics = append(topics, event.ID)
+	}
+	indexedTopics, err := abi.MakeTopics(indexed...)
+	if err != nil {
+		return nil, err
+	}
+	for _, topic := range indexedTopics {
+		topics = append(topics, topic[0])
+	}
+
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Log{
+		Address: addr,
+		Topics:  topics,
+		Data:    data,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package precompile_test
+
+import (
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/precompile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewLog", func() {
+	var (
+		addr   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		sender = common.HexToAddress("0x2000000000000000000000000000000000000001")
+		event  abi.Event
+	)
+
+	BeforeEach(func() {
+		addrType, _ := abi.NewType("address", "", nil)
+		uintType, _ := abi.NewType("uint256", "", nil)
+		event = abi.NewEvent("Sent", "Sent", false, abi.Arguments{
+			{Name: "sender", Type: addrType, Indexed: true},
+			{Name: "amount", Type: uintType},
+		})
+	})
+
+	It("should build the topics and data of the event", func() {
+		log, err := precompile.NewLog(addr, event, sender, big.NewInt(7))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(log.Address).To(Equal(addr))
+		Expect(log.Topics).To(Equal([]common.Hash{event.ID, common.BytesToHash(sender.Bytes())}))
+		Expect(log.Data).To(Equal(common.BigToHash(big.NewInt(7)).Bytes()))
+	})
+
+	It("should reject the wrong number of arguments", func() {
+		_, err := precompile.NewLog(addr, event, sender)
+		Expect(err).To(MatchError(precompile.ErrInvalidArgument))
+	})
+
+	It("should reject arguments of the wrong type", func() {
+		_, err := precompile.NewLog(addr, event, sender, "7")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -42,6 +42,9 @@ import (
  *          this field will be automatically populated.
  **/
 
+// wordSize is the size of an ABI word in bytes.
+const wordSize = 32
+
 // Executable is a type of function that stateful precompiled contract will implement. Each
 // Executable should directly correspond to an ABI method.
 type Executable func(
@@ -75,6 +78,16 @@ type Method struct {
 	// This field is optional; if left empty, the precompile's executable should consume gas using
 	// the native gas meter.
 	RequiredGas uint64
+
+	// RequiredGasFn returns the amount of gas used up by the execution of `Execute` for the given
+	// ABI encoded arguments (without the method ID). This field is optional; if set, it is used
+	// instead of `RequiredGas`.
+	RequiredGasFn func(args []byte) uint64
+
+	// ReturnGasFn returns the amount of gas that is charged after the execution of `Execute`,
+	// for the given ABI encoded return values. This field is optional; it should be set for
+	// methods whose cost is proportional to the size of their output.
+	ReturnGasFn func(ret []byte) uint64
 }
 
 // ValidateBasic returns an error if this a precompile `Method` has invalid fields.
@@ -87,6 +100,32 @@ func (m *Method) ValidateBasic() error {
 	return nil
 }
 
+// requiredGas returns the amount of gas used up by the execution of `Execute` for the given ABI
+// encoded arguments.
+func (m *Method) requiredGas(args []byte) uint64 {
+	if m.RequiredGasFn != nil {
+		return m.RequiredGasFn(args)
+	}
+	return m.RequiredGas
+}
+
+// returnGas returns the amount of gas that is charged after the execution of `Execute` for the
+// given ABI encoded return values.
+func (m *Method) returnGas(ret []byte) uint64 {
+	if m.ReturnGasFn == nil {
+		return 0
+	}
+	return m.ReturnGasFn(ret)
+}
+
+// GasPerWord returns a gas function, to be used as a `RequiredGasFn` or `ReturnGasFn`, that
+// charges the given amount of gas per 32-byte word of its input.
+func GasPerWord(gasPerWord uint64) func([]byte) uint64 {
+	return func(bz []byte) uint64 {
+		return gasPerWord * ((uint64(len(bz)) + wordSize - 1) / wordSize)
+	}
+}
+
 // Methods is a type that represents a list of precompile methods. This is what a stateful
 // precompiled contract implementation should expose.
 type Methods []*Method
@@ -34,6 +34,12 @@ import (
 // NumBytesMethodID is the number of bytes used to represent a ABI method's ID.
 const NumBytesMethodID = 4
 
+// Compile-time assertions that `stateful` is a `DynamicGasContainer` and a `ReadOnlyContainer`.
+var (
+	_ DynamicGasContainer = (*stateful)(nil)
+	_ ReadOnlyContainer   = (*stateful)(nil)
+)
+
 // stateful is a container for running stateful and dynamic precompiled contracts.
 type stateful struct {
 	// Registrable is the base precompile implementation.
@@ -131,5 +137,41 @@ func (sc *stateful) RequiredGas(input []byte) uint64 {
 		return 0
 	}
 
-	return method.RequiredGas
+	return method.requiredGas(input[NumBytesMethodID:])
+}
+
+// ReturnGas checks the Method corresponding to input for the amount of gas that is charged for
+// the given output, after the execution of the method.
+//
+// ReturnGas implements `DynamicGasContainer`.
+func (sc *stateful) ReturnGas(input, output []byte) uint64 {
+	if sc.idsToMethods == nil || len(input) < NumBytesMethodID {
+		return 0
+	}
+
+	// Extract the method ID from the input and load the method.
+	method, found := sc.idsToMethods[utils.UnsafeBytesToStr(input[:NumBytesMethodID])]
+	if !found {
+		return 0
+	}
+
+	return method.returnGas(output)
+}
+
+// IsReadOnly returns whether the Method corresponding to input is a view or pure method, which
+// does not modify state. Unknown methods are not read-only.
+//
+// IsReadOnly implements `ReadOnlyContainer`.
+func (sc *stateful) IsReadOnly(input []byte) bool {
+	if sc.idsToMethods == nil || len(input) < NumBytesMethodID {
+		return false
+	}
+
+	// Extract the method ID from the input and load the method.
+	method, found := sc.idsToMethods[utils.UnsafeBytesToStr(input[:NumBytesMethodID])]
+	if !found {
+		return false
+	}
+
+	return method.AbiMethod.IsConstant()
 }
@@ -72,6 +72,43 @@ var _ = Describe("Stateful Container", func() {
 		})
 	})
 
+	Describe("Test Dynamic Gas", func() {
+		var dynamic vm.PrecompileContainer
+		var input []byte
+
+		BeforeEach(func() {
+			dynamic = precompile.NewStateful(&mockStateful{&mockBase{}}, map[string]*precompile.Method{
+				utils.UnsafeBytesToStr(getOutputABI.ID): {
+					AbiSig:        getOutputABI.Sig,
+					AbiMethod:     &getOutputABI,
+					Execute:       getOutput,
+					RequiredGas:   1,
+					RequiredGasFn: precompile.GasPerWord(2),
+					ReturnGasFn:   precompile.GasPerWord(3),
+				},
+			})
+			inputs, err := getOutputABI.Inputs.Pack("string")
+			Expect(err).ToNot(HaveOccurred())
+			input = append(getOutputABI.ID, inputs...)
+		})
+
+		It("should use the required gas function over the static required gas", func() {
+			// The string argument is encoded as an offset, a length and a single data word.
+			Expect(dynamic.RequiredGas(input)).To(Equal(uint64(6)))
+		})
+
+		It("should charge gas based on the output", func() {
+			ret, err := dynamic.Run(ctx, nil, input, addr, value, readonly)
+			Expect(err).ToNot(HaveOccurred())
+			dg := utils.MustGetAs[precompile.DynamicGasContainer](dynamic)
+			Expect(dg.ReturnGas(input, ret)).To(Equal(uint64(3 * len(ret) / 32)))
+
+			// Methods without a return gas function do not charge gas after execution.
+			static := utils.MustGetAs[precompile.DynamicGasContainer](sc)
+			Expect(static.ReturnGas(input, ret)).To(Equal(uint64(0)))
+		})
+	})
+
 	Describe("Test Run", func() {
 		It("should return an error for invalid cases", func() {
 			// empty input
@@ -23,10 +23,12 @@ package core
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/trie"
 
+	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core/precompile"
 	"pkg.berachain.dev/polaris/eth/core/types"
 	"pkg.berachain.dev/polaris/eth/core/vm"
@@ -55,6 +57,9 @@ type StateProcessor struct {
 	// signer is the signer used to verify transaction signatures. We need this in order to to
 	// extract the underlying message from a transaction object in `ProcessTransaction`.
 	signer types.Signer
+	// baseFeeCollector is the address that receives the base fee portion of transaction fees for
+	// the current block. If nil, the base fee is burned.
+	baseFeeCollector *common.Address
 
 	// evm is the EVM that is used to process transactions. We re-use a single EVM for processing
 	// the entire block. This is done in order to reduce memory allocs.
@@ -63,6 +68,9 @@ type StateProcessor struct {
 	statedb vm.PolarisStateDB
 	// vmConfig is the configuration for the EVM.
 	vmConfig *vm.Config
+	// pe is the OPTIONAL executor that speculatively executes the transactions of the block in
+	// parallel. If nil, transactions are only executed sequentially.
+	pe *parallelExecutor
 
 	// We store information about the current block being processed so that we can access it
 	// during the processing of transactions. This allows us to utilize this information to
@@ -131,6 +139,7 @@ func (sp *StateProcessor) Prepare(ctx context.Context, evm *vm.GethEVM, header *
 	// we should check every block.
 	sp.BuildAndRegisterPrecompiles(precompile.GetDefaultPrecompiles(&rules))
 	sp.vmConfig.ExtraEips = sp.cp.ExtraEips()
+	sp.baseFeeCollector = sp.cp.BaseFeeCollector()
 	sp.evm = evm
 }
 
@@ -155,10 +164,21 @@ func (sp *StateProcessor) ProcessTransaction(
 	// fully reverted, when it fact it should've been a vm error saying out of gas.
 	gasPool := GasPool(sp.gp.BlockGasLimit() - sp.gp.BlockGasConsumed())
 
-	// Apply the state transition.
-	result, err := ApplyMessage(sp.evm, msg, &gasPool)
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not apply message %d [%s]", len(sp.txs), txHash.Hex())
+	// Apply the state transition, using the speculative execution of the transaction if it does
+	// not conflict with the transactions before it.
+	var result *ExecutionResult
+	var logs []*types.Log
+	spec := sp.applySpeculativeResult(txHash, msg.GasLimit, gasPool)
+	if spec != nil {
+		result, logs = spec.result, spec.logs
+	} else {
+		result, err = ApplyMessage(sp.evm, msg, &gasPool)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err, "could not apply message %d [%s]", len(sp.txs), txHash.Hex(),
+			)
+		}
+		logs = sp.statedb.Logs()
 	}
 
 	// If we used more gas than we had remaining on the gas plugin, we treat it as an out of gas error,
@@ -174,13 +194,27 @@ func (sp *StateProcessor) ProcessTransaction(
 		return nil, errors.Wrapf(err, "could not consume gas used %d [%s]", len(sp.txs), txHash.Hex())
 	}
 
+	// The state transition burns the base fee portion of the transaction fee, so if the host chain
+	// has configured a base fee collector, we credit it with the base fee instead.
+	sp.creditBaseFee(result.UsedGas)
+
+	// Keep track of the state written by the transaction, to detect the speculative executions
+	// of the next transactions that conflict with it.
+	if sp.pe != nil {
+		credited := []common.Address{sp.header.Coinbase}
+		if sp.baseFeeCollector != nil {
+			credited = append(credited, *sp.baseFeeCollector)
+		}
+		sp.pe.recordWrites(spec, sp.statedb, sp.pp.Has, credited...)
+	}
+
 	// Create a new receipt for the transaction.
 	receipt := &types.Receipt{
 		Type:              tx.Type(),
 		CumulativeGasUsed: sp.gp.BlockGasConsumed() + sp.gp.GasConsumed(),
 		TxHash:            txHash,
 		GasUsed:           result.UsedGas,
-		Logs:              sp.statedb.Logs(),
+		Logs:              logs,
 	}
 
 	// If the transaction created a contract, store the creation address in the receipt.
@@ -217,6 +251,33 @@ func (sp *StateProcessor) Finalize(
 	// We unlock the state processor to ensure that the state is consistent.
 	defer sp.mtx.Unlock()
 
+	sp.stopSpeculation()
+	block, logs := sp.buildBlock()
+
+	// We return a new block with the updated header and the receipts to the `blockchain`.
+	return block, sp.receipts, logs, nil
+}
+
+// Discard discards the block that is currently being processed, without finalizing it, and
+// returns the block that was built so far. If no block is being processed, nil is returned. The
+// host chain is responsible for discarding the state changes of the block.
+func (sp *StateProcessor) Discard(_ context.Context) *types.Block {
+	if sp.header == nil {
+		return nil
+	}
+
+	// We unlock the state processor so that a new block can be prepared.
+	defer sp.mtx.Unlock()
+
+	sp.stopSpeculation()
+	block, _ := sp.buildBlock()
+	sp.txs, sp.receipts = nil, nil
+	return block
+}
+
+// buildBlock builds the block from the current header, txs and receipts and resets the header for
+// the next block. It returns the block along with the logs of the block.
+func (sp *StateProcessor) buildBlock() (*types.Block, []*types.Log) {
 	// Now that we are done processing the block, we update the header with the consumed gas.
 	sp.header.GasUsed = sp.gp.BlockGasConsumed()
 
@@ -246,14 +307,80 @@ func (sp *StateProcessor) Finalize(
 		receipt.TransactionIndex = uint(txIndex)
 	}
 
-	// We return a new block with the updated header and the receipts to the `blockchain`.
-	return block, sp.receipts, logs, nil
+	return block, logs
+}
+
+// ==============================================================================
+// Parallel Execution
+// ==============================================================================
+
+// EnableParallelExecution enables the speculative execution of transactions with the given number
+// of workers. The speculative executions are replayed on the given state plugin, which must be the
+// plugin of the processor's statedb.
+func (sp *StateProcessor) EnableParallelExecution(plugin StatePlugin, workers int) {
+	sp.pe = newParallelExecutor(plugin, workers)
+}
+
+// SpeculateTransactions starts the speculative execution of the given transactions, which are
+// expected to be processed next in the block that is being processed. It is a no-op if parallel
+// execution is not enabled.
+func (sp *StateProcessor) SpeculateTransactions(_ context.Context, txs types.Transactions) error {
+	if sp.pe == nil || sp.header == nil {
+		return nil
+	}
+	sp.pe.stop()
+	return sp.pe.speculate(sp, txs)
+}
+
+// applySpeculativeResult replays the speculative execution of the given transaction on the state
+// plugin and returns it. If there is no speculative execution for the transaction, or it conflicts
+// with the transactions before it, the replay is reverted and nil is returned.
+func (sp *StateProcessor) applySpeculativeResult(
+	txHash common.Hash, gasLimit uint64, gasPool GasPool,
+) *speculativeResult {
+	if sp.pe == nil {
+		return nil
+	}
+	// The speculative execution ran with the entire block gas limit, so it is only valid if the
+	// remaining gas in the block is sufficient for the transaction.
+	spec := sp.pe.result(txHash)
+	if spec == nil || uint64(gasPool) < gasLimit || sp.pe.conflicts(spec) {
+		return nil
+	}
+
+	snapshot := sp.statedb.Snapshot()
+	if err := spec.overlay.Replay(sp.pe.sp); err != nil {
+		sp.statedb.RevertToSnapshot(snapshot)
+		return nil
+	}
+	for _, log := range spec.logs {
+		log.TxIndex = uint(len(sp.txs))
+	}
+	return spec
+}
+
+// stopSpeculation stops the speculative executions of the block, if any.
+func (sp *StateProcessor) stopSpeculation() {
+	if sp.pe != nil {
+		sp.pe.reset()
+	}
 }
 
 // ===========================================================================
 // Utilities
 // ===========================================================================
 
+// creditBaseFee credits the base fee collector of the host chain, if any, with the base fee
+// portion of the fee of a transaction that used the given amount of gas.
+func (sp *StateProcessor) creditBaseFee(usedGas uint64) {
+	if sp.baseFeeCollector != nil && sp.header.BaseFee != nil {
+		sp.statedb.AddBalance(
+			*sp.baseFeeCollector,
+			new(big.Int).Mul(new(big.Int).SetUint64(usedGas), sp.header.BaseFee),
+		)
+	}
+}
+
 // BuildPrecompiles builds the given precompiles and registers them with the precompile plugins.
 func (sp *StateProcessor) BuildAndRegisterPrecompiles(precompiles []precompile.Registrable) {
 	for _, pc := range precompiles {
@@ -121,6 +121,18 @@ var _ = Describe("StateProcessor", func() {
 			Expect(receipts).To(BeEmpty())
 			Expect(logs).To(BeEmpty())
 		})
+
+		It("should discard the block being processed", func() {
+			block := sp.Discard(context.Background())
+			Expect(block).ToNot(BeNil())
+			Expect(block.NumberU64()).To(Equal(dummyHeader.Number.Uint64()))
+
+			// there is no block left to discard and a new block can be prepared.
+			Expect(sp.Discard(context.Background())).To(BeNil())
+			sp.Prepare(context.Background(), nil, dummyHeader)
+			_, _, _, err := sp.Finalize(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+		})
 	})
 
 	Context("Block with transactions", func() {
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package core
+
+import "context"
+
+// Compile-time check to ensure that `replayGasPlugin` implements `GasPlugin`.
+var _ GasPlugin = (*replayGasPlugin)(nil)
+
+// replayGasPlugin is an in-memory `GasPlugin` that is used when re-executing the transactions of
+// an already finalized block (i.e. for tracing) or building the pending block. It ensures that
+// replaying transactions never touches the gas meters of the host chain.
+type replayGasPlugin struct {
+	blockGasLimit    uint64
+	blockGasConsumed uint64
+	gasConsumed      uint64
+}
+
+// newReplayGasPlugin returns a new `replayGasPlugin` with the given block gas limit.
+func newReplayGasPlugin(blockGasLimit uint64) *replayGasPlugin {
+	return &replayGasPlugin{
+		blockGasLimit: blockGasLimit,
+	}
+}
+
+// Prepare implements `GasPlugin`.
+func (gp *replayGasPlugin) Prepare(context.Context) {
+	gp.blockGasConsumed = 0
+	gp.gasConsumed = 0
+}
+
+// Reset implements `GasPlugin`. The gas consumed by the previous transaction is added to the
+// cumulative gas consumed by the block.
+func (gp *replayGasPlugin) Reset(context.Context) {
+	gp.blockGasConsumed += gp.gasConsumed
+	gp.gasConsumed = 0
+}
+
+// ConsumeGas implements `GasPlugin`.
+func (gp *replayGasPlugin) ConsumeGas(amount uint64) error {
+	if amount > gp.GasRemaining() {
+		return ErrBlockOutOfGas
+	}
+	gp.gasConsumed += amount
+	return nil
+}
+
+// GasRemaining implements `GasPlugin`.
+func (gp *replayGasPlugin) GasRemaining() uint64 {
+	return gp.blockGasLimit - gp.blockGasConsumed - gp.gasConsumed
+}
+
+// GasConsumed implements `GasPlugin`.
+func (gp *replayGasPlugin) GasConsumed() uint64 {
+	return gp.gasConsumed
+}
+
+// BlockGasConsumed implements `GasPlugin`.
+func (gp *replayGasPlugin) BlockGasConsumed() uint64 {
+	return gp.blockGasConsumed
+}
+
+// BlockGasLimit implements `GasPlugin`.
+func (gp *replayGasPlugin) BlockGasLimit() uint64 {
+	return gp.blockGasLimit
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package state
+
+import "pkg.berachain.dev/polaris/eth/common"
+
+// AccessSet is a set of accounts and of storage slots. It is used to detect the transactions of a
+// block that conflict with each other, which is why the accounts only stand for the fields that
+// transactions commonly change: the existence, balance and nonce of an account.
+type AccessSet struct {
+	accounts map[common.Address]struct{}
+	slots    map[common.Address]map[common.Hash]struct{}
+}
+
+// NewAccessSet returns a new, empty, access set.
+func NewAccessSet() *AccessSet {
+	return &AccessSet{
+		accounts: make(map[common.Address]struct{}),
+		slots:    make(map[common.Address]map[common.Hash]struct{}),
+	}
+}
+
+// AddAccount adds the account with the given address to the set.
+func (s *AccessSet) AddAccount(addr common.Address) {
+	s.accounts[addr] = struct{}{}
+}
+
+// AddSlot adds the given storage slot of the given account to the set.
+func (s *AccessSet) AddSlot(addr common.Address, slot common.Hash) {
+	slots, ok := s.slots[addr]
+	if !ok {
+		slots = make(map[common.Hash]struct{})
+		s.slots[addr] = slots
+	}
+	slots[slot] = struct{}{}
+}
+
+// Merge adds the accounts and storage slots of the given set to the set.
+func (s *AccessSet) Merge(other *AccessSet) {
+	for addr := range other.accounts {
+		s.AddAccount(addr)
+	}
+	for addr, slots := range other.slots {
+		for slot := range slots {
+			s.AddSlot(addr, slot)
+		}
+	}
+}
+
+// Intersects returns whether the set shares an account or a storage slot with the given set.
+func (s *AccessSet) Intersects(other *AccessSet) bool {
+	// iterate over the smaller of the sets.
+	if len(s.accounts)+len(s.slots) > len(other.accounts)+len(other.slots) {
+		s, other = other, s
+	}
+	for addr := range s.accounts {
+		if _, ok := other.accounts[addr]; ok {
+			return true
+		}
+	}
+	for addr, slots := range s.slots {
+		otherSlots, ok := other.slots[addr]
+		if !ok {
+			continue
+		}
+		for slot := range slots {
+			if _, ok = otherSlots[slot]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Len returns the number of accounts and storage slots in the set.
+func (s *AccessSet) Len() int {
+	n := len(s.accounts)
+	for _, slots := range s.slots {
+		n += len(slots)
+	}
+	return n
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"pkg.berachain.dev/polaris/eth/common"
+)
+
+// =============================================================================
+// Dump
+// =============================================================================
+
+// Polaris does not store accounts in a secure trie, so unlike Go-Ethereum, accounts are dumped in
+// ascending order of their address, and the `Start` and `Next` keys of a dump are addresses.
+
+// DumpToCollector iterates over the accounts of the state, in ascending order of address, and
+// sends them to the given collector. It returns the address of the next account if the dump was
+// interrupted by `conf.Max`. If the plugin is not iterable, no accounts are dumped.
+//
+// DumpToCollector implements the `StateDB` interface.
+func (sdb *stateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) []byte {
+	if conf == nil {
+		conf = new(DumpConfig)
+	}
+	c.OnRoot(common.Hash{})
+
+	ip, ok := sdb.Plugin.(IterablePlugin)
+	if !ok {
+		return nil
+	}
+
+	// Select the page of accounts to dump.
+	var next []byte
+	addrs := dumpAddresses(ip, conf.Start, conf.Max)
+	if conf.Max > 0 && uint64(len(addrs)) > conf.Max {
+		next = addrs[conf.Max].Bytes()
+		addrs = addrs[:conf.Max]
+	}
+
+	for _, addr := range addrs {
+		account := DumpAccount{
+			Balance:  sdb.GetBalance(addr).String(),
+			Nonce:    sdb.GetNonce(addr),
+			CodeHash: sdb.GetCodeHash(addr).Bytes(),
+		}
+		if !conf.SkipCode {
+			account.Code = sdb.GetCode(addr)
+		}
+		if !conf.SkipStorage {
+			storage := make(map[common.Hash]string)
+			if err := sdb.ForEachStorage(addr, func(key, value common.Hash) bool {
+				storage[key] = common.Bytes2Hex(common.TrimLeftZeroes(value[:]))
+				return true
+			}); err == nil {
+				account.Storage = storage
+			}
+		}
+		c.OnAccount(addr, account)
+	}
+	return next
+}
+
+// RawDump returns the accounts of the state.
+//
+// RawDump implements the `StateDB` interface.
+func (sdb *stateDB) RawDump(conf *DumpConfig) Dump {
+	dump := &Dump{Accounts: make(map[common.Address]DumpAccount)}
+	sdb.DumpToCollector(dump, conf)
+	return *dump
+}
+
+// Dump returns a JSON string representing the accounts of the state.
+//
+// Dump implements the `StateDB` interface.
+func (sdb *stateDB) Dump(conf *DumpConfig) []byte {
+	bz, err := json.MarshalIndent(sdb.RawDump(conf), "", "    ")
+	if err != nil {
+		return nil
+	}
+	return bz
+}
+
+// IteratorDump returns a page of the accounts of the state, along with the address of the account
+// that starts the next page.
+//
+// IteratorDump implements the `StateDB` interface.
+func (sdb *stateDB) IteratorDump(conf *DumpConfig) IteratorDump {
+	iterator := &IteratorDump{Accounts: make(map[common.Address]DumpAccount)}
+	iterator.Next = sdb.DumpToCollector(iterator, conf)
+	return *iterator
+}
+
+// dumpAddresses returns the addresses of the accounts, contracts and storage owners of the state
+// that are greater or equal than the given start address, in ascending order. If the given limit
+// is not zero, at most limit+1 addresses are returned, and the iterations over the state stop as
+// soon as they have found as many addresses.
+func dumpAddresses(ip IterablePlugin, start []byte, limit uint64) []common.Address {
+	// Each iteration returns the addresses in ascending order, so they are collected in sorted
+	// lists without duplicates, which are merged.
+	var lists [3][]common.Address
+	collect := func(i int, addr common.Address) bool {
+		if l := lists[i]; len(l) == 0 || l[len(l)-1] != addr {
+			lists[i] = append(l, addr)
+		}
+		return limit > 0 && uint64(len(lists[i])) > limit
+	}
+
+	from := common.BytesToAddress(start)
+	ip.IterateAccounts(from, func(addr common.Address) bool {
+		return collect(0, addr)
+	})
+	ip.IterateCode(from, func(addr common.Address, _ []byte) bool {
+		return collect(1, addr)
+	})
+	ip.IterateState(from, func(addr common.Address, _, _ common.Hash) bool {
+		return collect(2, addr)
+	})
+
+	addrs := mergeAddresses(mergeAddresses(lists[0], lists[1]), lists[2])
+	if limit > 0 && uint64(len(addrs)) > limit+1 {
+		addrs = addrs[:limit+1]
+	}
+	return addrs
+}
+
+// mergeAddresses merges the given sorted lists of addresses into a sorted list without
+// duplicates.
+func mergeAddresses(a, b []common.Address) []common.Address {
+	merged := make([]common.Address, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		var cmp int
+		switch {
+		case len(a) == 0:
+			cmp = 1
+		case len(b) == 0:
+			cmp = -1
+		default:
+			cmp = bytes.Compare(a[0].Bytes(), b[0].Bytes())
+		}
+		switch {
+		case cmp < 0:
+			merged, a = append(merged, a[0]), a[1:]
+		case cmp > 0:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged, a, b = append(merged, a[0]), a[1:], b[1:]
+		}
+	}
+	return merged
+}
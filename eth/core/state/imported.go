@@ -24,6 +24,7 @@ import "github.com/ethereum/go-ethereum/core/state"
 
 type (
 	Dump          = state.Dump
+	DumpAccount   = state.DumpAccount
 	DumpCollector = state.DumpCollector
 	DumpConfig    = state.DumpConfig
 	IteratorDump  = state.IteratorDump
@@ -86,6 +86,36 @@ type Plugin interface {
 	ForEachStorage(common.Address, func(common.Hash, common.Hash) bool) error
 }
 
+// IterablePlugin is an optional extension of the `Plugin` for host chains that are able to iterate
+// over their state, which is used to dump the state. The accounts are iterated in ascending order
+// of address, starting at the given address.
+type IterablePlugin interface {
+	// IterateAccounts iterates over the addresses of the accounts and calls the given callback
+	// function, until it returns true.
+	IterateAccounts(common.Address, func(common.Address) bool)
+	// IterateState iterates over the storage of the accounts and calls the given callback
+	// function, until it returns true.
+	IterateState(common.Address, func(common.Address, common.Hash, common.Hash) bool)
+	// IterateCode iterates over the code of the accounts and calls the given callback function,
+	// until it returns true.
+	IterateCode(common.Address, func(common.Address, []byte) bool)
+}
+
+// ProofPlugin is an optional extension of the `Plugin` for host chains that are able to prove their
+// state to light clients, which is used to serve `eth_getProof`. The format of the proofs, and
+// the header whose root they are verified against, are defined by the host chain.
+type ProofPlugin interface {
+	// GetProof returns the proofs of the account (balance, nonce and code hash) of the given
+	// address, which are verified against the root returned by `GetProofRoot`.
+	GetProof(common.Address) ([][]byte, error)
+	// GetStorageProof returns the proof of the given storage slot of the given address, which is
+	// verified against the root returned by `GetProofRoot`.
+	GetStorageProof(common.Address, common.Hash) ([][]byte, error)
+	// GetProofRoot returns the commitment of the host chain to its state (e.g. the app hash),
+	// which all of the proofs are verified against.
+	GetProofRoot() (common.Hash, error)
+}
+
 type (
 	// LogsJournal defines the interface for tracking logs created during a state transition.
 	LogsJournal interface {
@@ -126,6 +156,10 @@ type (
 		SlotInAccessList(common.Address, common.Hash) (addressPresent bool, slotPresent bool)
 		// `AddressInAccessList` returns whether the given address is in the access list.
 		AddressInAccessList(common.Address) bool
+		// `ForEachTouched` calls the given callback with every address, and the slots of the
+		// address, that were added to the access list since the last `Finalize`, including the
+		// ones that were reverted.
+		ForEachTouched(func(common.Address, []common.Hash))
 	}
 
 	SuicidesJournal interface {
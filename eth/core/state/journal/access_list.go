@@ -29,6 +29,9 @@ import (
 type accessList struct {
 	*AccessList                       // current access list, always the head of journal stack.
 	journal     ds.Stack[*AccessList] // journal of access lists.
+	// touched holds every address, and the slots of each address, that were added to the access
+	// list since the last `Finalize`, including the ones that were reverted.
+	touched map[common.Address][]common.Hash
 }
 
 // NewAccesslist returns a new `accessList` journal.
@@ -40,6 +43,7 @@ func NewAccesslist() *accessList {
 	return &accessList{
 		AccessList: journal.Peek(),
 		journal:    journal,
+		touched:    make(map[common.Address][]common.Hash),
 	}
 }
 
@@ -50,12 +54,18 @@ func (al *accessList) RegistryKey() string {
 
 // AddAddressToAccessList implements `state.AccessListJournal`.
 func (al *accessList) AddAddressToAccessList(addr common.Address) {
-	al.AddAddress(addr)
+	if al.AddAddress(addr) {
+		if _, ok := al.touched[addr]; !ok {
+			al.touched[addr] = nil
+		}
+	}
 }
 
 // AddSlotToAccessList implements `state.AccessListJournal`.
 func (al *accessList) AddSlotToAccessList(addr common.Address, slot common.Hash) {
-	al.AddSlot(addr, slot)
+	if _, slotAdded := al.AddSlot(addr, slot); slotAdded {
+		al.touched[addr] = append(al.touched[addr], slot)
+	}
 }
 
 // AddressInAccessList implements `state.AccessListJournal`.
@@ -68,6 +78,13 @@ func (al *accessList) SlotInAccessList(addr common.Address, slot common.Hash) (b
 	return al.Contains(addr, slot)
 }
 
+// ForEachTouched implements `state.AccessListJournal`.
+func (al *accessList) ForEachTouched(cb func(common.Address, []common.Hash)) {
+	for addr, slots := range al.touched {
+		cb(addr, slots)
+	}
+}
+
 // `Snapshot` implements `libtypes.Snapshottable`.
 func (al *accessList) Snapshot() int {
 	al.AccessList = al.AccessList.Copy()
@@ -86,4 +103,5 @@ func (al *accessList) Finalize() {
 	al.journal = stack.New[*AccessList](initCapacity)
 	al.journal.Push(NewAccessList())
 	al.AccessList = al.journal.Peek()
+	al.touched = make(map[common.Address][]common.Hash)
 }
@@ -71,4 +71,22 @@ var _ = Describe("AccessList", func() {
 		Expect(func() { al.Finalize() }).ToNot(Panic())
 		Expect(al.journal.Size()).To(Equal(1))
 	})
+
+	It("should keep track of the touched addresses and slots until finalized", func() {
+		al.AddAddressToAccessList(a1)
+		id := al.Snapshot()
+		al.AddSlotToAccessList(a2, s1)
+		al.RevertToSnapshot(id)
+
+		touched := make(map[common.Address][]common.Hash)
+		al.ForEachTouched(func(addr common.Address, slots []common.Hash) {
+			touched[addr] = slots
+		})
+		Expect(touched).To(HaveLen(2))
+		Expect(touched[a1]).To(BeEmpty())
+		Expect(touched[a2]).To(Equal([]common.Hash{s1}))
+
+		al.Finalize()
+		al.ForEachTouched(func(common.Address, []common.Hash) { Fail("touched after finalize") })
+	})
 })
@@ -0,0 +1,723 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/crypto"
+)
+
+var (
+	// ErrReplayMismatch is returned by `Overlay.Replay` when a read of the plugin that the calls
+	// are replayed on does not return the same result as it did on the overlay.
+	ErrReplayMismatch = errors.New("replayed read does not match the overlay")
+
+	// ErrOverlayNotRecorded is returned by `Overlay.Replay` when the overlay does not record the
+	// calls that are made to it.
+	ErrOverlayNotRecorded = errors.New("overlay does not record its calls")
+
+	// ErrProofsNotSupported is returned by the proofs of an `Overlay` whose base plugin is not a
+	// `ProofPlugin`.
+	ErrProofsNotSupported = errors.New("base plugin does not support proofs")
+
+	// emptyCodeHash is the Keccak256 Hash of empty code.
+	emptyCodeHash = crypto.Keccak256Hash(nil)
+)
+
+// Compile-time assertions that `Overlay` is a `Plugin`, and forwards the optional extensions of
+// the `Plugin` to its base plugin.
+var (
+	_ Plugin         = (*Overlay)(nil)
+	_ IterablePlugin = (*Overlay)(nil)
+	_ ProofPlugin    = (*Overlay)(nil)
+)
+
+// Overlay is an in-memory `Plugin` on top of a base plugin, which is never written to. Writes are
+// buffered in memory and reads that are not served by the buffered writes are forwarded to the
+// base plugin. Unless it is created with `NewQueryOverlay`, the overlay records every call that is
+// made to it, in order, so that the calls can later be replayed on another plugin with `Replay`.
+type Overlay struct {
+	// base is the plugin that reads are forwarded to.
+	base Plugin
+	// accounts are the accounts that have been read or written through the overlay.
+	accounts map[common.Address]*overlayAccount
+
+	// undo is the journal of the changes to the accounts, used to revert to a snapshot.
+	undo []func()
+	// revisions holds the size of the undo journal at each snapshot.
+	revisions []int
+
+	// recording is set if the calls that are made to the overlay are recorded.
+	recording bool
+	// calls are the calls that were made to the overlay.
+	calls []overlayCall
+}
+
+// overlayAccount is an account in the overlay. Fields that are not known are read from the base
+// plugin.
+type overlayAccount struct {
+	fields accountFields
+
+	// storage holds the known storage slots, the base storage is hidden if cleared is set.
+	storage map[common.Hash]common.Hash
+	cleared bool
+	// committed holds the known committed storage slots, as of the last `Finalize`.
+	committed        map[common.Hash]common.Hash
+	committedCleared bool
+}
+
+// accountFields are the non-storage fields of an account in the overlay.
+type accountFields struct {
+	// dirty is set once the account is written to.
+	dirty bool
+
+	exists      bool
+	existsKnown bool
+	// balance is nil if unknown, in which case delta holds the balance changes made since.
+	balance *big.Int
+	delta   *big.Int
+	nonce   uint64
+	// nonceKnown is set once the nonce is read or written.
+	nonceKnown    bool
+	code          []byte
+	codeKnown     bool
+	codeHash      common.Hash
+	codeHashKnown bool
+}
+
+// NewOverlay returns a new, empty, overlay on top of the given base plugin.
+func NewOverlay(base Plugin) *Overlay {
+	return &Overlay{
+		base:      base,
+		accounts:  make(map[common.Address]*overlayAccount),
+		recording: true,
+	}
+}
+
+// NewQueryOverlay returns a new, empty, overlay on top of the given base plugin that does not
+// record its calls, and so cannot be replayed. It is used to isolate the state changes of queries,
+// such as `eth_call` with state overrides, from the stores of the base plugin.
+func NewQueryOverlay(base Plugin) *Overlay {
+	return &Overlay{
+		base:     base,
+		accounts: make(map[common.Address]*overlayAccount),
+	}
+}
+
+// record records the given call, if the overlay is recording.
+func (o *Overlay) record(c overlayCall) {
+	if o.recording {
+		o.calls = append(o.calls, c)
+	}
+}
+
+// =============================================================================
+// Controllable
+// =============================================================================
+
+// RegistryKey implements `libtypes.Registrable`.
+func (o *Overlay) RegistryKey() string {
+	return o.base.RegistryKey()
+}
+
+// Snapshot implements `libtypes.Snapshottable`.
+func (o *Overlay) Snapshot() int {
+	o.revisions = append(o.revisions, len(o.undo))
+	id := len(o.revisions) - 1
+	o.record(overlayCall{kind: callSnapshot, id: id})
+	return id
+}
+
+// RevertToSnapshot implements `libtypes.Snapshottable`.
+func (o *Overlay) RevertToSnapshot(id int) {
+	size := o.revisions[id]
+	for i := len(o.undo) - 1; i >= size; i-- {
+		o.undo[i]()
+	}
+	o.undo = o.undo[:size]
+	o.revisions = o.revisions[:id]
+	o.record(overlayCall{kind: callRevertToSnapshot, id: id})
+}
+
+// Finalize commits the storage of the accounts, which is then returned by `GetCommittedState`,
+// and clears the snapshots.
+//
+// Finalize implements `libtypes.Controllable`.
+func (o *Overlay) Finalize() {
+	for _, acct := range o.accounts {
+		acct.committed = make(map[common.Hash]common.Hash, len(acct.storage))
+		for key, value := range acct.storage {
+			acct.committed[key] = value
+		}
+		acct.committedCleared = acct.cleared
+	}
+	o.undo = o.undo[:0]
+	o.revisions = o.revisions[:0]
+}
+
+// Prepare is a no-op, as the base plugin is never written to.
+//
+// Prepare implements `libtypes.Preparable`.
+func (o *Overlay) Prepare(context.Context) {}
+
+// Reset is a no-op, as the base plugin is never written to.
+//
+// Reset implements `libtypes.Resettable`.
+func (o *Overlay) Reset(context.Context) {}
+
+// overlayContextKey is the key of the value that marks the context of an overlay.
+type overlayContextKey struct{}
+
+// GetContext returns the context of the base plugin, marked as the context of an overlay. The
+// stateful precompiles of the host chain write to its stores through this context, bypassing the
+// overlay, so precompile plugins must check `IsOverlayContext` and refuse to run the precompile
+// methods that modify state.
+func (o *Overlay) GetContext() context.Context {
+	return context.WithValue(o.base.GetContext(), overlayContextKey{}, true)
+}
+
+// IsOverlayContext returns whether the given context is the context of an `Overlay`.
+func IsOverlayContext(ctx context.Context) bool {
+	isOverlay, _ := ctx.Value(overlayContextKey{}).(bool)
+	return isOverlay
+}
+
+// =============================================================================
+// Accounts
+// =============================================================================
+
+// CreateAccount implements `Plugin`.
+func (o *Overlay) CreateAccount(addr common.Address) {
+	o.record(overlayCall{kind: callCreateAccount, addr: addr})
+	acct := o.write(addr)
+	acct.fields.exists, acct.fields.existsKnown = true, true
+}
+
+// Exist implements `Plugin`.
+func (o *Overlay) Exist(addr common.Address) bool {
+	acct := o.account(addr)
+	if !acct.fields.existsKnown {
+		acct.fields.exists, acct.fields.existsKnown = o.base.Exist(addr), true
+	}
+	o.record(overlayCall{kind: callExist, addr: addr, ok: acct.fields.exists})
+	return acct.fields.exists
+}
+
+// Empty implements `Plugin`.
+func (o *Overlay) Empty(addr common.Address) bool {
+	var empty bool
+	if acct := o.account(addr); !acct.fields.dirty {
+		empty = o.base.Empty(addr)
+	} else {
+		codeHash := o.getCodeHash(addr)
+		empty = o.getBalance(addr).Sign() == 0 && o.getNonce(addr) == 0 &&
+			(codeHash == common.Hash{} || codeHash == emptyCodeHash)
+	}
+	o.record(overlayCall{kind: callEmpty, addr: addr, ok: empty})
+	return empty
+}
+
+// DeleteAccounts implements `Plugin`.
+func (o *Overlay) DeleteAccounts(addrs []common.Address) {
+	o.record(overlayCall{
+		kind: callDeleteAccounts, addrs: append([]common.Address(nil), addrs...),
+	})
+	for _, addr := range addrs {
+		acct := o.write(addr)
+		acct.fields = accountFields{
+			dirty:         true,
+			existsKnown:   true,
+			balance:       new(big.Int),
+			nonceKnown:    true,
+			codeKnown:     true,
+			codeHashKnown: true,
+		}
+		o.replaceStorage(acct, make(map[common.Hash]common.Hash))
+	}
+}
+
+// =============================================================================
+// Balance
+// =============================================================================
+
+// GetBalance implements `Plugin`.
+func (o *Overlay) GetBalance(addr common.Address) *big.Int {
+	balance := o.getBalance(addr)
+	o.record(overlayCall{kind: callGetBalance, addr: addr, amount: balance})
+	return new(big.Int).Set(balance)
+}
+
+// SetBalance implements `Plugin`.
+func (o *Overlay) SetBalance(addr common.Address, amount *big.Int) {
+	amount = new(big.Int).Set(amount)
+	o.record(overlayCall{kind: callSetBalance, addr: addr, amount: amount})
+	acct := o.write(addr)
+	acct.fields.balance, acct.fields.delta = amount, nil
+}
+
+// AddBalance implements `Plugin`.
+func (o *Overlay) AddBalance(addr common.Address, amount *big.Int) {
+	amount = new(big.Int).Set(amount)
+	o.record(overlayCall{kind: callAddBalance, addr: addr, amount: amount})
+	o.addBalance(o.write(addr), amount)
+}
+
+// SubBalance implements `Plugin`.
+func (o *Overlay) SubBalance(addr common.Address, amount *big.Int) {
+	amount = new(big.Int).Set(amount)
+	o.record(overlayCall{kind: callSubBalance, addr: addr, amount: amount})
+	o.addBalance(o.write(addr), new(big.Int).Neg(amount))
+}
+
+// addBalance adds the given amount to the balance of the account. If the balance is not known,
+// the amount is added to the balance delta instead, so that the base plugin is not read.
+func (o *Overlay) addBalance(acct *overlayAccount, amount *big.Int) {
+	if acct.fields.balance != nil {
+		acct.fields.balance = new(big.Int).Add(acct.fields.balance, amount)
+		return
+	}
+	if acct.fields.delta == nil {
+		acct.fields.delta = new(big.Int)
+	}
+	acct.fields.delta = new(big.Int).Add(acct.fields.delta, amount)
+}
+
+// getBalance returns the balance of the given account, which is read from the base plugin if it is
+// not known yet. The returned balance must not be modified.
+func (o *Overlay) getBalance(addr common.Address) *big.Int {
+	acct := o.account(addr)
+	if acct.fields.balance == nil {
+		balance := new(big.Int).Set(o.base.GetBalance(addr))
+		if acct.fields.delta != nil {
+			balance.Add(balance, acct.fields.delta)
+		}
+		acct.fields.balance, acct.fields.delta = balance, nil
+	}
+	return acct.fields.balance
+}
+
+// =============================================================================
+// Nonce
+// =============================================================================
+
+// GetNonce implements `Plugin`.
+func (o *Overlay) GetNonce(addr common.Address) uint64 {
+	nonce := o.getNonce(addr)
+	o.record(overlayCall{kind: callGetNonce, addr: addr, nonce: nonce})
+	return nonce
+}
+
+// SetNonce implements `Plugin`.
+func (o *Overlay) SetNonce(addr common.Address, nonce uint64) {
+	o.record(overlayCall{kind: callSetNonce, addr: addr, nonce: nonce})
+	acct := o.write(addr)
+	acct.fields.nonce, acct.fields.nonceKnown = nonce, true
+}
+
+// getNonce returns the nonce of the given account, which is read from the base plugin if it is not
+// known yet.
+func (o *Overlay) getNonce(addr common.Address) uint64 {
+	acct := o.account(addr)
+	if !acct.fields.nonceKnown {
+		acct.fields.nonce, acct.fields.nonceKnown = o.base.GetNonce(addr), true
+	}
+	return acct.fields.nonce
+}
+
+// =============================================================================
+// Code
+// =============================================================================
+
+// GetCodeHash implements `Plugin`.
+func (o *Overlay) GetCodeHash(addr common.Address) common.Hash {
+	codeHash := o.getCodeHash(addr)
+	o.record(overlayCall{kind: callGetCodeHash, addr: addr, hash: codeHash})
+	return codeHash
+}
+
+// GetCode implements `Plugin`.
+func (o *Overlay) GetCode(addr common.Address) []byte {
+	acct := o.account(addr)
+	if !acct.fields.codeKnown {
+		acct.fields.code = common.CopyBytes(o.base.GetCode(addr))
+		acct.fields.codeKnown = true
+	}
+	o.record(overlayCall{kind: callGetCode, addr: addr, code: acct.fields.code})
+	return common.CopyBytes(acct.fields.code)
+}
+
+// SetCode implements `Plugin`.
+func (o *Overlay) SetCode(addr common.Address, code []byte) {
+	code = common.CopyBytes(code)
+	o.record(overlayCall{kind: callSetCode, addr: addr, code: code})
+	acct := o.write(addr)
+	acct.fields.code, acct.fields.codeKnown = code, true
+	acct.fields.codeHash, acct.fields.codeHashKnown = crypto.Keccak256Hash(code), true
+}
+
+// getCodeHash returns the code hash of the given account, which is read from the base plugin if it
+// is not known yet.
+func (o *Overlay) getCodeHash(addr common.Address) common.Hash {
+	acct := o.account(addr)
+	if !acct.fields.codeHashKnown {
+		acct.fields.codeHash, acct.fields.codeHashKnown = o.base.GetCodeHash(addr), true
+	}
+	return acct.fields.codeHash
+}
+
+// =============================================================================
+// Storage
+// =============================================================================
+
+// GetCommittedState implements `Plugin`.
+func (o *Overlay) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	acct := o.account(addr)
+	value, ok := acct.committed[key]
+	if !ok && !acct.committedCleared {
+		value = o.base.GetCommittedState(addr, key)
+		if acct.committed == nil {
+			acct.committed = make(map[common.Hash]common.Hash)
+		}
+		acct.committed[key] = value
+	}
+	o.record(overlayCall{
+		kind: callGetCommittedState, addr: addr, key: key, hash: value,
+	})
+	return value
+}
+
+// GetState implements `Plugin`.
+func (o *Overlay) GetState(addr common.Address, key common.Hash) common.Hash {
+	acct := o.account(addr)
+	value, ok := acct.storage[key]
+	if !ok && !acct.cleared {
+		value = o.base.GetState(addr, key)
+		if acct.storage == nil {
+			acct.storage = make(map[common.Hash]common.Hash)
+		}
+		acct.storage[key] = value
+	}
+	o.record(overlayCall{kind: callGetState, addr: addr, key: key, hash: value})
+	return value
+}
+
+// SetState implements `Plugin`.
+func (o *Overlay) SetState(addr common.Address, key, value common.Hash) {
+	o.record(overlayCall{kind: callSetState, addr: addr, key: key, hash: value})
+	acct := o.write(addr)
+	if acct.storage == nil {
+		acct.storage = make(map[common.Hash]common.Hash)
+	}
+	storage := acct.storage
+	prev, ok := storage[key]
+	o.undo = append(o.undo, func() {
+		if ok {
+			storage[key] = prev
+		} else {
+			delete(storage, key)
+		}
+	})
+	storage[key] = value
+}
+
+// SetStorage implements `Plugin`.
+func (o *Overlay) SetStorage(addr common.Address, storage map[common.Hash]common.Hash) {
+	copied := make(map[common.Hash]common.Hash, len(storage))
+	for key, value := range storage {
+		copied[key] = value
+	}
+	o.record(overlayCall{kind: callSetStorage, addr: addr, storage: copied})
+	o.replaceStorage(o.write(addr), copied)
+}
+
+// ForEachStorage iterates over the storage of the base plugin, as modified by the overlay, and
+// then over the slots that are only known to the overlay. The call can not be replayed.
+//
+// ForEachStorage implements `Plugin`.
+func (o *Overlay) ForEachStorage(
+	addr common.Address, cb func(common.Hash, common.Hash) bool,
+) error {
+	o.record(overlayCall{kind: callForEachStorage, addr: addr})
+	acct := o.account(addr)
+	visited := make(map[common.Hash]struct{})
+	stopped := false
+	if !acct.cleared {
+		if err := o.base.ForEachStorage(addr, func(key, value common.Hash) bool {
+			if v, ok := acct.storage[key]; ok {
+				value = v
+			}
+			visited[key] = struct{}{}
+			stopped = !cb(key, value)
+			return !stopped
+		}); err != nil {
+			return err
+		}
+	}
+	for key, value := range acct.storage {
+		if stopped {
+			break
+		}
+		if _, ok := visited[key]; !ok {
+			stopped = !cb(key, value)
+		}
+	}
+	return nil
+}
+
+// replaceStorage replaces the storage of the given account, hiding the base storage.
+func (o *Overlay) replaceStorage(acct *overlayAccount, storage map[common.Hash]common.Hash) {
+	prev, prevCleared := acct.storage, acct.cleared
+	o.undo = append(o.undo, func() {
+		acct.storage, acct.cleared = prev, prevCleared
+	})
+	acct.storage, acct.cleared = storage, true
+}
+
+// =============================================================================
+// Iteration and Proofs
+// =============================================================================
+
+// IterateAccounts implements `IterablePlugin` by iterating over the accounts of the base plugin,
+// if it is iterable. The writes buffered in the overlay are not iterated over.
+func (o *Overlay) IterateAccounts(start common.Address, cb func(common.Address) bool) {
+	if ip, ok := o.base.(IterablePlugin); ok {
+		ip.IterateAccounts(start, cb)
+	}
+}
+
+// IterateState implements `IterablePlugin` by iterating over the storage of the base plugin, if it
+// is iterable. The writes buffered in the overlay are not iterated over.
+func (o *Overlay) IterateState(
+	start common.Address, cb func(common.Address, common.Hash, common.Hash) bool,
+) {
+	if ip, ok := o.base.(IterablePlugin); ok {
+		ip.IterateState(start, cb)
+	}
+}
+
+// IterateCode implements `IterablePlugin` by iterating over the code of the base plugin, if it is
+// iterable. The writes buffered in the overlay are not iterated over.
+func (o *Overlay) IterateCode(start common.Address, cb func(common.Address, []byte) bool) {
+	if ip, ok := o.base.(IterablePlugin); ok {
+		ip.IterateCode(start, cb)
+	}
+}
+
+// GetProof implements `ProofPlugin` by returning the proof of the base plugin, as the writes
+// buffered in the overlay can not be proven.
+func (o *Overlay) GetProof(addr common.Address) ([][]byte, error) {
+	pp, ok := o.base.(ProofPlugin)
+	if !ok {
+		return nil, ErrProofsNotSupported
+	}
+	return pp.GetProof(addr)
+}
+
+// GetStorageProof implements `ProofPlugin` by returning the proof of the base plugin.
+func (o *Overlay) GetStorageProof(addr common.Address, slot common.Hash) ([][]byte, error) {
+	pp, ok := o.base.(ProofPlugin)
+	if !ok {
+		return nil, ErrProofsNotSupported
+	}
+	return pp.GetStorageProof(addr, slot)
+}
+
+// GetProofRoot implements `ProofPlugin` by returning the proof root of the base plugin.
+func (o *Overlay) GetProofRoot() (common.Hash, error) {
+	pp, ok := o.base.(ProofPlugin)
+	if !ok {
+		return common.Hash{}, ErrProofsNotSupported
+	}
+	return pp.GetProofRoot()
+}
+
+// =============================================================================
+// Replay
+// =============================================================================
+
+// Replay replays the calls that were made to the overlay, in order, on the given plugin. It
+// returns `ErrReplayMismatch` as soon as a read of the plugin does not return the same result as
+// it did on the overlay, in which case the calls made so far are not reverted. If all of the reads
+// match, the plugin ends up in the same state as if the calls were made on it in the first place.
+// It returns `ErrOverlayNotRecorded` if the overlay was created with `NewQueryOverlay`.
+func (o *Overlay) Replay(p Plugin) error {
+	if !o.recording {
+		return ErrOverlayNotRecorded
+	}
+	snapshots := make(map[int]int)
+	for i := range o.calls {
+		c := &o.calls[i]
+		var match = true
+		switch c.kind {
+		case callCreateAccount:
+			p.CreateAccount(c.addr)
+		case callExist:
+			match = p.Exist(c.addr) == c.ok
+		case callEmpty:
+			match = p.Empty(c.addr) == c.ok
+		case callDeleteAccounts:
+			p.DeleteAccounts(c.addrs)
+		case callGetBalance:
+			match = p.GetBalance(c.addr).Cmp(c.amount) == 0
+		case callSetBalance:
+			p.SetBalance(c.addr, new(big.Int).Set(c.amount))
+		case callAddBalance:
+			p.AddBalance(c.addr, new(big.Int).Set(c.amount))
+		case callSubBalance:
+			p.SubBalance(c.addr, new(big.Int).Set(c.amount))
+		case callGetNonce:
+			match = p.GetNonce(c.addr) == c.nonce
+		case callSetNonce:
+			p.SetNonce(c.addr, c.nonce)
+		case callGetCodeHash:
+			match = p.GetCodeHash(c.addr) == c.hash
+		case callGetCode:
+			match = bytes.Equal(p.GetCode(c.addr), c.code)
+		case callSetCode:
+			p.SetCode(c.addr, common.CopyBytes(c.code))
+		case callGetCommittedState:
+			match = p.GetCommittedState(c.addr, c.key) == c.hash
+		case callGetState:
+			match = p.GetState(c.addr, c.key) == c.hash
+		case callSetState:
+			p.SetState(c.addr, c.key, c.hash)
+		case callSetStorage:
+			p.SetStorage(c.addr, c.storage)
+		case callForEachStorage:
+			// the iteration depends on the callback, so it can not be replayed.
+			match = false
+		case callSnapshot:
+			snapshots[c.id] = p.Snapshot()
+		case callRevertToSnapshot:
+			p.RevertToSnapshot(snapshots[c.id])
+		}
+		if !match {
+			return ErrReplayMismatch
+		}
+	}
+	return nil
+}
+
+// Reads returns the accounts and storage slots that were read through the overlay, in the sense
+// of `AccessSet`. The code of an account is not included, as it only changes when a contract is
+// created or destroyed, which `Replay` detects.
+func (o *Overlay) Reads() *AccessSet {
+	reads := NewAccessSet()
+	for i := range o.calls {
+		switch c := &o.calls[i]; c.kind { //nolint:exhaustive // only reads are included.
+		case callExist, callEmpty, callGetBalance, callGetNonce:
+			reads.AddAccount(c.addr)
+		case callGetCommittedState, callGetState:
+			reads.AddSlot(c.addr, c.key)
+		}
+	}
+	return reads
+}
+
+// Writes returns the accounts and storage slots that were written through the overlay, in the
+// sense of `AccessSet`, including the writes that were reverted.
+func (o *Overlay) Writes() *AccessSet {
+	writes := NewAccessSet()
+	for i := range o.calls {
+		switch c := &o.calls[i]; c.kind { //nolint:exhaustive // only writes are included.
+		case callCreateAccount, callSetBalance, callAddBalance, callSubBalance, callSetNonce,
+			callSetCode, callSetStorage:
+			writes.AddAccount(c.addr)
+		case callDeleteAccounts:
+			for _, addr := range c.addrs {
+				writes.AddAccount(addr)
+			}
+		case callSetState:
+			writes.AddSlot(c.addr, c.key)
+		}
+	}
+	return writes
+}
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+// account returns the account with the given address, creating an unknown account if needed.
+func (o *Overlay) account(addr common.Address) *overlayAccount {
+	acct, ok := o.accounts[addr]
+	if !ok {
+		acct = &overlayAccount{}
+		o.accounts[addr] = acct
+	}
+	return acct
+}
+
+// write returns the account with the given address, after journaling its fields so that the
+// write that follows can be reverted.
+func (o *Overlay) write(addr common.Address) *overlayAccount {
+	acct := o.account(addr)
+	prev := acct.fields
+	o.undo = append(o.undo, func() { acct.fields = prev })
+	acct.fields.dirty = true
+	return acct
+}
+
+// callKind is the kind of a call that was made to the overlay.
+type callKind uint8
+
+const (
+	callCreateAccount callKind = iota
+	callExist
+	callEmpty
+	callDeleteAccounts
+	callGetBalance
+	callSetBalance
+	callAddBalance
+	callSubBalance
+	callGetNonce
+	callSetNonce
+	callGetCodeHash
+	callGetCode
+	callSetCode
+	callGetCommittedState
+	callGetState
+	callSetState
+	callSetStorage
+	callForEachStorage
+	callSnapshot
+	callRevertToSnapshot
+)
+
+// overlayCall is a call that was made to the overlay, along with its result if it is a read.
+type overlayCall struct {
+	kind    callKind
+	addr    common.Address
+	addrs   []common.Address
+	key     common.Hash
+	hash    common.Hash
+	amount  *big.Int
+	nonce   uint64
+	code    []byte
+	ok      bool
+	storage map[common.Hash]common.Hash
+	id      int
+}
@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package state_test
+
+import (
+	"bytes"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/eth/core/state/journal/mock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Overlay", func() {
+	var base, live *mock.PluginMock
+	var o *state.Overlay
+
+	BeforeEach(func() {
+		base = newMapPlugin(map[common.Address]int64{alice: 10, bob: 5})
+		live = newMapPlugin(map[common.Address]int64{alice: 10, bob: 5})
+		o = state.NewOverlay(base)
+	})
+
+	It("should buffer writes without writing to the base plugin", func() {
+		o.AddBalance(alice, big.NewInt(5))
+		o.SetState(alice, slot, common.Hash{2})
+		o.SetNonce(alice, 3)
+
+		Expect(o.GetBalance(alice)).To(Equal(big.NewInt(15)))
+		Expect(o.GetState(alice, slot)).To(Equal(common.Hash{2}))
+		Expect(o.GetNonce(alice)).To(Equal(uint64(3)))
+		Expect(base.GetBalance(alice)).To(Equal(big.NewInt(10)))
+		Expect(base.GetState(alice, slot)).To(Equal(common.Hash{}))
+		Expect(base.GetNonce(alice)).To(Equal(uint64(0)))
+		Expect(base.SetStateCalls()).To(BeEmpty())
+	})
+
+	It("should revert to a snapshot", func() {
+		o.SetState(alice, slot, common.Hash{2})
+		id := o.Snapshot()
+		o.SetState(alice, slot, common.Hash{3})
+		o.SubBalance(alice, big.NewInt(4))
+		o.RevertToSnapshot(id)
+
+		Expect(o.GetState(alice, slot)).To(Equal(common.Hash{2}))
+		Expect(o.GetBalance(alice)).To(Equal(big.NewInt(10)))
+	})
+
+	It("should hide the base storage after deleting an account", func() {
+		base.SetState(bob, slot, common.Hash{1})
+		o.DeleteAccounts([]common.Address{bob})
+
+		Expect(o.GetState(bob, slot)).To(Equal(common.Hash{}))
+		Expect(o.GetBalance(bob).Sign()).To(Equal(0))
+	})
+
+	It("should return the accounts and slots that were read and written", func() {
+		o.SetBalance(bob, o.GetBalance(alice))
+		o.SetState(bob, slot, o.GetState(alice, slot))
+
+		reads, writes := o.Reads(), o.Writes()
+		Expect(reads.Len()).To(Equal(2))
+		Expect(writes.Len()).To(Equal(2))
+		Expect(reads.Intersects(writes)).To(BeFalse())
+
+		other := state.NewAccessSet()
+		other.AddSlot(bob, slot)
+		Expect(writes.Intersects(other)).To(BeTrue())
+		Expect(reads.Intersects(other)).To(BeFalse())
+		other.AddAccount(alice)
+		Expect(reads.Intersects(other)).To(BeTrue())
+	})
+
+	It("should forward the iteration and proofs to the base plugin", func() {
+		Expect(o.GetProof(alice)).Error().To(MatchError(state.ErrProofsNotSupported))
+		o.IterateAccounts(common.Address{}, func(common.Address) bool {
+			Fail("the base plugin is not iterable")
+			return true
+		})
+
+		q := state.NewQueryOverlay(
+			&provablePlugin{PluginMock: base, addrs: []common.Address{alice, bob}},
+		)
+		var addrs []common.Address
+		q.IterateAccounts(alice, func(addr common.Address) bool {
+			addrs = append(addrs, addr)
+			return false
+		})
+		Expect(addrs).To(Equal([]common.Address{alice, bob}))
+		Expect(q.GetProof(bob)).To(Equal([][]byte{bob.Bytes()}))
+		Expect(q.GetStorageProof(bob, slot)).To(Equal([][]byte{bob.Bytes(), slot.Bytes()}))
+		Expect(q.GetProofRoot()).To(Equal(common.Hash{0xaa}))
+
+		// the statedb on top of the overlay serves the proofs of the base plugin.
+		sdb, ok := state.NewStateDB(q).(interface {
+			GetProof(common.Address) ([][]byte, error)
+		})
+		Expect(ok).To(BeTrue())
+		Expect(sdb.GetProof(alice)).To(Equal([][]byte{alice.Bytes()}))
+	})
+
+	When("replaying", func() {
+		It("should apply the writes if the reads match", func() {
+			o.SubBalance(alice, new(big.Int).Sub(o.GetBalance(alice), big.NewInt(1)))
+			o.AddBalance(bob, big.NewInt(9))
+			o.SetState(alice, slot, o.GetState(bob, slot))
+			o.SetNonce(alice, o.GetNonce(alice)+1)
+
+			Expect(o.Replay(live)).To(Succeed())
+			Expect(live.GetBalance(alice)).To(Equal(big.NewInt(1)))
+			Expect(live.GetBalance(bob)).To(Equal(big.NewInt(14)))
+			Expect(live.GetNonce(alice)).To(Equal(uint64(1)))
+		})
+
+		It("should not conflict on balance changes that were not read", func() {
+			o.AddBalance(bob, big.NewInt(9))
+			live.AddBalance(bob, big.NewInt(1))
+
+			Expect(o.Replay(live)).To(Succeed())
+			Expect(live.GetBalance(bob)).To(Equal(big.NewInt(15)))
+		})
+
+		It("should detect a conflicting read", func() {
+			o.SetBalance(bob, o.GetBalance(alice))
+			live.SetBalance(alice, big.NewInt(11))
+
+			Expect(o.Replay(live)).To(MatchError(state.ErrReplayMismatch))
+		})
+
+		It("should detect a conflicting storage read", func() {
+			o.SetState(alice, slot, o.GetState(bob, slot))
+			live.SetState(bob, slot, common.Hash{7})
+
+			Expect(o.Replay(live)).To(MatchError(state.ErrReplayMismatch))
+		})
+
+		It("should not replay a query overlay", func() {
+			q := state.NewQueryOverlay(base)
+			q.SetBalance(alice, big.NewInt(1))
+
+			Expect(q.GetBalance(alice)).To(Equal(big.NewInt(1)))
+			Expect(base.GetBalance(alice)).To(Equal(big.NewInt(10)))
+			Expect(q.Replay(live)).To(MatchError(state.ErrOverlayNotRecorded))
+			Expect(live.SetBalanceCalls()).To(BeEmpty())
+		})
+	})
+})
+
+// provablePlugin is a state plugin mock that can be iterated over and proves its accounts with
+// their addresses.
+type provablePlugin struct {
+	*mock.PluginMock
+	addrs []common.Address
+}
+
+func (p *provablePlugin) IterateAccounts(start common.Address, cb func(common.Address) bool) {
+	for _, addr := range p.addrs {
+		if bytes.Compare(addr.Bytes(), start.Bytes()) >= 0 && cb(addr) {
+			return
+		}
+	}
+}
+
+func (p *provablePlugin) IterateState(
+	common.Address, func(common.Address, common.Hash, common.Hash) bool,
+) {
+}
+
+func (p *provablePlugin) IterateCode(common.Address, func(common.Address, []byte) bool) {}
+
+func (p *provablePlugin) GetProof(addr common.Address) ([][]byte, error) {
+	return [][]byte{addr.Bytes()}, nil
+}
+
+func (p *provablePlugin) GetStorageProof(addr common.Address, slot common.Hash) ([][]byte, error) {
+	return [][]byte{addr.Bytes(), slot.Bytes()}, nil
+}
+
+func (p *provablePlugin) GetProofRoot() (common.Hash, error) {
+	return common.Hash{0xaa}, nil
+}
+
+// newMapPlugin returns a state plugin mock that keeps the balances, nonces, and storage of the
+// accounts in memory.
+func newMapPlugin(balances map[common.Address]int64) *mock.PluginMock {
+	p := mock.NewEmptyStatePlugin()
+	bals := make(map[common.Address]*big.Int)
+	for addr, balance := range balances {
+		bals[addr] = big.NewInt(balance)
+	}
+	nonces := make(map[common.Address]uint64)
+	storage := make(map[common.Address]map[common.Hash]common.Hash)
+
+	p.GetBalanceFunc = func(addr common.Address) *big.Int {
+		if balance, ok := bals[addr]; ok {
+			return new(big.Int).Set(balance)
+		}
+		return new(big.Int)
+	}
+	p.SetBalanceFunc = func(addr common.Address, amount *big.Int) {
+		bals[addr] = new(big.Int).Set(amount)
+	}
+	p.AddBalanceFunc = func(addr common.Address, amount *big.Int) {
+		bals[addr] = new(big.Int).Add(p.GetBalance(addr), amount)
+	}
+	p.SubBalanceFunc = func(addr common.Address, amount *big.Int) {
+		bals[addr] = new(big.Int).Sub(p.GetBalance(addr), amount)
+	}
+	p.GetNonceFunc = func(addr common.Address) uint64 {
+		return nonces[addr]
+	}
+	p.SetNonceFunc = func(addr common.Address, nonce uint64) {
+		nonces[addr] = nonce
+	}
+	p.GetStateFunc = func(addr common.Address, key common.Hash) common.Hash {
+		return storage[addr][key]
+	}
+	p.SetStateFunc = func(addr common.Address, key, value common.Hash) {
+		if storage[addr] == nil {
+			storage[addr] = make(map[common.Hash]common.Hash)
+		}
+		storage[addr][key] = value
+	}
+	return p
+}
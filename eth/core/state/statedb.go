@@ -185,22 +185,6 @@ func (sdb *stateDB) Copy() StateDBI {
 	return NewStateDB(sdb.Plugin)
 }
 
-func (sdb *stateDB) DumpToCollector(_ DumpCollector, _ *DumpConfig) []byte {
-	return nil
-}
-
-func (sdb *stateDB) Dump(_ *DumpConfig) []byte {
-	return nil
-}
-
-func (sdb *stateDB) RawDump(_ *DumpConfig) Dump {
-	return Dump{}
-}
-
-func (sdb *stateDB) IteratorDump(_ *DumpConfig) IteratorDump {
-	return IteratorDump{}
-}
-
 func (sdb *stateDB) Database() Database {
 	return nil
 }
@@ -213,22 +197,59 @@ func (sdb *stateDB) IntermediateRoot(_ bool) common.Hash {
 	return common.Hash{}
 }
 
-func (sdb *stateDB) StorageTrie(_ common.Address) (Trie, error) {
-	return nil, nil
+// StorageTrie implements the `StateDB` interface. Polaris does not store accounts in a trie, and
+// the storage of an account is not committed to separately, so if the plugin supports proofs, the
+// returned trie of an existing account has the zero hash. The storage proofs of the plugin are
+// verified against its proof root instead.
+func (sdb *stateDB) StorageTrie(addr common.Address) (Trie, error) {
+	if _, ok := sdb.Plugin.(ProofPlugin); !ok || !sdb.Exist(addr) {
+		return nil, nil
+	}
+	return &storageCommitment{}, nil
 }
 
 func (sdb *stateDB) Error() error {
 	return nil
 }
 
-func (sdb *stateDB) GetStorageProof(_ common.Address, _ common.Hash) ([][]byte, error) {
+// GetStorageProof implements the `StateDB` interface by returning the proof of the given storage
+// slot from the plugin, if it supports proofs.
+func (sdb *stateDB) GetStorageProof(addr common.Address, slot common.Hash) ([][]byte, error) {
+	if pp, ok := sdb.Plugin.(ProofPlugin); ok {
+		return pp.GetStorageProof(addr, slot)
+	}
 	return nil, nil
 }
 
-func (sdb *stateDB) GetProof(_ common.Address) ([][]byte, error) {
+// GetProof implements the `StateDB` interface by returning the proof of the given account from the
+// plugin, if it supports proofs.
+func (sdb *stateDB) GetProof(addr common.Address) ([][]byte, error) {
+	if pp, ok := sdb.Plugin.(ProofPlugin); ok {
+		return pp.GetProof(addr)
+	}
 	return nil, nil
 }
 
+// GetProofRoot returns the commitment that the proofs of the plugin are verified against, if it
+// supports proofs.
+func (sdb *stateDB) GetProofRoot() (common.Hash, error) {
+	if pp, ok := sdb.Plugin.(ProofPlugin); ok {
+		return pp.GetProofRoot()
+	}
+	return common.Hash{}, ErrProofsNotSupported
+}
+
 func (sdb *stateDB) GetOrNewStateObject(_ common.Address) *StateObject {
 	return nil
 }
+
+// storageCommitment is a `Trie` that only exposes the commitment of an account's storage, which is
+// the zero hash as the storage is not committed to separately.
+type storageCommitment struct {
+	Trie
+}
+
+// Hash implements `Trie`.
+func (sc *storageCommitment) Hash() common.Hash {
+	return common.Hash{}
+}
@@ -21,7 +21,9 @@
 package state_test
 
 import (
+	"bytes"
 	"math/big"
+	"sort"
 
 	"pkg.berachain.dev/polaris/eth/common"
 	"pkg.berachain.dev/polaris/eth/core/state"
@@ -112,4 +114,166 @@ var _ = Describe("StateDB", func() {
 		sdb.Finalize()
 		Expect(sdb.HasSuicided(bob)).To(BeFalse())
 	})
+
+	It("should not return proofs if the plugin does not support them", func() {
+		sdb.CreateAccount(alice)
+		trie, err := sdb.StorageTrie(alice)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trie).To(BeNil())
+		proof, err := sdb.GetProof(alice)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proof).To(BeNil())
+	})
+
+	It("should not dump accounts if the plugin is not iterable", func() {
+		sdb.CreateAccount(alice)
+		Expect(sdb.RawDump(nil).Accounts).To(BeEmpty())
+	})
+
+	It("should dump accounts in pages", func() {
+		sp := mock.NewEmptyStatePlugin()
+		storage := map[common.Address]map[common.Hash]common.Hash{
+			bob: {slot: common.Hash{31: 0x2a}},
+		}
+		sp.ForEachStorageFunc = func(addr common.Address, cb func(common.Hash, common.Hash) bool) error {
+			for k, v := range storage[addr] {
+				if !cb(k, v) {
+					break
+				}
+			}
+			return nil
+		}
+		sdb = state.NewStateDB(&iterablePlugin{PluginMock: sp, storage: storage})
+		sdb.CreateAccount(alice)
+		sdb.AddBalance(alice, big.NewInt(10))
+		sdb.CreateAccount(bob)
+		sdb.SetCode(bob, []byte{1, 2, 3})
+
+		dump := sdb.IteratorDump(&state.DumpConfig{Max: 1})
+		Expect(dump.Accounts).To(HaveLen(1))
+		Expect(dump.Accounts[alice].Balance).To(Equal("10"))
+		Expect(dump.Next).To(Equal(bob.Bytes()))
+
+		dump = sdb.IteratorDump(&state.DumpConfig{Start: dump.Next, Max: 1})
+		Expect(dump.Accounts).To(HaveLen(1))
+		Expect(dump.Next).To(BeNil())
+		Expect([]byte(dump.Accounts[bob].Code)).To(Equal([]byte{1, 2, 3}))
+		Expect(dump.Accounts[bob].Storage).To(Equal(map[common.Hash]string{slot: "2a"}))
+
+		raw := sdb.RawDump(&state.DumpConfig{SkipCode: true, SkipStorage: true})
+		Expect(raw.Accounts).To(HaveLen(2))
+		Expect(raw.Accounts[bob].Code).To(BeEmpty())
+		Expect(raw.Accounts[bob].Storage).To(BeNil())
+	})
+
+	It("should stop iterating over the state after a page", func() {
+		ip := &iterablePlugin{PluginMock: mock.NewEmptyStatePlugin()}
+		sdb = state.NewStateDB(ip)
+		for i := byte(1); i <= 5; i++ {
+			sdb.CreateAccount(common.Address{i})
+		}
+
+		dump := sdb.IteratorDump(&state.DumpConfig{Start: common.Address{2}.Bytes(), Max: 2})
+		Expect(dump.Accounts).To(HaveLen(2))
+		Expect(dump.Accounts).To(HaveKey(common.Address{2}))
+		Expect(dump.Accounts).To(HaveKey(common.Address{3}))
+		Expect(dump.Next).To(Equal(common.Address{4}.Bytes()))
+		Expect(ip.iterated).To(Equal(3))
+	})
+
+	It("should return proofs from the plugin", func() {
+		sp := mock.NewEmptyStatePlugin()
+		sp.ExistFunc = func(addr common.Address) bool {
+			_, ok := mock.Accounts[addr]
+			return ok
+		}
+		sdb = state.NewStateDB(&proofPlugin{sp})
+		sdb.CreateAccount(alice)
+
+		trie, err := sdb.StorageTrie(alice)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trie.Hash()).To(Equal(common.Hash{}))
+		trie, err = sdb.StorageTrie(bob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trie).To(BeNil())
+
+		proof, err := sdb.GetProof(alice)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proof).To(Equal([][]byte{alice.Bytes()}))
+		proof, err = sdb.GetStorageProof(alice, slot)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proof).To(Equal([][]byte{alice.Bytes(), slot.Bytes()}))
+		root, err := sdb.(interface{ GetProofRoot() (common.Hash, error) }).GetProofRoot()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(root).To(Equal(common.Hash{0xaa}))
+	})
 })
+
+// iterablePlugin is a state plugin that supports iterating over its state.
+type iterablePlugin struct {
+	*mock.PluginMock
+	storage map[common.Address]map[common.Hash]common.Hash
+	// iterated is the number of accounts that were iterated over
+	iterated int
+}
+
+func (ip *iterablePlugin) IterateAccounts(start common.Address, cb func(common.Address) bool) {
+	for _, addr := range sortedFrom(start, mock.Accounts) {
+		ip.iterated++
+		if cb(addr) {
+			return
+		}
+	}
+}
+
+func (ip *iterablePlugin) IterateState(
+	start common.Address, cb func(common.Address, common.Hash, common.Hash) bool,
+) {
+	for _, addr := range sortedFrom(start, ip.storage) {
+		for k, v := range ip.storage[addr] {
+			if cb(addr, k, v) {
+				return
+			}
+		}
+	}
+}
+
+func (ip *iterablePlugin) IterateCode(start common.Address, cb func(common.Address, []byte) bool) {
+	for _, addr := range sortedFrom(start, mock.Accounts) {
+		if code := mock.Accounts[addr].Code; len(code) > 0 && cb(addr, code) {
+			return
+		}
+	}
+}
+
+// sortedFrom returns the addresses of the given map that are greater or equal than the given start
+// address, in ascending order.
+func sortedFrom[V any](start common.Address, m map[common.Address]V) []common.Address {
+	var from []common.Address
+	for addr := range m {
+		if bytes.Compare(addr.Bytes(), start.Bytes()) >= 0 {
+			from = append(from, addr)
+		}
+	}
+	sort.Slice(from, func(i, j int) bool {
+		return bytes.Compare(from[i].Bytes(), from[j].Bytes()) < 0
+	})
+	return from
+}
+
+// proofPlugin is a state plugin that supports proofs.
+type proofPlugin struct {
+	*mock.PluginMock
+}
+
+func (pp *proofPlugin) GetProof(addr common.Address) ([][]byte, error) {
+	return [][]byte{addr.Bytes()}, nil
+}
+
+func (pp *proofPlugin) GetStorageProof(addr common.Address, slot common.Hash) ([][]byte, error) {
+	return [][]byte{addr.Bytes(), slot.Bytes()}, nil
+}
+
+func (pp *proofPlugin) GetProofRoot() (common.Hash, error) {
+	return common.Hash{0xaa}, nil
+}
@@ -43,10 +43,15 @@ type (
 	LegacyTx          = types.LegacyTx
 	TxData            = types.TxData
 	Signer            = types.Signer
+	TxByNonce         = types.TxByNonce
+	HomesteadSigner   = types.HomesteadSigner
+
+	TransactionsByPriceAndNonce = types.TransactionsByPriceAndNonce
 )
 
 var (
 	NewLondonSigner        = types.NewLondonSigner
+	CopyHeader             = types.CopyHeader
 	BytesToBloom           = types.BytesToBloom
 	CreateBloom            = types.CreateBloom
 	MakeSigner             = types.MakeSigner
@@ -67,6 +72,13 @@ var (
 	MustSignNewTx          = types.MustSignNewTx
 	NewBlock               = types.NewBlock
 	ErrInvalidSig          = types.ErrInvalidSig
+	ErrInvalidChainID      = types.ErrInvalidChainId
+
+	NewTransactionsByPriceAndNonce = types.NewTransactionsByPriceAndNonce
+)
+
+const (
+	BloomBitLength = types.BloomBitLength
 )
 
 var (
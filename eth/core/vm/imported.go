@@ -34,8 +34,10 @@ type (
 	GethEVM             = vm.EVM
 	GethStateDB         = vm.StateDB
 	GetHashFunc         = vm.GetHashFunc
+	OpCode              = vm.OpCode
 	PrecompileContainer = vm.PrecompiledContract
 	PrecompileManager   = vm.PrecompileManager
+	ScopeContext        = vm.ScopeContext
 	TransferFunc        = vm.TransferFunc
 	TxContext           = vm.TxContext
 )
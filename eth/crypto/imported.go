@@ -40,6 +40,7 @@ var (
 	ValidateSignatureValues = crypto.ValidateSignatureValues
 	Keccak256               = crypto.Keccak256
 	Keccak256Hash           = crypto.Keccak256Hash
+	LoadECDSA               = crypto.LoadECDSA
 	PubkeyToAddress         = crypto.PubkeyToAddress
 	SignatureLength         = crypto.SignatureLength
 	ToECDSA                 = crypto.ToECDSA
@@ -26,3 +26,11 @@ type (
 	ChainConfig = params.ChainConfig
 	Rules       = params.Rules
 )
+
+const (
+	BloomBitsBlocks          = params.BloomBitsBlocks
+	BaseFeeChangeDenominator = params.BaseFeeChangeDenominator
+	ElasticityMultiplier     = params.ElasticityMultiplier
+	TxGas                    = params.TxGas
+	Ether                    = params.Ether
+)
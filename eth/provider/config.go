@@ -23,6 +23,7 @@ package provider
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/BurntSushi/toml"
 
@@ -30,8 +31,12 @@ import (
 	"github.com/ethereum/go-ethereum/p2p"
 
 	"pkg.berachain.dev/polaris/eth/rpc"
+	rpcapi "pkg.berachain.dev/polaris/eth/rpc/api"
 )
 
+// defaultPendingRecommit is the default minimum interval between two builds of the pending block.
+const defaultPendingRecommit = 2 * time.Second
+
 // DefaultConfig returns the default configuration for the provider.
 func DefaultConfig() *Config {
 	c := Config{}
@@ -46,6 +51,7 @@ func DefaultConfig() *Config {
 	nodeCfg.WSOrigins = []string{"*"}
 	c.NodeConfig = nodeCfg
 	c.RPCConfig = *rpc.DefaultConfig()
+	c.PendingBlockConfig.Recommit = defaultPendingRecommit
 	return &c
 }
 
@@ -53,6 +59,23 @@ func DefaultConfig() *Config {
 type Config struct {
 	NodeConfig node.Config
 	RPCConfig  rpc.Config
+	// UserOperationConfig configures the optional ERC-4337 user operation RPC API.
+	UserOperationConfig rpcapi.UserOperationConfig
+	// PrivateTransactionConfig configures the optional private transaction RPC API.
+	PrivateTransactionConfig rpcapi.PrivateTransactionConfig
+	// PendingBlockConfig configures the optional building of the pending block.
+	PendingBlockConfig PendingBlockConfig
+}
+
+// PendingBlockConfig is the configuration of the pending block, which is built on top of the
+// chain head with the transactions of the tx pool. When it is disabled, the pending block is the
+// chain head.
+type PendingBlockConfig struct {
+	// Enabled enables building the pending block.
+	Enabled bool `toml:""`
+	// Recommit is the minimum interval between two builds of the pending block. It defaults to
+	// 2s.
+	Recommit time.Duration `toml:""`
 }
 
 // LoadConfigFromFilePath reads in a Polaris config file from the fileystem.
@@ -68,5 +68,13 @@ var _ = Describe("TestConfig", func() {
 		Expect(config.RPCConfig.GPO.Default).To(Equal(big.NewInt(1000000000)))
 		Expect(config.RPCConfig.GPO.MaxPrice).To(Equal(big.NewInt(100000000000)))
 		Expect(config.RPCConfig.GPO.IgnorePrice).To(Equal(big.NewInt(0)))
+		Expect(config.UserOperationConfig.Enabled).To(BeFalse())
+		Expect(config.UserOperationConfig.Authenticated).To(BeTrue())
+		Expect(config.UserOperationConfig.MaxOpsPerEntity).To(BeNumerically("==", 4))
+		Expect(config.PrivateTransactionConfig.Enabled).To(BeFalse())
+		Expect(config.PrivateTransactionConfig.Authenticated).To(BeTrue())
+		Expect(config.PrivateTransactionConfig.MaxBlocks).To(BeNumerically("==", 25))
+		Expect(config.PendingBlockConfig.Enabled).To(BeFalse())
+		Expect(config.PendingBlockConfig.Recommit).To(Equal(2 * time.Second))
 	})
 })
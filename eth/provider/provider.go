@@ -21,12 +21,15 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/node"
 
 	"pkg.berachain.dev/polaris/eth/api"
 	"pkg.berachain.dev/polaris/eth/core"
 	"pkg.berachain.dev/polaris/eth/log"
 	"pkg.berachain.dev/polaris/eth/rpc"
+	rpcapi "pkg.berachain.dev/polaris/eth/rpc/api"
 )
 
 // PolarisProvider is the only object that an implementing chain should use.
@@ -34,6 +37,9 @@ type PolarisProvider struct {
 	api.Chain
 	backend rpc.PolarisBackend
 	Node    *node.Node
+	cfg     *Config
+	// stopPending stops the pending block worker, if it is running.
+	stopPending context.CancelFunc
 }
 
 // NewPolarisProvider creates a new `PolarisEVM` instance for use on an underlying blockchain.
@@ -63,7 +69,7 @@ func NewPolarisProviderWithConfig(
 	host core.PolarisHostChain,
 	logHandler log.Handler,
 ) *PolarisProvider {
-	sp := &PolarisProvider{}
+	sp := &PolarisProvider{cfg: cfg}
 	// When creating a Polaris EVM, we allow the implementing chain
 	// to specify their own log handler. If logHandler is nil then we
 	// we use the default geth log handler.
@@ -87,8 +93,49 @@ func NewPolarisProviderWithConfig(
 	return sp
 }
 
+// Config returns the config of the provider.
+func (sp *PolarisProvider) Config() *Config {
+	return sp.cfg
+}
+
 // StartServices starts the standard go-ethereum node-services (i.e json-rpc).
 func (sp *PolarisProvider) StartServices() error {
-	sp.Node.RegisterAPIs(rpc.GetAPIs(sp.backend))
+	apis := rpc.GetAPIs(sp.backend)
+	// The user operation API is optional, as it requires a bundler key, and can be restricted to
+	// the authenticated endpoint.
+	if cfg := sp.cfg.UserOperationConfig; cfg.Enabled {
+		userOpAPI, err := rpcapi.NewUserOperationAPI(sp.backend, &cfg)
+		if err != nil {
+			return err
+		}
+		apis = append(apis, rpc.API{
+			Namespace:     "eth",
+			Service:       userOpAPI,
+			Authenticated: cfg.Authenticated,
+		})
+	}
+	// The private transaction API is optional, and can be restricted to the authenticated endpoint.
+	if cfg := sp.cfg.PrivateTransactionConfig; cfg.Enabled {
+		apis = append(apis, rpc.API{
+			Namespace:     "eth",
+			Service:       rpcapi.NewPrivateTransactionAPI(sp.backend, &cfg),
+			Authenticated: cfg.Authenticated,
+		})
+	}
+	sp.Node.RegisterAPIs(apis)
+	// The pending block is only read over rpc, so it is only built once the services are started.
+	if cfg := sp.cfg.PendingBlockConfig; cfg.Enabled {
+		var ctx context.Context
+		ctx, sp.stopPending = context.WithCancel(context.Background())
+		sp.Chain.StartPendingWorker(ctx, cfg.Recommit)
+	}
 	return sp.Node.Start()
 }
+
+// StopServices stops the services started by `StartServices`.
+func (sp *PolarisProvider) StopServices() error {
+	if sp.stopPending != nil {
+		sp.stopPending()
+	}
+	return sp.Node.Close()
+}
@@ -54,9 +54,33 @@ func GetAPIs(apiBackend PolarisBackend) []API {
 			Namespace: "debug",
 			Service:   NewDebugAPI(apiBackend),
 		},
+		{
+			Namespace: "debug",
+			Service:   api.NewTracerAPI(apiBackend),
+		},
+		{
+			Namespace: "debug",
+			Service:   api.NewDumpAPI(apiBackend),
+		},
 		{
 			Namespace: "net",
 			Service:   api.NewNetAPI(apiBackend),
 		},
+		{
+			Namespace: "eth",
+			Service:   api.NewSimulateAPI(apiBackend),
+		},
+		{
+			// Registered after the geth `eth` APIs, so that `eth_call` and `eth_estimateGas` are
+			// served with state and block overrides.
+			Namespace: "eth",
+			Service:   api.NewCallAPI(apiBackend),
+		},
+		{
+			// Registered after the geth `eth` APIs, so that `eth_getProof` returns the root that
+			// the proofs are verified against.
+			Namespace: "eth",
+			Service:   api.NewProofAPI(apiBackend),
+		},
 	}
 }
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+// CallAPI is the collection of RPC API methods that execute a call on top of a block, with
+// optional state and block overrides. It is registered after the geth `eth` APIs, so that its
+// methods take precedence over the geth ones of the same name.
+type CallAPI interface {
+	Call(
+		context.Context, ethapi.TransactionArgs, rpc.BlockNumberOrHash,
+		*StateOverride, *BlockOverrides,
+	) (hexutil.Bytes, error)
+	EstimateGas(
+		context.Context, ethapi.TransactionArgs, *rpc.BlockNumberOrHash,
+		*StateOverride, *BlockOverrides,
+	) (hexutil.Uint64, error)
+}
+
+// callAPI offers the call RPC methods.
+type callAPI struct {
+	b CallBackend
+}
+
+// NewCallAPI creates a new call API instance.
+func NewCallAPI(b CallBackend) CallAPI {
+	return &callAPI{b}
+}
+
+// Call executes the given call on top of the state of the given block, after applying the given
+// state and block overrides. The overrides are applied to an in-memory overlay of the state, so
+// they never reach the stores of the host chain.
+func (api *callAPI) Call(
+	ctx context.Context, args ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
+) (hexutil.Bytes, error) {
+	result, err := api.doCall(ctx, args, blockNrOrHash, overrides, blockOverrides, api.b.RPCGasCap())
+	if err != nil {
+		return nil, err
+	}
+	// If the result contains a revert reason, try to unpack and return it.
+	if len(result.Revert()) > 0 {
+		return nil, newRevertError(result)
+	}
+	return result.Return(), result.Err
+}
+
+// EstimateGas returns the lowest gas limit that allows the given call to succeed on top of the
+// state of the given block, after applying the given state and block overrides.
+func (api *callAPI) EstimateGas(
+	ctx context.Context, args ethapi.TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
+) (hexutil.Uint64, error) {
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	gasCap := api.b.RPCGasCap()
+	if args.From == nil {
+		args.From = new(common.Address)
+	}
+
+	// Determine the highest gas limit that can be used during the estimation.
+	var (
+		lo     = params.TxGas - 1
+		hi     uint64
+		gasMax uint64
+	)
+	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
+		hi = uint64(*args.Gas)
+	} else {
+		statedb, header, err := api.stateAndHeader(ctx, bNrOrHash, overrides, blockOverrides)
+		if err != nil {
+			return 0, err
+		}
+		hi = header.GasLimit
+		// Recap the highest gas limit with the account's available balance.
+		if feeCap := estimateFeeCap(args); feeCap != nil && feeCap.BitLen() != 0 {
+			available := new(big.Int).Set(statedb.GetBalance(*args.From))
+			if args.Value != nil {
+				if args.Value.ToInt().Cmp(available) >= 0 {
+					return 0, core.ErrInsufficientFundsForTransfer
+				}
+				available.Sub(available, args.Value.ToInt())
+			}
+			allowance := new(big.Int).Div(available, feeCap)
+			if allowance.IsUint64() && hi > allowance.Uint64() {
+				hi = allowance.Uint64()
+			}
+		}
+	}
+	// Recap the highest gas allowance with the specified gas cap.
+	if gasCap != 0 && hi > gasCap {
+		hi = gasCap
+	}
+	gasMax = hi
+
+	// executable returns whether the call fails with the given gas limit. Errors that are not
+	// caused by the gas limit are returned as is.
+	executable := func(gas uint64) (bool, *core.ExecutionResult, error) {
+		args.Gas = (*hexutil.Uint64)(&gas)
+		result, err := api.doCall(ctx, args, bNrOrHash, overrides, blockOverrides, 0)
+		if err != nil {
+			if errors.Is(err, core.ErrIntrinsicGas) {
+				return true, nil, nil
+			}
+			return true, nil, err
+		}
+		return result.Failed(), result, nil
+	}
+	// Binary search for the smallest gas limit that allows the call to succeed.
+	for lo+1 < hi {
+		mid := (hi + lo) / 2 //nolint:gomnd // binary search.
+		failed, _, err := executable(mid)
+		if err != nil {
+			return 0, err
+		}
+		if failed {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	// Reject the call as invalid if it still fails at the highest allowance.
+	if hi == gasMax {
+		failed, result, err := executable(hi)
+		if err != nil {
+			return 0, err
+		}
+		if failed {
+			if result != nil && !errors.Is(result.Err, vm.ErrOutOfGas) {
+				if len(result.Revert()) > 0 {
+					return 0, newRevertError(result)
+				}
+				return 0, result.Err
+			}
+			return 0, fmt.Errorf("gas required exceeds allowance (%d)", gasMax)
+		}
+	}
+	return hexutil.Uint64(hi), nil
+}
+
+// doCall executes the given call on top of the state of the given block, after applying the
+// given state and block overrides.
+func (api *callAPI) doCall(
+	ctx context.Context, args ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides, gasCap uint64,
+) (*core.ExecutionResult, error) {
+	statedb, header, err := api.stateAndHeader(ctx, blockNrOrHash, overrides, blockOverrides)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := withTimeout(ctx, api.b)
+	defer cancel()
+
+	msg, err := args.ToMessage(gasCap, header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	// Calls are not bound by the gas limit of the block.
+	sim := newSimulator(api.b, statedb, header, &vm.Config{NoBaseFee: true})
+	sim.gasPool = new(core.GasPool).AddGas(math.MaxUint64)
+	result, _, err := sim.apply(ctx, msg, common.Hash{})
+	if err != nil {
+		return nil, fmt.Errorf("err: %w (supplied gas %d)", err, msg.GasLimit)
+	}
+	return result, nil
+}
+
+// stateAndHeader returns the statedb and header of the given block, with the given state and block
+// overrides applied.
+func (api *callAPI) stateAndHeader(
+	ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
+) (vm.PolarisStateDB, *types.Header, error) {
+	statedb, header, err := stateAndHeader(ctx, api.b, blockNrOrHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = overrides.Apply(statedb); err != nil {
+		return nil, nil, err
+	}
+	// Copy the header, so that the block overrides do not modify the header of the block.
+	header = types.CopyHeader(header)
+	blockOverrides.Apply(header)
+	return statedb, header, nil
+}
+
+// estimateFeeCap returns the fee cap of the given call, or nil if it is not set.
+func estimateFeeCap(args ethapi.TransactionArgs) *big.Int {
+	switch {
+	case args.GasPrice != nil:
+		return args.GasPrice.ToInt()
+	case args.MaxFeePerGas != nil:
+		return args.MaxFeePerGas.ToInt()
+	default:
+		return nil
+	}
+}
+
+// revertError is an API error that holds the revert reason of a call.
+type revertError struct {
+	error
+	// reason is the hex encoded revert data.
+	reason string
+}
+
+// newRevertError returns a revert error for the given result of a reverted call.
+func newRevertError(result *core.ExecutionResult) *revertError {
+	reason, errUnpack := abi.UnpackRevert(result.Revert())
+	err := errors.New("execution reverted")
+	if errUnpack == nil {
+		err = fmt.Errorf("execution reverted: %v", reason)
+	}
+	return &revertError{
+		error:  err,
+		reason: hexutil.Encode(result.Revert()),
+	}
+}
+
+// ErrorCode returns the JSON error code of a revert.
+func (e *revertError) ErrorCode() int {
+	return errCodeReverted
+}
+
+// ErrorData returns the hex encoded revert reason.
+func (e *revertError) ErrorData() interface{} {
+	return e.reason
+}
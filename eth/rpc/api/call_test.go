@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CallAPI", func() {
+	var (
+		b     *mockSimulateBackend
+		sp    *mockTracerStatePlugin
+		api   CallAPI
+		alice = common.Address{1}
+		bob   = common.Address{2}
+		// numberCode returns the number of the block: NUMBER PUSH1 0 MSTORE PUSH1 32 PUSH1 0
+		// RETURN.
+		numberCode = hexutil.Bytes{0x43, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+		base       = rpc.BlockNumberOrHashWithNumber(1)
+	)
+
+	transfer := func() ethapi.TransactionArgs {
+		return ethapi.TransactionArgs{
+			From:  &alice,
+			To:    &bob,
+			Value: (*hexutil.Big)(big.NewInt(1000)),
+		}
+	}
+
+	BeforeEach(func() {
+		sp = &mockTracerStatePlugin{
+			balances: map[common.Address]*big.Int{},
+			codes:    map[common.Address][]byte{bob: numberCode},
+		}
+		b = &mockSimulateBackend{
+			mockTracerBackend: &mockTracerBackend{
+				block: types.NewBlock(&types.Header{
+					Number:     big.NewInt(1),
+					GasLimit:   1e6,
+					BaseFee:    big.NewInt(1),
+					Difficulty: new(big.Int),
+				}, nil, nil, nil, trie.NewStackTrie(nil)),
+				chainConfig: params.DefaultChainConfig,
+				sp:          sp,
+			},
+			gasCap: 1e7,
+		}
+		api = NewCallAPI(b)
+	})
+
+	It("should call with the state overrides", func() {
+		_, err := api.Call(context.Background(), transfer(), base, nil, nil)
+		Expect(err).To(MatchError(ContainSubstring("insufficient funds")))
+
+		balance := (*hexutil.Big)(big.NewInt(1e18))
+		overrides := &StateOverride{alice: OverrideAccount{Balance: &balance}}
+		res, err := api.Call(context.Background(), transfer(), base, overrides, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(new(big.Int).SetBytes(res)).To(Equal(big.NewInt(1)))
+
+		// the overrides are not written to the state of the block.
+		Expect(sp.GetBalance(alice).Sign()).To(BeZero())
+	})
+
+	It("should call with the block overrides", func() {
+		code := hexutil.Bytes{0x00} // STOP
+		res, err := api.Call(context.Background(), ethapi.TransactionArgs{From: &alice, To: &bob},
+			base, nil, &BlockOverrides{Number: (*hexutil.Big)(big.NewInt(42))})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(new(big.Int).SetBytes(res)).To(Equal(big.NewInt(42)))
+
+		res, err = api.Call(context.Background(), ethapi.TransactionArgs{From: &alice, To: &bob},
+			base, &StateOverride{bob: OverrideAccount{Code: &code}}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeEmpty())
+		Expect(sp.GetCode(bob)).To(Equal([]byte(numberCode)))
+	})
+
+	It("should estimate gas with the state and block overrides", func() {
+		_, err := api.EstimateGas(context.Background(), transfer(), &base, nil, nil)
+		Expect(err).To(HaveOccurred())
+
+		balance := (*hexutil.Big)(big.NewInt(1e18))
+		gasLimit := hexutil.Uint64(params.TxGas)
+		carol := common.Address{3}
+		args := transfer()
+		args.To = &carol
+		gas, err := api.EstimateGas(context.Background(), args, &base,
+			&StateOverride{alice: OverrideAccount{Balance: &balance}},
+			&BlockOverrides{GasLimit: &gasLimit})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gas).To(Equal(hexutil.Uint64(params.TxGas)))
+
+		// the estimation is capped by the overridden gas limit of the block.
+		_, err = api.EstimateGas(context.Background(), transfer(), &base,
+			&StateOverride{alice: OverrideAccount{Balance: &balance}},
+			&BlockOverrides{GasLimit: &gasLimit})
+		Expect(err).To(MatchError(ContainSubstring("gas required exceeds allowance")))
+	})
+})
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+)
+
+// accountRangeMaxResults is the maximum number of results to be returned per call.
+const accountRangeMaxResults = 256
+
+// DumpBackend is the collection of methods required to satisfy the debug state dump RPC API.
+type DumpBackend interface {
+	StateAndHeaderByNumberOrHash(
+		context.Context, rpc.BlockNumberOrHash,
+	) (vm.GethStateDB, *types.Header, error)
+}
+
+// DumpAPI is the collection of debug state dump RPC API methods.
+type DumpAPI interface {
+	DumpBlock(context.Context, rpc.BlockNumber) (state.Dump, error)
+	AccountRange(
+		context.Context, rpc.BlockNumberOrHash, hexutil.Bytes, int, bool, bool, bool,
+	) (state.IteratorDump, error)
+}
+
+// dumper is a statedb that is able to dump its accounts.
+type dumper interface {
+	RawDump(*state.DumpConfig) state.Dump
+	IteratorDump(*state.DumpConfig) state.IteratorDump
+}
+
+// dumpAPI offers state dump RPC methods.
+type dumpAPI struct {
+	b DumpBackend
+}
+
+// NewDumpAPI creates a new debug state dump API instance.
+func NewDumpAPI(b DumpBackend) DumpAPI {
+	return &dumpAPI{b}
+}
+
+// DumpBlock retrieves all the accounts of the state at the given block. Use `AccountRange` to
+// page through large states.
+func (api *dumpAPI) DumpBlock(ctx context.Context, number rpc.BlockNumber) (state.Dump, error) {
+	d, err := api.dumperAt(ctx, rpc.BlockNumberOrHashWithNumber(number))
+	if err != nil {
+		return state.Dump{}, err
+	}
+	return d.RawDump(&state.DumpConfig{OnlyWithAddresses: true}), nil
+}
+
+// AccountRange retrieves a page of the accounts of the state at the given block, starting at the
+// given address.
+func (api *dumpAPI) AccountRange(
+	ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start hexutil.Bytes,
+	maxResults int, nocode, nostorage, incompletes bool,
+) (state.IteratorDump, error) {
+	d, err := api.dumperAt(ctx, blockNrOrHash)
+	if err != nil {
+		return state.IteratorDump{}, err
+	}
+
+	conf := &state.DumpConfig{
+		SkipCode:          nocode,
+		SkipStorage:       nostorage,
+		OnlyWithAddresses: !incompletes,
+		Start:             start,
+		Max:               uint64(maxResults),
+	}
+	if maxResults > accountRangeMaxResults || maxResults <= 0 {
+		conf.Max = accountRangeMaxResults
+	}
+	return d.IteratorDump(conf), nil
+}
+
+// dumperAt returns the statedb at the given block, if it is able to dump its accounts.
+func (api *dumpAPI) dumperAt(
+	ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash,
+) (dumper, error) {
+	statedb, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := statedb.(dumper)
+	if !ok {
+		return nil, errors.New("state dump is not supported")
+	}
+	return d, nil
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+)
+
+// OverrideAccount indicates the overriding fields of an account during the execution of a
+// message call. The `State` and `StateDiff` fields are mutually exclusive: `State` replaces the
+// entire storage of the account, while `StateDiff` only replaces the given slots.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   **hexutil.Big                `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of overridden accounts.
+type StateOverride map[common.Address]OverrideAccount
+
+// overridableStateDB is a statedb that is able to override the balance and storage of accounts.
+type overridableStateDB interface {
+	vm.PolarisStateDB
+	SetBalance(common.Address, *big.Int)
+	SetStorage(common.Address, map[common.Hash]common.Hash)
+}
+
+// Apply overrides the fields of the specified accounts in the given statedb.
+func (diff *StateOverride) Apply(statedb vm.PolarisStateDB) error {
+	if diff == nil {
+		return nil
+	}
+	sdb, ok := statedb.(overridableStateDB)
+	if !ok {
+		return errors.New("state overrides are not supported")
+	}
+	for addr, account := range *diff {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		if !sdb.Exist(addr) {
+			sdb.CreateAccount(addr)
+		}
+		if account.Nonce != nil {
+			sdb.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			sdb.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			sdb.SetBalance(addr, (*big.Int)(*account.Balance))
+		}
+		if account.State != nil {
+			sdb.SetStorage(addr, *account.State)
+		}
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				sdb.SetState(addr, key, value)
+			}
+		}
+	}
+	// The overrides are finalized, so that they are not reverted with the first message.
+	sdb.Finalize()
+	return nil
+}
+
+// BlockOverrides is a set of header fields to override.
+type BlockOverrides struct {
+	Number        *hexutil.Big    `json:"number"`
+	Time          *hexutil.Uint64 `json:"time"`
+	GasLimit      *hexutil.Uint64 `json:"gasLimit"`
+	FeeRecipient  *common.Address `json:"feeRecipient"`
+	PrevRandao    *common.Hash    `json:"prevRandao"`
+	BaseFeePerGas *hexutil.Big    `json:"baseFeePerGas"`
+}
+
+// Apply overrides the given header fields in the given header.
+func (o *BlockOverrides) Apply(header *types.Header) {
+	if o == nil {
+		return
+	}
+	if o.Number != nil {
+		header.Number = new(big.Int).Set(o.Number.ToInt())
+	}
+	if o.Time != nil {
+		header.Time = uint64(*o.Time)
+	}
+	if o.GasLimit != nil {
+		header.GasLimit = uint64(*o.GasLimit)
+	}
+	if o.FeeRecipient != nil {
+		header.Coinbase = *o.FeeRecipient
+	}
+	if o.PrevRandao != nil {
+		header.MixDigest = *o.PrevRandao
+	}
+	if o.BaseFeePerGas != nil {
+		header.BaseFee = new(big.Int).Set(o.BaseFeePerGas.ToInt())
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core/types"
+)
+
+// defaultPrivateTxMaxBlocks is the number of blocks a private transaction stays in the tx pool
+// when no max block number is given.
+const defaultPrivateTxMaxBlocks = 25
+
+// PrivateTransactionConfig is the configuration of the private transaction RPC API.
+type PrivateTransactionConfig struct {
+	// Enabled enables the private transaction RPC API.
+	Enabled bool `toml:""`
+	// Authenticated only serves the private transaction RPC API on the authenticated (JWT) RPC
+	// endpoint.
+	Authenticated bool `toml:""`
+	// MaxBlocks is the maximum number of blocks a private transaction stays in the tx pool. It
+	// defaults to 25.
+	MaxBlocks uint64 `toml:""`
+}
+
+// PrivateTransactionBackend is the collection of methods required to satisfy the private
+// transaction RPC API.
+type PrivateTransactionBackend interface {
+	CurrentHeader() *types.Header
+	SendPrivTx(context.Context, *types.Transaction, uint64) error
+	CancelPrivTx(context.Context, common.Hash) bool
+}
+
+// PrivateTransactionAPI is the collection of private transaction RPC API methods.
+type PrivateTransactionAPI interface {
+	SendPrivateTransaction(context.Context, PrivateTransactionArgs) (common.Hash, error)
+	CancelPrivateTransaction(context.Context, CancelPrivateTransactionArgs) (bool, error)
+}
+
+// PrivateTransactionArgs are the arguments of `eth_sendPrivateTransaction`.
+type PrivateTransactionArgs struct {
+	// Tx is the signed, RLP encoded transaction.
+	Tx hexutil.Bytes `json:"tx"`
+	// MaxBlockNumber is the highest block number the transaction can be included in. It defaults
+	// to the current block number plus the configured max blocks.
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber"`
+}
+
+// CancelPrivateTransactionArgs are the arguments of `eth_cancelPrivateTransaction`.
+type CancelPrivateTransactionArgs struct {
+	TxHash common.Hash `json:"txHash"`
+}
+
+// privateTransactionAPI offers the private transaction RPC API methods.
+type privateTransactionAPI struct {
+	b         PrivateTransactionBackend
+	maxBlocks uint64
+}
+
+// NewPrivateTransactionAPI creates a new private transaction API instance with the given config.
+func NewPrivateTransactionAPI(
+	b PrivateTransactionBackend, cfg *PrivateTransactionConfig,
+) PrivateTransactionAPI {
+	maxBlocks := cfg.MaxBlocks
+	if maxBlocks == 0 {
+		maxBlocks = defaultPrivateTxMaxBlocks
+	}
+	return &privateTransactionAPI{b, maxBlocks}
+}
+
+// SendPrivateTransaction adds the signed transaction to the local tx pool, without gossiping it
+// to peers, and returns its hash. The transaction is dropped if it is not included by the max
+// block number.
+func (api *privateTransactionAPI) SendPrivateTransaction(
+	ctx context.Context, args PrivateTransactionArgs,
+) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(args.Tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	current := api.b.CurrentHeader().Number.Uint64()
+	maxBlockNumber := current + api.maxBlocks
+	if args.MaxBlockNumber != nil {
+		switch requested := uint64(*args.MaxBlockNumber); {
+		case requested <= current:
+			return common.Hash{}, fmt.Errorf(
+				"max block number %d is not after the current block %d", requested, current,
+			)
+		case requested > maxBlockNumber:
+			return common.Hash{}, fmt.Errorf(
+				"max block number %d is more than %d blocks ahead", requested, api.maxBlocks,
+			)
+		default:
+			maxBlockNumber = requested
+		}
+	}
+
+	if err := api.b.SendPrivTx(ctx, tx, maxBlockNumber); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// CancelPrivateTransaction drops the private transaction with the given hash from the local tx
+// pool. It returns whether a pending private transaction was cancelled.
+func (api *privateTransactionAPI) CancelPrivateTransaction(
+	ctx context.Context, args CancelPrivateTransactionArgs,
+) (bool, error) {
+	if args.TxHash == (common.Hash{}) {
+		return false, errors.New("missing tx hash")
+	}
+	return api.b.CancelPrivTx(ctx, args.TxHash), nil
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+)
+
+// ProofBackend is the collection of methods required to satisfy the proof RPC API.
+type ProofBackend interface {
+	StateAndHeaderByNumberOrHash(
+		context.Context, rpc.BlockNumberOrHash,
+	) (vm.GethStateDB, *types.Header, error)
+	HeaderByNumber(context.Context, rpc.BlockNumber) (*types.Header, error)
+}
+
+// ProofAPI is the collection of proof RPC API methods.
+type ProofAPI interface {
+	GetProof(context.Context, common.Address, []string, rpc.BlockNumberOrHash) (*AccountResult, error)
+}
+
+// AccountResult is the result of `eth_getProof`. On top of the fields returned by geth, it holds
+// the root that the proofs are verified against, which is the root of the header of the block
+// following the queried one. Accounts do not have a storage root, so `StorageHash` is always the
+// zero hash.
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+	ProofRoot    common.Hash     `json:"proofRoot"`
+}
+
+// StorageResult is the proof of a storage slot.
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// prover is a statedb that is able to prove its state.
+type prover interface {
+	vm.GethStateDB
+	GetProof(common.Address) ([][]byte, error)
+	GetStorageProof(common.Address, common.Hash) ([][]byte, error)
+	GetProofRoot() (common.Hash, error)
+}
+
+// proofAPI offers proof RPC methods.
+type proofAPI struct {
+	b ProofBackend
+}
+
+// NewProofAPI creates a new proof API instance.
+func NewProofAPI(b ProofBackend) ProofAPI {
+	return &proofAPI{b}
+}
+
+// GetProof returns the account and storage values of the given address, along with their proofs,
+// at the given block. The state at the end of a block is only committed to in the header of the
+// next block, so the proofs of the latest block can not be verified and are not served.
+func (api *proofAPI) GetProof(
+	ctx context.Context, address common.Address, storageKeys []string,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (*AccountResult, error) {
+	statedb, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := statedb.(prover)
+	if !ok {
+		return nil, errors.New("proofs are not supported")
+	}
+
+	// The proofs must be verifiable against the root of the next header.
+	next, err := api.b.HeaderByNumber(ctx, rpc.BlockNumber(header.Number.Int64()+1))
+	if err != nil || next == nil {
+		return nil, fmt.Errorf(
+			"proofs of block %d can not be verified until the next block is committed",
+			header.Number.Uint64(),
+		)
+	}
+	root, err := p.GetProofRoot()
+	if err != nil {
+		return nil, err
+	}
+	if root != next.Root {
+		return nil, fmt.Errorf(
+			"proof root %s does not match the root %s of block %d",
+			root.Hex(), next.Root.Hex(), next.Number.Uint64(),
+		)
+	}
+
+	accountProof, err := p.GetProof(address)
+	if err != nil {
+		return nil, err
+	}
+	storageProof := make([]StorageResult, len(storageKeys))
+	for i, hexKey := range storageKeys {
+		key, err := decodeHash(hexKey)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := p.GetStorageProof(address, key)
+		if err != nil {
+			return nil, err
+		}
+		storageProof[i] = StorageResult{
+			Key:   hexKey,
+			Value: (*hexutil.Big)(p.GetState(address, key).Big()),
+			Proof: toHexSlice(proof),
+		}
+	}
+
+	return &AccountResult{
+		Address:      address,
+		AccountProof: toHexSlice(accountProof),
+		Balance:      (*hexutil.Big)(p.GetBalance(address)),
+		CodeHash:     p.GetCodeHash(address),
+		Nonce:        hexutil.Uint64(p.GetNonce(address)),
+		StorageProof: storageProof,
+		ProofRoot:    root,
+	}, nil
+}
+
+// decodeHash parses a hex-encoded 32-byte hash. The input may optionally be 0x-prefixed and
+// shorter than 32 bytes.
+func decodeHash(s string) (common.Hash, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	if (len(s) & 1) > 0 {
+		s = "0" + s
+	}
+	b, err := hexutil.Decode("0x" + s)
+	if err != nil {
+		return common.Hash{}, errors.New("hex string invalid")
+	}
+	if len(b) > common.HashLength {
+		return common.Hash{}, errors.New("hex string too long, want at most 32 bytes")
+	}
+	return common.BytesToHash(b), nil
+}
+
+// toHexSlice hex-encodes the given byte slices.
+func toHexSlice(b [][]byte) []string {
+	r := make([]string, len(b))
+	for i := range b {
+		r[i] = hexutil.Encode(b[i])
+	}
+	return r
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProofAPI", func() {
+	var (
+		b     *mockProofBackend
+		api   ProofAPI
+		alice = common.Address{1}
+		slot  = common.Hash{2}
+		root  = common.Hash{0xaa}
+	)
+
+	BeforeEach(func() {
+		b = &mockProofBackend{
+			sp: &mockProofStatePlugin{
+				mockTracerStatePlugin: &mockTracerStatePlugin{
+					balances: map[common.Address]*big.Int{alice: big.NewInt(7)},
+				},
+				root: root,
+			},
+			headers: map[int64]*types.Header{
+				1: {Number: big.NewInt(1)},
+				2: {Number: big.NewInt(2), Root: root},
+			},
+		}
+		api = NewProofAPI(b)
+	})
+
+	It("should return the proofs along with the root of the next header", func() {
+		res, err := api.GetProof(
+			context.Background(), alice, []string{"0x02"}, rpc.BlockNumberOrHashWithNumber(1),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.ProofRoot).To(Equal(root))
+		Expect(res.AccountProof).To(Equal([]string{hexutil.Encode(alice.Bytes())}))
+		Expect(res.Balance.ToInt()).To(Equal(big.NewInt(7)))
+		Expect(res.StorageHash).To(Equal(common.Hash{}))
+		Expect(res.StorageProof).To(HaveLen(1))
+		Expect(res.StorageProof[0].Key).To(Equal("0x02"))
+		Expect(res.StorageProof[0].Proof).To(Equal([]string{hexutil.Encode(slot.Bytes())}))
+	})
+
+	It("should refuse the proofs of the latest block", func() {
+		_, err := api.GetProof(
+			context.Background(), alice, nil, rpc.BlockNumberOrHashWithNumber(2),
+		)
+		Expect(err).To(MatchError(ContainSubstring("until the next block is committed")))
+	})
+
+	It("should refuse proofs that do not match the next header", func() {
+		b.headers[2].Root = common.Hash{0xbb}
+		_, err := api.GetProof(
+			context.Background(), alice, nil, rpc.BlockNumberOrHashWithNumber(1),
+		)
+		Expect(err).To(MatchError(ContainSubstring("does not match")))
+	})
+})
+
+// mockProofBackend serves the state of a provable plugin at any of its headers.
+type mockProofBackend struct {
+	sp      *mockProofStatePlugin
+	headers map[int64]*types.Header
+}
+
+func (b *mockProofBackend) StateAndHeaderByNumberOrHash(
+	_ context.Context, blockNrOrHash rpc.BlockNumberOrHash,
+) (vm.GethStateDB, *types.Header, error) {
+	number, _ := blockNrOrHash.Number()
+	return state.NewStateDB(state.NewQueryOverlay(b.sp)), b.headers[number.Int64()], nil
+}
+
+func (b *mockProofBackend) HeaderByNumber(
+	_ context.Context, number rpc.BlockNumber,
+) (*types.Header, error) {
+	if header, ok := b.headers[number.Int64()]; ok {
+		return header, nil
+	}
+	return nil, errors.New("header not found")
+}
+
+// mockProofStatePlugin is a state plugin that supports proofs.
+type mockProofStatePlugin struct {
+	*mockTracerStatePlugin
+	root common.Hash
+}
+
+func (sp *mockProofStatePlugin) GetProof(addr common.Address) ([][]byte, error) {
+	return [][]byte{addr.Bytes()}, nil
+}
+
+func (sp *mockProofStatePlugin) GetStorageProof(
+	_ common.Address, slot common.Hash,
+) ([][]byte, error) {
+	return [][]byte{slot.Bytes()}, nil
+}
+
+func (sp *mockProofStatePlugin) GetProofRoot() (common.Hash, error) {
+	return sp.root, nil
+}
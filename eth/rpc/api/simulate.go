@@ -0,0 +1,482 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+const (
+	// simulatedBlockInterval is the number of seconds between simulated blocks, unless the time
+	// of a block is overridden.
+	simulatedBlockInterval = 12
+	// maxSimulateBlocks is the maximum number of blocks that `eth_simulateV1` simulates, counted
+	// from the base block to the last simulated block, so that the number overrides can not skip
+	// past the limit.
+	maxSimulateBlocks = 256
+
+	// errCodeReverted is the error code of a simulated call that reverted.
+	errCodeReverted = 3
+	// errCodeVMError is the error code of a simulated call that failed with a VM error.
+	errCodeVMError = -32015
+)
+
+// CallBackend is the collection of methods required to execute calls on top of a block.
+type CallBackend interface {
+	StateAndHeaderByNumberOrHash(
+		context.Context, rpc.BlockNumberOrHash,
+	) (vm.GethStateDB, *types.Header, error)
+	GetEVM(
+		context.Context, *core.Message, vm.GethStateDB, *types.Header, *vm.Config,
+	) (*vm.GethEVM, func() error, error)
+	RPCGasCap() uint64
+	RPCEVMTimeout() time.Duration
+}
+
+// SimulateBackend is the collection of methods required to satisfy the simulation RPC API.
+type SimulateBackend interface {
+	CallBackend
+	ChainConfig() *params.ChainConfig
+}
+
+// SimulateAPI is the collection of RPC API methods that simulate a sequence of transactions or
+// calls on top of a block.
+type SimulateAPI interface {
+	CallBundle(context.Context, CallBundleArgs) (*CallBundleResult, error)
+	SimulateV1(
+		context.Context, SimulateOpts, *rpc.BlockNumberOrHash,
+	) ([]*SimulatedBlock, error)
+}
+
+// CallBundleArgs are the arguments of `eth_callBundle`.
+type CallBundleArgs struct {
+	Txs                    []hexutil.Bytes       `json:"txs"`
+	BlockNumber            rpc.BlockNumber       `json:"blockNumber"`
+	StateBlockNumberOrHash rpc.BlockNumberOrHash `json:"stateBlockNumber"`
+	Coinbase               *common.Address       `json:"coinbase"`
+	Timestamp              *hexutil.Uint64       `json:"timestamp"`
+	GasLimit               *hexutil.Uint64       `json:"gasLimit"`
+	BaseFee                *hexutil.Big          `json:"baseFee"`
+}
+
+// CallBundleResult is the result of `eth_callBundle`.
+type CallBundleResult struct {
+	BundleHash       common.Hash       `json:"bundleHash"`
+	CoinbaseDiff     *hexutil.Big      `json:"coinbaseDiff"`
+	GasFees          *hexutil.Big      `json:"gasFees"`
+	Results          []*BundleTxResult `json:"results"`
+	StateBlockNumber hexutil.Uint64    `json:"stateBlockNumber"`
+	TotalGasUsed     hexutil.Uint64    `json:"totalGasUsed"`
+}
+
+// BundleTxResult is the result of a single transaction of a bundle.
+type BundleTxResult struct {
+	TxHash      common.Hash     `json:"txHash"`
+	FromAddress common.Address  `json:"fromAddress"`
+	ToAddress   *common.Address `json:"toAddress"`
+	GasUsed     hexutil.Uint64  `json:"gasUsed"`
+	GasPrice    *hexutil.Big    `json:"gasPrice"`
+	Logs        []*types.Log    `json:"logs"`
+	ReturnData  hexutil.Bytes   `json:"returnData"`
+	Error       string          `json:"error,omitempty"`
+	Revert      string          `json:"revert,omitempty"`
+}
+
+// SimulateOpts are the options of `eth_simulateV1`.
+type SimulateOpts struct {
+	BlockStateCalls []SimulateBlock `json:"blockStateCalls"`
+	// Validation enables the nonce, balance and base fee checks of the calls.
+	Validation bool `json:"validation"`
+}
+
+// SimulateBlock is a block of calls to simulate, with the overrides that are applied before the
+// calls.
+type SimulateBlock struct {
+	BlockOverrides *BlockOverrides          `json:"blockOverrides"`
+	StateOverrides *StateOverride           `json:"stateOverrides"`
+	Calls          []ethapi.TransactionArgs `json:"calls"`
+}
+
+// SimulatedBlock is the result of a simulated block.
+type SimulatedBlock struct {
+	Number        hexutil.Uint64   `json:"number"`
+	Timestamp     hexutil.Uint64   `json:"timestamp"`
+	GasLimit      hexutil.Uint64   `json:"gasLimit"`
+	GasUsed       hexutil.Uint64   `json:"gasUsed"`
+	FeeRecipient  common.Address   `json:"feeRecipient"`
+	BaseFeePerGas *hexutil.Big     `json:"baseFeePerGas"`
+	Calls         []*SimulatedCall `json:"calls"`
+}
+
+// SimulatedCall is the result of a single simulated call.
+type SimulatedCall struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Logs       []*types.Log   `json:"logs"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	Status     hexutil.Uint64 `json:"status"`
+	Error      *SimulateError `json:"error,omitempty"`
+}
+
+// SimulateError is the error of a simulated call that failed.
+type SimulateError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// simulateAPI offers the simulation RPC methods.
+type simulateAPI struct {
+	b SimulateBackend
+}
+
+// NewSimulateAPI creates a new simulation API instance.
+func NewSimulateAPI(b SimulateBackend) SimulateAPI {
+	return &simulateAPI{b}
+}
+
+// CallBundle simulates the given signed transactions, in order, in a block on top of the state
+// block. The transactions are executed on a single statedb, so each transaction sees the state
+// changes of the transactions before it.
+func (api *simulateAPI) CallBundle(
+	ctx context.Context, args CallBundleArgs,
+) (*CallBundleResult, error) {
+	if len(args.Txs) == 0 {
+		return nil, errors.New("bundle missing txs")
+	}
+	txs := make(types.Transactions, len(args.Txs))
+	for i, encoded := range args.Txs {
+		txs[i] = new(types.Transaction)
+		if err := txs[i].UnmarshalBinary(encoded); err != nil {
+			return nil, fmt.Errorf("invalid tx %d: %w", i, err)
+		}
+	}
+
+	statedb, parent, err := stateAndHeader(ctx, api.b, args.StateBlockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	header := newSimulatedHeader(parent)
+	if args.BlockNumber > 0 {
+		header.Number = big.NewInt(args.BlockNumber.Int64())
+	}
+	(&BlockOverrides{
+		Time:          args.Timestamp,
+		GasLimit:      args.GasLimit,
+		FeeRecipient:  args.Coinbase,
+		BaseFeePerGas: args.BaseFee,
+	}).Apply(header)
+
+	ctx, cancel := withTimeout(ctx, api.b)
+	defer cancel()
+	sim := newSimulator(api.b, statedb, header, &vm.Config{})
+	signer := types.MakeSigner(api.b.ChainConfig(), header.Number)
+	coinbaseBalance := new(big.Int).Set(statedb.GetBalance(header.Coinbase))
+	res := &CallBundleResult{
+		Results:          make([]*BundleTxResult, len(txs)),
+		StateBlockNumber: hexutil.Uint64(parent.Number.Uint64()),
+	}
+	gasFees := new(big.Int)
+	var hashes []byte
+	for i, tx := range txs {
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx %d [%s]: %w", i, tx.Hash().Hex(), err)
+		}
+		result, logs, err := sim.apply(ctx, msg, tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("could not apply tx %d [%s]: %w", i, tx.Hash().Hex(), err)
+		}
+
+		tip := msg.GasPrice
+		if header.BaseFee != nil {
+			tip = new(big.Int).Sub(msg.GasPrice, header.BaseFee)
+		}
+		txResult := &BundleTxResult{
+			TxHash:      tx.Hash(),
+			FromAddress: msg.From,
+			ToAddress:   msg.To,
+			GasUsed:     hexutil.Uint64(result.UsedGas),
+			GasPrice:    (*hexutil.Big)(msg.GasPrice),
+			Logs:        logs,
+			ReturnData:  result.Return(),
+		}
+		if result.Err != nil {
+			txResult.Error = result.Err.Error()
+			txResult.ReturnData = result.Revert()
+			if reason, unpackErr := abi.UnpackRevert(result.Revert()); unpackErr == nil {
+				txResult.Revert = reason
+			}
+		}
+		res.Results[i] = txResult
+		res.TotalGasUsed += hexutil.Uint64(result.UsedGas)
+		gasFees.Add(gasFees, new(big.Int).Mul(tip, new(big.Int).SetUint64(result.UsedGas)))
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+
+	res.BundleHash = crypto.Keccak256Hash(hashes)
+	res.GasFees = (*hexutil.Big)(gasFees)
+	res.CoinbaseDiff = (*hexutil.Big)(
+		new(big.Int).Sub(statedb.GetBalance(header.Coinbase), coinbaseBalance),
+	)
+	return res, nil
+}
+
+// SimulateV1 simulates the given blocks of calls, in order, on top of the given block. Each
+// block is built on top of the previous one and all of the calls are executed on a single
+// statedb, so each call sees the state changes of the calls before it. At most
+// `maxSimulateBlocks` blocks are simulated, and the gas used by all of the calls is capped by
+// the rpc gas cap.
+func (api *simulateAPI) SimulateV1(
+	ctx context.Context, opts SimulateOpts, blockNrOrHash *rpc.BlockNumberOrHash,
+) ([]*SimulatedBlock, error) {
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, fmt.Errorf("too many blocks: have %d, max %d",
+			len(opts.BlockStateCalls), maxSimulateBlocks)
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	statedb, parent, err := stateAndHeader(ctx, api.b, *blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx, api.b)
+	defer cancel()
+	var (
+		results = make([]*SimulatedBlock, len(opts.BlockStateCalls))
+		maxNum  = new(big.Int).Add(parent.Number, big.NewInt(maxSimulateBlocks))
+		budget  = api.b.RPCGasCap()
+	)
+	if budget == 0 {
+		budget = math.MaxUint64
+	}
+	for i, block := range opts.BlockStateCalls {
+		header := newSimulatedHeader(parent)
+		block.BlockOverrides.Apply(header)
+		if header.Number.Cmp(parent.Number) <= 0 {
+			return nil, fmt.Errorf("block %d: block numbers must be increasing", i)
+		}
+		if header.Number.Cmp(maxNum) > 0 {
+			return nil, fmt.Errorf("block %d: too many blocks: number %v exceeds %v",
+				i, header.Number, maxNum)
+		}
+		if err = block.StateOverrides.Apply(statedb); err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		sim := newSimulator(api.b, statedb, header, &vm.Config{NoBaseFee: !opts.Validation})
+		result := &SimulatedBlock{
+			Number:        hexutil.Uint64(header.Number.Uint64()),
+			Timestamp:     hexutil.Uint64(header.Time),
+			GasLimit:      hexutil.Uint64(header.GasLimit),
+			FeeRecipient:  header.Coinbase,
+			BaseFeePerGas: (*hexutil.Big)(header.BaseFee),
+			Calls:         make([]*SimulatedCall, len(block.Calls)),
+		}
+		for j := range block.Calls {
+			call, err := api.simulateCall(ctx, sim, &block.Calls[j], opts.Validation, &budget)
+			if err != nil {
+				return nil, fmt.Errorf("block %d, call %d: %w", i, j, err)
+			}
+			result.Calls[j] = call
+			result.GasUsed += call.GasUsed
+		}
+		results[i] = result
+		parent = header
+	}
+	return results, nil
+}
+
+// simulateCall simulates a single call of `eth_simulateV1`. The gas of the call is capped by the
+// given gas budget, which the gas used by the call is deducted from.
+func (api *simulateAPI) simulateCall(
+	ctx context.Context, sim *simulator, args *ethapi.TransactionArgs, validation bool,
+	budget *uint64,
+) (*SimulatedCall, error) {
+	// By default, a call may use all of the gas left in the block, up to the gas budget.
+	if args.Gas == nil {
+		gas := hexutil.Uint64(sim.gasPool.Gas())
+		if uint64(gas) > *budget {
+			gas = hexutil.Uint64(*budget)
+		}
+		args.Gas = &gas
+	}
+	msg, err := args.ToMessage(*budget, sim.header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	if validation {
+		msg.SkipAccountChecks = false
+		if args.Nonce == nil {
+			msg.Nonce = sim.statedb.GetNonce(msg.From)
+		}
+	}
+
+	result, logs, err := sim.apply(ctx, msg, common.Hash{})
+	if err != nil {
+		return nil, err
+	}
+	*budget -= result.UsedGas
+	call := &SimulatedCall{
+		ReturnData: result.Return(),
+		Logs:       logs,
+		GasUsed:    hexutil.Uint64(result.UsedGas),
+		Status:     hexutil.Uint64(types.ReceiptStatusSuccessful),
+	}
+	if result.Err != nil {
+		call.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+		call.Error = &SimulateError{Code: errCodeVMError, Message: result.Err.Error()}
+		if errors.Is(result.Err, vm.ErrExecutionReverted) {
+			call.ReturnData = result.Revert()
+			call.Error = &SimulateError{
+				Code:    errCodeReverted,
+				Message: result.Err.Error(),
+				Data:    hexutil.Encode(result.Revert()),
+			}
+			if reason, unpackErr := abi.UnpackRevert(result.Revert()); unpackErr == nil {
+				call.Error.Message += ": " + reason
+			}
+		}
+	}
+	return call, nil
+}
+
+// stateAndHeader returns the statedb and header of the given block.
+func stateAndHeader(
+	ctx context.Context, b CallBackend, blockNrOrHash rpc.BlockNumberOrHash,
+) (vm.PolarisStateDB, *types.Header, error) {
+	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	statedb, ok := state.(vm.PolarisStateDB)
+	if !ok {
+		return nil, nil, vm.ErrStateDBNotSupported
+	}
+	return statedb, header, nil
+}
+
+// withTimeout returns the context that a call is run with, which is cancelled after the rpc evm
+// timeout.
+func withTimeout(ctx context.Context, b CallBackend) (context.Context, context.CancelFunc) {
+	if timeout := b.RPCEVMTimeout(); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// newSimulatedHeader returns the header of a simulated block on top of the given parent.
+func newSimulatedHeader(parent *types.Header) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   parent.Coinbase,
+		Difficulty: big.NewInt(0),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + simulatedBlockInterval,
+		Extra:      []byte{},
+	}
+	if parent.BaseFee != nil {
+		header.BaseFee = new(big.Int).Set(parent.BaseFee)
+	}
+	return header
+}
+
+// simulator executes messages, in order, on a single statedb in a simulated block.
+type simulator struct {
+	b        CallBackend
+	statedb  vm.PolarisStateDB
+	header   *types.Header
+	vmConfig *vm.Config
+	gasPool  *core.GasPool
+	txIndex  int
+}
+
+// newSimulator returns a simulator for the given block.
+func newSimulator(
+	b CallBackend, statedb vm.PolarisStateDB, header *types.Header, vmConfig *vm.Config,
+) *simulator {
+	return &simulator{
+		b:        b,
+		statedb:  statedb,
+		header:   header,
+		vmConfig: vmConfig,
+		gasPool:  new(core.GasPool).AddGas(header.GasLimit),
+	}
+}
+
+// apply executes the given message and finalizes its state changes, so that they are visible to
+// the messages after it. It returns the result of the execution and the logs it emitted.
+func (s *simulator) apply(
+	ctx context.Context, msg *core.Message, txHash common.Hash,
+) (*core.ExecutionResult, []*types.Log, error) {
+	evm, _, err := s.b.GetEVM(ctx, msg, s.statedb, s.header, s.vmConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Stop the execution once the context is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			evm.Cancel()
+		case <-done:
+		}
+	}()
+
+	s.statedb.Reset(txHash, s.txIndex)
+	result, err := core.ApplyMessage(evm, msg, s.gasPool)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, nil, fmt.Errorf("execution aborted (timeout = %v)", s.b.RPCEVMTimeout())
+	}
+
+	logs := s.statedb.Logs()
+	for _, log := range logs {
+		log.BlockNumber = s.header.Number.Uint64()
+	}
+	s.statedb.Finalize()
+	s.txIndex++
+	return result, logs, nil
+}
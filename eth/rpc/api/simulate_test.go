@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SimulateAPI", func() {
+	var (
+		b     *mockSimulateBackend
+		api   SimulateAPI
+		key   *ecdsa.PrivateKey
+		alice common.Address
+		bob   = common.Address{2}
+		base  = rpc.BlockNumberOrHashWithNumber(1)
+	)
+
+	newBackend := func(chainConfig *params.ChainConfig, baseFee *big.Int) *mockSimulateBackend {
+		return &mockSimulateBackend{
+			mockTracerBackend: &mockTracerBackend{
+				block: types.NewBlock(&types.Header{
+					Number:     big.NewInt(1),
+					GasLimit:   1e6,
+					BaseFee:    baseFee,
+					Difficulty: new(big.Int),
+				}, nil, nil, nil, trie.NewStackTrie(nil)),
+				chainConfig: chainConfig,
+				sp: &mockTracerStatePlugin{
+					balances: map[common.Address]*big.Int{alice: big.NewInt(1e18)},
+				},
+			},
+			gasCap: 1e7,
+		}
+	}
+
+	transfer := func() ethapi.TransactionArgs {
+		return ethapi.TransactionArgs{
+			From:  &alice,
+			To:    &bob,
+			Value: (*hexutil.Big)(big.NewInt(1000)),
+		}
+	}
+
+	BeforeEach(func() {
+		key, _ = crypto.GenerateEthKey()
+		alice = crypto.PubkeyToAddress(key.PublicKey)
+		b = newBackend(params.DefaultChainConfig, big.NewInt(1))
+		api = NewSimulateAPI(b)
+	})
+
+	It("should call a bundle on top of a block without a base fee", func() {
+		preLondon := *params.DefaultChainConfig
+		preLondon.LondonBlock = nil
+		b = newBackend(&preLondon, nil)
+		api = NewSimulateAPI(b)
+
+		tx := types.MustSignNewTx(key, types.LatestSignerForChainID(preLondon.ChainID),
+			&types.LegacyTx{Gas: params.TxGas, GasPrice: big.NewInt(3), To: &bob})
+		encoded, err := tx.MarshalBinary()
+		Expect(err).ToNot(HaveOccurred())
+
+		res, err := api.CallBundle(context.Background(), CallBundleArgs{
+			Txs:                    []hexutil.Bytes{encoded},
+			StateBlockNumberOrHash: base,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Results).To(HaveLen(1))
+		Expect(res.Results[0].Error).To(BeEmpty())
+		Expect(res.GasFees.ToInt()).To(Equal(big.NewInt(3 * int64(params.TxGas))))
+	})
+
+	It("should simulate calls in blocks built on top of each other", func() {
+		blocks, err := api.SimulateV1(context.Background(), SimulateOpts{
+			BlockStateCalls: []SimulateBlock{
+				{Calls: []ethapi.TransactionArgs{transfer()}},
+				{Calls: []ethapi.TransactionArgs{transfer()}},
+			},
+		}, &base)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(blocks).To(HaveLen(2))
+		for i, block := range blocks {
+			Expect(block.Number).To(Equal(hexutil.Uint64(i + 2)))
+			Expect(block.GasUsed).To(Equal(hexutil.Uint64(params.TxGas)))
+			Expect(block.Calls[0].Status).To(Equal(hexutil.Uint64(types.ReceiptStatusSuccessful)))
+		}
+	})
+
+	It("should limit the number of simulated blocks", func() {
+		_, err := api.SimulateV1(context.Background(), SimulateOpts{
+			BlockStateCalls: make([]SimulateBlock, maxSimulateBlocks+1),
+		}, &base)
+		Expect(err).To(MatchError(ContainSubstring("too many blocks")))
+
+		// the number overrides can not skip past the limit.
+		_, err = api.SimulateV1(context.Background(), SimulateOpts{
+			BlockStateCalls: []SimulateBlock{{BlockOverrides: &BlockOverrides{
+				Number: (*hexutil.Big)(big.NewInt(maxSimulateBlocks + 2)),
+			}}},
+		}, &base)
+		Expect(err).To(MatchError(ContainSubstring("too many blocks")))
+	})
+
+	It("should cap the gas used by all of the calls", func() {
+		b.gasCap = params.TxGas + params.TxGas/2
+		_, err := api.SimulateV1(context.Background(), SimulateOpts{
+			BlockStateCalls: []SimulateBlock{
+				{Calls: []ethapi.TransactionArgs{transfer()}},
+				{Calls: []ethapi.TransactionArgs{transfer()}},
+			},
+		}, &base)
+		Expect(err).To(MatchError(core.ErrIntrinsicGas))
+	})
+})
+
+// mockSimulateBackend serves a single block on top of an in-memory state, with a configurable
+// rpc gas cap.
+type mockSimulateBackend struct {
+	*mockTracerBackend
+	gasCap uint64
+}
+
+func (b *mockSimulateBackend) ChainConfig() *params.ChainConfig {
+	return b.chainConfig
+}
+
+func (b *mockSimulateBackend) RPCGasCap() uint64 {
+	return b.gasCap
+}
+
+func (b *mockSimulateBackend) RPCEVMTimeout() time.Duration {
+	return time.Second
+}
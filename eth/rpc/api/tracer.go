@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	// Register the built-in native (callTracer, prestateTracer, 4byteTracer) and JS tracers.
+	_ "github.com/ethereum/go-ethereum/eth/tracers/js"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+// defaultTraceTimeout is the amount of time a single transaction can execute by default before
+// being forcefully aborted.
+const defaultTraceTimeout = 5 * time.Second
+
+// TracerBackend is the collection of methods required to satisfy the debug tracing RPC API.
+type TracerBackend interface {
+	BlockByNumber(context.Context, rpc.BlockNumber) (*types.Block, error)
+	BlockByHash(context.Context, common.Hash) (*types.Block, error)
+	GetTransaction(
+		context.Context, common.Hash,
+	) (*types.Transaction, common.Hash, uint64, uint64, error)
+	StateAndHeaderByNumberOrHash(
+		context.Context, rpc.BlockNumberOrHash,
+	) (vm.GethStateDB, *types.Header, error)
+	ReplayAtTransaction(
+		context.Context, *types.Block, int,
+	) (*core.Message, core.Replay, error)
+	GetEVM(
+		context.Context, *core.Message, vm.GethStateDB, *types.Header, *vm.Config,
+	) (*vm.GethEVM, func() error, error)
+	ChainConfigAt(int64) (*params.ChainConfig, error)
+	RPCGasCap() uint64
+}
+
+// TracerAPI is the collection of debug tracing RPC API methods.
+type TracerAPI interface {
+	TraceTransaction(context.Context, common.Hash, *tracers.TraceConfig) (any, error)
+	TraceBlockByNumber(
+		context.Context, rpc.BlockNumber, *tracers.TraceConfig,
+	) ([]*TxTraceResult, error)
+	TraceBlockByHash(
+		context.Context, common.Hash, *tracers.TraceConfig,
+	) ([]*TxTraceResult, error)
+	TraceCall(
+		context.Context, ethapi.TransactionArgs, rpc.BlockNumberOrHash, *tracers.TraceConfig,
+	) (any, error)
+}
+
+// TxTraceResult is the result of a single transaction trace.
+type TxTraceResult struct {
+	Result any    `json:"result,omitempty"` // Trace results produced by the tracer
+	Error  string `json:"error,omitempty"`  // Trace failure produced by the tracer
+}
+
+// tracerAPI offers transaction tracing RPC methods.
+type tracerAPI struct {
+	b TracerBackend
+}
+
+// NewTracerAPI creates a new debug tracing API instance.
+func NewTracerAPI(b TracerBackend) TracerAPI {
+	return &tracerAPI{b}
+}
+
+// TraceTransaction returns the structured logs created during the execution of the EVM and
+// returns them as a JSON object.
+func (api *tracerAPI) TraceTransaction(
+	ctx context.Context, hash common.Hash, config *tracers.TraceConfig,
+) (any, error) {
+	_, blockHash, _, index, err := api.b.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	// It shouldn't happen in practice.
+	if blockHash == (common.Hash{}) {
+		return nil, errors.New("transaction not found")
+	}
+	block, err := api.b.BlockByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, replay, err := api.b.ReplayAtTransaction(ctx, block, int(index))
+	if err != nil {
+		return nil, err
+	}
+	txctx := &tracers.Context{
+		BlockHash: blockHash,
+		TxIndex:   int(index),
+		TxHash:    hash,
+	}
+	res, _, err := api.traceTx(ctx, msg, txctx, replay.StateDB(), config, replayEVM(msg, replay))
+	return res, err
+}
+
+// TraceBlockByNumber returns the structured logs created during the execution of the EVM and
+// returns them as a JSON object.
+func (api *tracerAPI) TraceBlockByNumber(
+	ctx context.Context, number rpc.BlockNumber, config *tracers.TraceConfig,
+) ([]*TxTraceResult, error) {
+	block, err := api.b.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlock(ctx, block, config)
+}
+
+// TraceBlockByHash returns the structured logs created during the execution of the EVM and
+// returns them as a JSON object.
+func (api *tracerAPI) TraceBlockByHash(
+	ctx context.Context, hash common.Hash, config *tracers.TraceConfig,
+) ([]*TxTraceResult, error) {
+	block, err := api.b.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlock(ctx, block, config)
+}
+
+// TraceCall lets you trace a given eth_call. It collects the structured logs created during the
+// execution of the EVM if the given transaction was added on top of the provided block and
+// returns them as a JSON object.
+func (api *tracerAPI) TraceCall(
+	ctx context.Context, args ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	config *tracers.TraceConfig,
+) (any, error) {
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	statedb, ok := state.(vm.PolarisStateDB)
+	if !ok {
+		return nil, vm.ErrStateDBNotSupported
+	}
+
+	msg, err := args.ToMessage(api.b.RPCGasCap(), header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	// The EVM is acquired through the backend, so that the stateful precompiles of the host chain
+	// are available during the trace.
+	res, _, err := api.traceTx(ctx, msg, new(tracers.Context), statedb, config,
+		func(vmConfig *vm.Config) (*vm.GethEVM, func() error, error) {
+			return api.b.GetEVM(ctx, msg, statedb, header, vmConfig)
+		},
+	)
+	return res, err
+}
+
+// traceBlock configures a new tracer according to the provided configuration, and executes all
+// the transactions contained within. The return value will be one item per transaction,
+// dependent on the requested tracer.
+func (api *tracerAPI) traceBlock(
+	ctx context.Context, block *types.Block, config *tracers.TraceConfig,
+) ([]*TxTraceResult, error) {
+	txs := block.Transactions()
+	results := make([]*TxTraceResult, len(txs))
+	if len(txs) == 0 {
+		return results, nil
+	}
+
+	// Load the state right before the first transaction in the block, all of the subsequent
+	// transactions are traced on top of it.
+	_, replay, err := api.b.ReplayAtTransaction(ctx, block, 0)
+	if err != nil {
+		return nil, err
+	}
+	statedb := replay.StateDB()
+
+	// The transactions are signed for the chain config that was active at the block.
+	chainConfig, err := api.b.ChainConfigAt(block.Number().Int64())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		header    = block.Header()
+		blockHash = block.Hash()
+		signer    = types.MakeSigner(chainConfig, block.Number())
+	)
+	for i, tx := range txs {
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		txctx := &tracers.Context{
+			BlockHash: blockHash,
+			TxIndex:   i,
+			TxHash:    tx.Hash(),
+		}
+		res, result, err := api.traceTx(ctx, msg, txctx, statedb, config, replayEVM(msg, replay))
+		if err != nil {
+			results[i] = &TxTraceResult{Error: err.Error()}
+		} else {
+			results[i] = &TxTraceResult{Result: res}
+		}
+		// Finalize the state so any modifications, including the base fee that the state
+		// processor credits to the base fee collector, are written to the statedb for the
+		// subsequent transactions.
+		if result != nil {
+			replay.Finalize(result)
+		} else {
+			statedb.Finalize()
+		}
+	}
+	return results, nil
+}
+
+// evmGetter returns the EVM that a message is traced with, along with a function that returns the
+// error of the vm, if any.
+type evmGetter func(*vm.Config) (*vm.GethEVM, func() error, error)
+
+// replayEVM returns the getter of the EVMs of the given replay that execute the given message,
+// which run the precompiles of the replay rather than the ones that process blocks.
+func replayEVM(msg *core.Message, replay core.Replay) evmGetter {
+	return func(vmConfig *vm.Config) (*vm.GethEVM, func() error, error) {
+		return replay.GetEVM(core.NewEVMTxContext(msg), vmConfig), replay.StateDB().Error, nil
+	}
+}
+
+// traceTx configures a new tracer according to the provided configuration, and executes the
+// given message on the provided statedb with an EVM of the given getter. The returned trace will
+// be tracer dependent, the execution result is returned if the message was applied.
+func (api *tracerAPI) traceTx(
+	ctx context.Context, msg *core.Message, txctx *tracers.Context,
+	statedb vm.PolarisStateDB, config *tracers.TraceConfig, getEVM evmGetter,
+) (any, *core.ExecutionResult, error) {
+	var (
+		tracer  tracers.Tracer
+		err     error
+		timeout = defaultTraceTimeout
+	)
+	if config == nil {
+		config = &tracers.TraceConfig{}
+	}
+
+	// Default tracer is the struct logger.
+	tracer = logger.NewStructLogger(config.Config)
+	if config.Tracer != nil {
+		tracer, err = tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	vmConfig := &vm.Config{Tracer: tracer, NoBaseFee: true}
+	evm, vmError, err := getEVM(vmConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Define a meaningful timeout of a single transaction trace.
+	if config.Timeout != nil {
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, nil, err
+		}
+	}
+	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
+	go func() {
+		<-deadlineCtx.Done()
+		if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
+			tracer.Stop(errors.New("execution timeout"))
+			// Stop evm execution. Note cancellation is not necessarily immediate.
+			evm.Cancel()
+		}
+	}()
+	defer cancel()
+
+	// Reset the statedb to clear out the journals of any previous execution.
+	statedb.Reset(txctx.TxHash, txctx.TxIndex)
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing failed: %w", err)
+	}
+	if err = vmError(); err != nil {
+		return nil, result, err
+	}
+	res, err := tracer.GetResult()
+	return res, result, err
+}
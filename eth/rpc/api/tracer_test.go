@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core"
+	"pkg.berachain.dev/polaris/eth/core/precompile"
+	"pkg.berachain.dev/polaris/eth/core/state"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracerAPI", func() {
+	var (
+		b     *mockTracerBackend
+		api   TracerAPI
+		alice common.Address
+		bob   = common.Address{2}
+	)
+
+	BeforeEach(func() {
+		key, _ := crypto.GenerateEthKey()
+		alice = crypto.PubkeyToAddress(key.PublicKey)
+
+		// The block was signed for a chain id that is no longer the current one.
+		historical := *params.DefaultChainConfig
+		historical.ChainID = big.NewInt(7)
+		signer := types.LatestSignerForChainID(historical.ChainID)
+		txs := make(types.Transactions, 2)
+		for i := range txs {
+			txs[i] = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+				ChainID:   historical.ChainID,
+				Nonce:     uint64(i),
+				GasTipCap: big.NewInt(1),
+				GasFeeCap: big.NewInt(2),
+				Gas:       params.TxGas,
+				To:        &bob,
+				Value:     big.NewInt(1000),
+			})
+		}
+
+		b = &mockTracerBackend{
+			block: types.NewBlock(&types.Header{
+				Number:     big.NewInt(1),
+				GasLimit:   1e6,
+				BaseFee:    big.NewInt(1),
+				Difficulty: new(big.Int),
+			}, txs, nil, nil, trie.NewStackTrie(nil)),
+			chainConfig: &historical,
+			sp: &mockTracerStatePlugin{
+				balances: map[common.Address]*big.Int{alice: big.NewInt(1e18)},
+			},
+		}
+		api = NewTracerAPI(b)
+	})
+
+	It("should recover the senders with the chain config of the block", func() {
+		results, err := api.TraceBlockByNumber(context.Background(), rpc.BlockNumber(1), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		for _, res := range results {
+			Expect(res.Error).To(BeEmpty())
+			Expect(res.Result).ToNot(BeNil())
+		}
+		Expect(b.configHeights).To(ConsistOf(int64(1)))
+
+		// the replay finalizes every transaction with its result.
+		Expect(b.replay.usedGas).To(Equal([]uint64{params.TxGas, params.TxGas}))
+	})
+
+	It("should return the error of the vm", func() {
+		b.vmErr = errors.New("state read failed")
+		_, err := api.TraceCall(context.Background(), ethapi.TransactionArgs{
+			From:  &alice,
+			To:    &bob,
+			Value: (*hexutil.Big)(big.NewInt(1000)),
+		}, rpc.BlockNumberOrHashWithNumber(1), nil)
+		Expect(err).To(MatchError(b.vmErr))
+	})
+})
+
+// mockTracerBackend serves a single block on top of an in-memory state.
+type mockTracerBackend struct {
+	block         *types.Block
+	chainConfig   *params.ChainConfig
+	sp            state.Plugin
+	vmErr         error
+	configHeights []int64
+	replay        *mockReplay
+}
+
+func (b *mockTracerBackend) BlockByNumber(context.Context, rpc.BlockNumber) (*types.Block, error) {
+	return b.block, nil
+}
+
+func (b *mockTracerBackend) BlockByHash(context.Context, common.Hash) (*types.Block, error) {
+	return b.block, nil
+}
+
+func (b *mockTracerBackend) GetTransaction(
+	context.Context, common.Hash,
+) (*types.Transaction, common.Hash, uint64, uint64, error) {
+	return nil, common.Hash{}, 0, 0, errors.New("not implemented")
+}
+
+func (b *mockTracerBackend) StateAndHeaderByNumberOrHash(
+	context.Context, rpc.BlockNumberOrHash,
+) (vm.GethStateDB, *types.Header, error) {
+	return state.NewStateDB(state.NewQueryOverlay(b.sp)), b.block.Header(), nil
+}
+
+func (b *mockTracerBackend) ReplayAtTransaction(
+	context.Context, *types.Block, int,
+) (*core.Message, core.Replay, error) {
+	b.replay = &mockReplay{b: b, statedb: state.NewStateDB(state.NewQueryOverlay(b.sp))}
+	return nil, b.replay, nil
+}
+
+func (b *mockTracerBackend) GetEVM(
+	_ context.Context, msg *core.Message, statedb vm.GethStateDB, header *types.Header,
+	vmConfig *vm.Config,
+) (*vm.GethEVM, func() error, error) {
+	author := header.Coinbase
+	return vm.NewGethEVMWithPrecompiles(
+		core.NewEVMBlockContext(header, b, &author), core.NewEVMTxContext(msg), statedb,
+		b.chainConfig, *vmConfig, precompile.NewDefaultPlugin(),
+	), func() error { return b.vmErr }, nil
+}
+
+func (b *mockTracerBackend) ChainConfigAt(number int64) (*params.ChainConfig, error) {
+	b.configHeights = append(b.configHeights, number)
+	return b.chainConfig, nil
+}
+
+func (b *mockTracerBackend) RPCGasCap() uint64 {
+	return 1e7
+}
+
+func (b *mockTracerBackend) Engine() consensus.Engine {
+	return nil
+}
+
+func (b *mockTracerBackend) GetHeader(common.Hash, uint64) *types.Header {
+	return b.block.Header()
+}
+
+// mockReplay replays the block of the backend on top of its in-memory state.
+type mockReplay struct {
+	b       *mockTracerBackend
+	statedb vm.PolarisStateDB
+	// usedGas is the gas used by each of the finalized transactions.
+	usedGas []uint64
+}
+
+func (r *mockReplay) StateDB() vm.PolarisStateDB {
+	return r.statedb
+}
+
+func (r *mockReplay) GetEVM(txContext vm.TxContext, vmConfig *vm.Config) *vm.GethEVM {
+	header := r.b.block.Header()
+	return vm.NewGethEVMWithPrecompiles(
+		core.NewEVMBlockContext(header, r.b, &header.Coinbase), txContext, r.statedb,
+		r.b.chainConfig, *vmConfig, precompile.NewDefaultPlugin(),
+	)
+}
+
+func (r *mockReplay) Finalize(result *core.ExecutionResult) {
+	r.usedGas = append(r.usedGas, result.UsedGas)
+	r.statedb.Finalize()
+}
+
+// mockTracerStatePlugin implements the reads of `state.Plugin` that are forwarded by the query
+// overlay.
+type mockTracerStatePlugin struct {
+	state.Plugin
+	balances map[common.Address]*big.Int
+	codes    map[common.Address][]byte
+}
+
+func (sp *mockTracerStatePlugin) RegistryKey() string {
+	return "state"
+}
+
+func (sp *mockTracerStatePlugin) GetContext() context.Context {
+	return context.Background()
+}
+
+func (sp *mockTracerStatePlugin) Exist(addr common.Address) bool {
+	_, ok := sp.balances[addr]
+	return ok || len(sp.codes[addr]) > 0
+}
+
+func (sp *mockTracerStatePlugin) Empty(addr common.Address) bool {
+	return !sp.Exist(addr)
+}
+
+func (sp *mockTracerStatePlugin) GetBalance(addr common.Address) *big.Int {
+	if balance, ok := sp.balances[addr]; ok {
+		return new(big.Int).Set(balance)
+	}
+	return new(big.Int)
+}
+
+func (sp *mockTracerStatePlugin) GetNonce(common.Address) uint64 {
+	return 0
+}
+
+func (sp *mockTracerStatePlugin) GetCode(addr common.Address) []byte {
+	return sp.codes[addr]
+}
+
+func (sp *mockTracerStatePlugin) GetCodeHash(addr common.Address) common.Hash {
+	if code, ok := sp.codes[addr]; ok {
+		return crypto.Keccak256Hash(code)
+	}
+	return common.Hash{}
+}
+
+func (sp *mockTracerStatePlugin) GetState(common.Address, common.Hash) common.Hash {
+	return common.Hash{}
+}
+
+func (sp *mockTracerStatePlugin) GetCommittedState(common.Address, common.Hash) common.Hash {
+	return common.Hash{}
+}
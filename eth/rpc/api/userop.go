@@ -0,0 +1,777 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"pkg.berachain.dev/polaris/eth/accounts/abi"
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+)
+
+const (
+	// userOpFixedGas is the gas of the transaction that bundles a user operation, which is paid by
+	// the user operation as it is bundled on its own.
+	userOpFixedGas = params.TxGas
+	// userOpOverheadGas is the gas that the entry point uses per user operation outside of its
+	// validation and execution.
+	userOpOverheadGas = 18300
+	// userOpZeroByteGas and userOpNonZeroByteGas are the calldata costs of a user operation.
+	userOpZeroByteGas    = 4
+	userOpNonZeroByteGas = 16
+
+	// defaultMaxUserOpsPerEntity is the default number of user operations of an unstaked entity
+	// that may be bundled and not included in a block yet, as defined by ERC-7562.
+	defaultMaxUserOpsPerEntity = 4
+
+	// maxTrackedUserOps is the number of sent user operations that are remembered for
+	// `eth_getUserOperationByHash` and `eth_getUserOperationReceipt`.
+	maxTrackedUserOps = 4096
+)
+
+// entryPointABI is the part of the ABI of the ERC-4337 (v0.6) entry point that the user operation
+// API uses.
+var entryPointABI = mustParseEntryPointABI()
+
+// userOperationEventID is the topic of the `UserOperationEvent` of the entry point.
+var userOperationEventID = entryPointABI.Events["UserOperationEvent"].ID
+
+// UserOperationConfig is the configuration of the user operation RPC API.
+type UserOperationConfig struct {
+	// Enabled enables the user operation RPC API.
+	Enabled bool `toml:""`
+	// EntryPoints are the addresses of the supported entry point contracts.
+	EntryPoints []common.Address `toml:""`
+	// BundlerKeyFile is the path of the file with the hex encoded private key that signs the
+	// transactions that bundle the user operations.
+	BundlerKeyFile string `toml:""`
+	// Beneficiary receives the gas payments of the user operations. It defaults to the bundler.
+	Beneficiary *common.Address `toml:""`
+	// Authenticated only serves the user operation RPC API on the authenticated (JWT) RPC
+	// endpoint, as the bundles that fail to be included are paid by the bundler key.
+	Authenticated bool `toml:""`
+	// MaxOpsPerEntity is the maximum number of user operations of an unstaked sender, factory or
+	// paymaster that are bundled and not included in a block yet. It defaults to 4.
+	MaxOpsPerEntity uint64 `toml:""`
+}
+
+// UserOperationBackend is the collection of methods required to satisfy the user operation RPC
+// API.
+type UserOperationBackend interface {
+	CallBackend
+	ChainConfig() *params.ChainConfig
+	SendTx(context.Context, *types.Transaction) error
+	GetPoolNonce(context.Context, common.Address) (uint64, error)
+	GetTransaction(
+		context.Context, common.Hash,
+	) (*types.Transaction, common.Hash, uint64, uint64, error)
+	GetReceipts(context.Context, common.Hash) (types.Receipts, error)
+}
+
+// UserOperationAPI is the collection of ERC-4337 bundler RPC API methods.
+type UserOperationAPI interface {
+	SendUserOperation(context.Context, UserOperation, common.Address) (common.Hash, error)
+	EstimateUserOperationGas(
+		context.Context, UserOperation, common.Address,
+	) (*UserOperationGasEstimate, error)
+	GetUserOperationByHash(context.Context, common.Hash) (*UserOperationByHash, error)
+	GetUserOperationReceipt(context.Context, common.Hash) (*UserOperationReceipt, error)
+	SupportedEntryPoints(context.Context) []common.Address
+}
+
+// UserOperation is an ERC-4337 (v0.6) user operation.
+type UserOperation struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+// UserOperationGasEstimate is the result of `eth_estimateUserOperationGas`.
+type UserOperationGasEstimate struct {
+	PreVerificationGas   *hexutil.Big `json:"preVerificationGas"`
+	VerificationGasLimit *hexutil.Big `json:"verificationGasLimit"`
+	CallGasLimit         *hexutil.Big `json:"callGasLimit"`
+}
+
+// UserOperationByHash is the result of `eth_getUserOperationByHash`. The block fields are nil
+// until the user operation is included in a block.
+type UserOperationByHash struct {
+	UserOperation   *UserOperation `json:"userOperation"`
+	EntryPoint      common.Address `json:"entryPoint"`
+	TransactionHash common.Hash    `json:"transactionHash"`
+	BlockHash       *common.Hash   `json:"blockHash"`
+	BlockNumber     *hexutil.Big   `json:"blockNumber"`
+}
+
+// UserOperationReceipt is the result of `eth_getUserOperationReceipt`.
+type UserOperationReceipt struct {
+	UserOpHash    common.Hash    `json:"userOpHash"`
+	EntryPoint    common.Address `json:"entryPoint"`
+	Sender        common.Address `json:"sender"`
+	Nonce         *hexutil.Big   `json:"nonce"`
+	Paymaster     common.Address `json:"paymaster"`
+	ActualGasCost *hexutil.Big   `json:"actualGasCost"`
+	ActualGasUsed *hexutil.Big   `json:"actualGasUsed"`
+	Success       bool           `json:"success"`
+	Logs          []*types.Log   `json:"logs"`
+	Receipt       *types.Receipt `json:"receipt"`
+}
+
+// packedUserOperation is a user operation in the form that is ABI encoded for the entry point.
+type packedUserOperation struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+// validationResult is the `ValidationResult` error that `simulateValidation` reverts with if the
+// user operation is valid.
+type validationResult struct {
+	ReturnInfo struct {
+		PreOpGas         *big.Int
+		Prefund          *big.Int
+		SigFailed        bool
+		ValidAfter       *big.Int
+		ValidUntil       *big.Int
+		PaymasterContext []byte
+	}
+	SenderInfo    stakeInfo
+	FactoryInfo   stakeInfo
+	PaymasterInfo stakeInfo
+}
+
+// stakeInfo is the stake of an entity of a user operation.
+type stakeInfo struct {
+	Stake           *big.Int
+	UnstakeDelaySec *big.Int
+}
+
+// staked returns whether the entity has a stake in the entry point.
+func (si *stakeInfo) staked() bool {
+	return si.Stake != nil && si.Stake.Sign() > 0 &&
+		si.UnstakeDelaySec != nil && si.UnstakeDelaySec.Sign() > 0
+}
+
+// sentUserOperation is a user operation that was bundled by the API.
+type sentUserOperation struct {
+	op         *UserOperation
+	entryPoint common.Address
+	txHash     common.Hash
+	// throttled are the unstaked entities of the user operation, which are throttled until the
+	// bundle is included in a block or dropped.
+	throttled []common.Address
+}
+
+// userOperationAPI offers the ERC-4337 bundler RPC methods. Every user operation is validated
+// with `simulateValidation` and bundled on its own in a `handleOps` transaction, which is signed
+// by the bundler key and sent to the transaction pool.
+type userOperationAPI struct {
+	b           UserOperationBackend
+	entryPoints []common.Address
+	key         *ecdsa.PrivateKey
+	bundler     common.Address
+	beneficiary common.Address
+	maxOps      uint64
+
+	// mu protects the fields below, and serializes the nonces of the bundler.
+	mu    sync.Mutex
+	sent  map[common.Hash]*sentUserOperation
+	order []common.Hash
+	// inflight are the bundled user operations that are not included in a block yet, and
+	// inflightOps is the number of them for each throttled entity.
+	inflight    []*sentUserOperation
+	inflightOps map[common.Address]uint64
+}
+
+// NewUserOperationAPI creates a new user operation API instance with the given config.
+func NewUserOperationAPI(
+	b UserOperationBackend, cfg *UserOperationConfig,
+) (UserOperationAPI, error) {
+	if len(cfg.EntryPoints) == 0 {
+		return nil, errors.New("no entry points configured")
+	}
+	key, err := crypto.LoadECDSA(cfg.BundlerKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the bundler key: %w", err)
+	}
+	api := &userOperationAPI{
+		b:           b,
+		entryPoints: cfg.EntryPoints,
+		key:         key,
+		bundler:     crypto.PubkeyToAddress(key.PublicKey),
+		maxOps:      cfg.MaxOpsPerEntity,
+		sent:        make(map[common.Hash]*sentUserOperation),
+		inflightOps: make(map[common.Address]uint64),
+	}
+	if api.maxOps == 0 {
+		api.maxOps = defaultMaxUserOpsPerEntity
+	}
+	api.beneficiary = api.bundler
+	if cfg.Beneficiary != nil {
+		api.beneficiary = *cfg.Beneficiary
+	}
+	return api, nil
+}
+
+// SupportedEntryPoints returns the addresses of the supported entry points.
+func (api *userOperationAPI) SupportedEntryPoints(context.Context) []common.Address {
+	return api.entryPoints
+}
+
+// SendUserOperation validates the given user operation, bundles it and sends the bundle to the
+// transaction pool. It returns the hash of the user operation. User operations of unstaked
+// entities that already have `MaxOpsPerEntity` bundles waiting to be included are rejected.
+func (api *userOperationAPI) SendUserOperation(
+	ctx context.Context, op UserOperation, entryPoint common.Address,
+) (common.Hash, error) {
+	if err := api.checkEntryPoint(entryPoint); err != nil {
+		return common.Hash{}, err
+	}
+	packed := op.pack()
+	res, err := api.simulateValidation(ctx, packed, entryPoint)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if res.ReturnInfo.SigFailed {
+		return common.Hash{}, errors.New("invalid user operation signature")
+	}
+
+	data, err := entryPointABI.Pack("handleOps", []packedUserOperation{*packed}, api.beneficiary)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	gas, err := api.estimateGas(ctx, api.bundler, entryPoint, data)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to estimate the bundle gas: %w", err)
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	sent := &sentUserOperation{op: &op, entryPoint: entryPoint}
+	for entity, info := range map[common.Address]stakeInfo{
+		packed.Sender:      res.SenderInfo,
+		packed.factory():   res.FactoryInfo,
+		packed.paymaster(): res.PaymasterInfo,
+	} {
+		if entity != (common.Address{}) && !info.staked() {
+			sent.throttled = append(sent.throttled, entity)
+		}
+	}
+	if err = api.throttle(ctx, sent.throttled); err != nil {
+		return common.Hash{}, err
+	}
+
+	nonce, err := api.b.GetPoolNonce(ctx, api.bundler)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	chainID := api.b.ChainConfig().ChainID
+	tx, err := types.SignNewTx(api.key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: packed.MaxPriorityFeePerGas,
+		GasFeeCap: packed.MaxFeePerGas,
+		Gas:       gas,
+		To:        &entryPoint,
+		Data:      data,
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err = api.b.SendTx(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	hash := packed.hash(entryPoint, chainID)
+	sent.txHash = tx.Hash()
+	api.track(hash, sent)
+	api.inflight = append(api.inflight, sent)
+	for _, entity := range sent.throttled {
+		api.inflightOps[entity]++
+	}
+	return hash, nil
+}
+
+// EstimateUserOperationGas estimates the gas limits of the given user operation. The verification
+// gas is measured with `simulateValidation` and the call gas is estimated by calling the sender
+// from the entry point.
+func (api *userOperationAPI) EstimateUserOperationGas(
+	ctx context.Context, op UserOperation, entryPoint common.Address,
+) (*UserOperationGasEstimate, error) {
+	if err := api.checkEntryPoint(entryPoint); err != nil {
+		return nil, err
+	}
+	packed := op.pack()
+	// Simulate without fees, so that the sender does not need a deposit, and with as much
+	// verification gas as a call may use.
+	packed.PreVerificationGas = packed.preVerificationGas()
+	packed.VerificationGasLimit = new(big.Int).SetUint64(api.b.RPCGasCap())
+	packed.MaxFeePerGas, packed.MaxPriorityFeePerGas = new(big.Int), new(big.Int)
+	res, err := api.simulateValidation(ctx, packed, entryPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	callGas, err := api.estimateGas(ctx, entryPoint, packed.Sender, packed.CallData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate the call gas: %w", err)
+	}
+	return &UserOperationGasEstimate{
+		PreVerificationGas: (*hexutil.Big)(packed.PreVerificationGas),
+		VerificationGasLimit: (*hexutil.Big)(
+			new(big.Int).Sub(res.ReturnInfo.PreOpGas, packed.PreVerificationGas),
+		),
+		CallGasLimit: (*hexutil.Big)(new(big.Int).SetUint64(callGas)),
+	}, nil
+}
+
+// GetUserOperationByHash returns the user operation with the given hash, if it was sent through
+// this API.
+func (api *userOperationAPI) GetUserOperationByHash(
+	ctx context.Context, hash common.Hash,
+) (*UserOperationByHash, error) {
+	sent := api.lookup(hash)
+	if sent == nil {
+		return nil, nil //nolint:nilnil // unknown user operations are returned as null.
+	}
+	res := &UserOperationByHash{
+		UserOperation:   sent.op,
+		EntryPoint:      sent.entryPoint,
+		TransactionHash: sent.txHash,
+	}
+	// The bundle is not found until it is in the transaction pool or in a block.
+	_, blockHash, blockNumber, _, err := api.b.GetTransaction(ctx, sent.txHash)
+	if err == nil && blockHash != (common.Hash{}) {
+		res.BlockHash = &blockHash
+		res.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
+	}
+	return res, nil
+}
+
+// GetUserOperationReceipt returns the receipt of the user operation with the given hash, if it
+// was sent through this API and its bundle is included in a block.
+func (api *userOperationAPI) GetUserOperationReceipt(
+	ctx context.Context, hash common.Hash,
+) (*UserOperationReceipt, error) {
+	sent := api.lookup(hash)
+	if sent == nil {
+		return nil, nil //nolint:nilnil // unknown user operations are returned as null.
+	}
+	_, blockHash, _, index, err := api.b.GetTransaction(ctx, sent.txHash)
+	if err != nil || blockHash == (common.Hash{}) {
+		return nil, nil //nolint:nilnil // pending user operations are returned as null.
+	}
+	receipts, err := api.b.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if index >= uint64(len(receipts)) {
+		return nil, core.ErrTxNotFound
+	}
+	receipt := receipts[index]
+
+	// The logs of the user operation are the ones that are emitted before its event.
+	for i, log := range receipt.Logs {
+		if log.Address != sent.entryPoint || len(log.Topics) != 4 ||
+			log.Topics[0] != userOperationEventID || log.Topics[1] != hash {
+			continue
+		}
+		values, err := entryPointABI.Unpack("UserOperationEvent", log.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &UserOperationReceipt{
+			UserOpHash:    hash,
+			EntryPoint:    sent.entryPoint,
+			Sender:        common.BytesToAddress(log.Topics[2].Bytes()),
+			Nonce:         (*hexutil.Big)(values[0].(*big.Int)),
+			Paymaster:     common.BytesToAddress(log.Topics[3].Bytes()),
+			Success:       values[1].(bool),
+			ActualGasCost: (*hexutil.Big)(values[2].(*big.Int)),
+			ActualGasUsed: (*hexutil.Big)(values[3].(*big.Int)),
+			Logs:          receipt.Logs[:i],
+			Receipt:       receipt,
+		}, nil
+	}
+	return nil, errors.New("user operation event not found in bundle receipt")
+}
+
+// simulateValidation runs `simulateValidation` of the given entry point for the given user
+// operation on top of the latest block. It returns an error if the validation fails or uses a
+// banned opcode.
+func (api *userOperationAPI) simulateValidation(
+	ctx context.Context, op *packedUserOperation, entryPoint common.Address,
+) (*validationResult, error) {
+	data, err := entryPointABI.Pack("simulateValidation", *op)
+	if err != nil {
+		return nil, err
+	}
+	statedb, header, err := stateAndHeader(
+		ctx, api.b, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := withTimeout(ctx, api.b)
+	defer cancel()
+
+	input := hexutil.Bytes(data)
+	args := ethapi.TransactionArgs{To: &entryPoint, Data: &input}
+	msg, err := args.ToMessage(api.b.RPCGasCap(), header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	tracer := newValidationTracer(op, entryPoint)
+	sim := newSimulator(api.b, statedb, header, &vm.Config{Tracer: tracer, NoBaseFee: true})
+	sim.gasPool = new(core.GasPool).AddGas(math.MaxUint64)
+	result, _, err := sim.apply(ctx, msg, common.Hash{})
+	if err != nil {
+		return nil, err
+	}
+	if tracer.err != nil {
+		return nil, tracer.err
+	}
+	res, err := unpackValidationResult(result.Revert())
+	if err != nil {
+		return nil, err
+	}
+	if err = tracer.checkStorage(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// estimateGas returns the lowest gas limit, up to the gas limit of the latest block and the rpc
+// gas cap, that allows the given call to succeed on top of the latest block.
+func (api *userOperationAPI) estimateGas(
+	ctx context.Context, from, to common.Address, data []byte,
+) (uint64, error) {
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	_, header, err := stateAndHeader(ctx, api.b, latest)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := withTimeout(ctx, api.b)
+	defer cancel()
+
+	hi := header.GasLimit
+	if gasCap := api.b.RPCGasCap(); gasCap != 0 && hi > gasCap {
+		hi = gasCap
+	}
+	// failed returns whether the call fails with the given gas limit. Every attempt runs on a new
+	// statedb of the latest block.
+	input := hexutil.Bytes(data)
+	failed := func(gas uint64) (bool, *core.ExecutionResult, error) {
+		statedb, _, err := stateAndHeader(ctx, api.b, latest)
+		if err != nil {
+			return true, nil, err
+		}
+		args := ethapi.TransactionArgs{
+			From: &from, To: &to, Data: &input, Gas: (*hexutil.Uint64)(&gas),
+		}
+		msg, err := args.ToMessage(0, header.BaseFee)
+		if err != nil {
+			return true, nil, err
+		}
+		sim := newSimulator(api.b, statedb, header, &vm.Config{NoBaseFee: true})
+		sim.gasPool = new(core.GasPool).AddGas(math.MaxUint64)
+		result, _, err := sim.apply(ctx, msg, common.Hash{})
+		switch {
+		case errors.Is(err, core.ErrIntrinsicGas):
+			return true, nil, nil
+		case err != nil:
+			return true, nil, err
+		}
+		return result.Failed(), result, nil
+	}
+
+	// Reject the call if it fails with the highest allowance, then binary search for the smallest
+	// gas limit that allows the call to succeed.
+	fail, result, err := failed(hi)
+	switch {
+	case err != nil:
+		return 0, err
+	case fail && result != nil && len(result.Revert()) > 0:
+		return 0, fmt.Errorf("%w: %s", result.Err, hexutil.Encode(result.Revert()))
+	case fail:
+		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+	for lo := params.TxGas - 1; lo+1 < hi; {
+		mid := (hi + lo) / 2 //nolint:gomnd // binary search.
+		if fail, _, err = failed(mid); err != nil {
+			return 0, err
+		}
+		if fail {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil
+}
+
+// checkEntryPoint returns an error if the given entry point is not supported.
+func (api *userOperationAPI) checkEntryPoint(entryPoint common.Address) error {
+	for _, supported := range api.entryPoints {
+		if supported == entryPoint {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported entry point %s", entryPoint.Hex())
+}
+
+// track remembers the given sent user operation, forgetting the oldest one once
+// `maxTrackedUserOps` are tracked. It must be called with the mutex held.
+func (api *userOperationAPI) track(hash common.Hash, sent *sentUserOperation) {
+	if len(api.order) == maxTrackedUserOps {
+		delete(api.sent, api.order[0])
+		api.order = api.order[1:]
+	}
+	api.sent[hash] = sent
+	api.order = append(api.order, hash)
+}
+
+// throttle returns an error if one of the given entities has `maxOps` bundled user operations
+// that are waiting to be included, after forgetting the bundles that were included in a block or
+// dropped from the transaction pool. It must be called with the mutex held.
+func (api *userOperationAPI) throttle(ctx context.Context, entities []common.Address) error {
+	inflight := api.inflight[:0]
+	for _, sent := range api.inflight {
+		tx, blockHash, _, _, err := api.b.GetTransaction(ctx, sent.txHash)
+		if err == nil && tx != nil && blockHash == (common.Hash{}) {
+			inflight = append(inflight, sent)
+			continue
+		}
+		for _, entity := range sent.throttled {
+			if api.inflightOps[entity]--; api.inflightOps[entity] == 0 {
+				delete(api.inflightOps, entity)
+			}
+		}
+	}
+	api.inflight = inflight
+
+	for _, entity := range entities {
+		if n := api.inflightOps[entity]; n >= api.maxOps {
+			return fmt.Errorf(
+				"user operation throttled: unstaked entity %s has %d pending user operations",
+				entity.Hex(), n,
+			)
+		}
+	}
+	return nil
+}
+
+// lookup returns the sent user operation with the given hash, or nil.
+func (api *userOperationAPI) lookup(hash common.Hash) *sentUserOperation {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.sent[hash]
+}
+
+// pack returns the given user operation in the form that is ABI encoded for the entry point.
+func (op *UserOperation) pack() *packedUserOperation {
+	return &packedUserOperation{
+		Sender:               op.Sender,
+		Nonce:                bigOrZero(op.Nonce),
+		InitCode:             op.InitCode,
+		CallData:             op.CallData,
+		CallGasLimit:         bigOrZero(op.CallGasLimit),
+		VerificationGasLimit: bigOrZero(op.VerificationGasLimit),
+		PreVerificationGas:   bigOrZero(op.PreVerificationGas),
+		MaxFeePerGas:         bigOrZero(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: bigOrZero(op.MaxPriorityFeePerGas),
+		PaymasterAndData:     op.PaymasterAndData,
+		Signature:            op.Signature,
+	}
+}
+
+// factory returns the factory of the user operation, or the zero address if it has none.
+func (op *packedUserOperation) factory() common.Address {
+	if len(op.InitCode) < common.AddressLength {
+		return common.Address{}
+	}
+	return common.BytesToAddress(op.InitCode[:common.AddressLength])
+}
+
+// paymaster returns the paymaster of the user operation, or the zero address if it has none.
+func (op *packedUserOperation) paymaster() common.Address {
+	if len(op.PaymasterAndData) < common.AddressLength {
+		return common.Address{}
+	}
+	return common.BytesToAddress(op.PaymasterAndData[:common.AddressLength])
+}
+
+// hash returns the hash of the user operation for the given entry point and chain, as returned
+// by `getUserOpHash` of the entry point.
+func (op *packedUserOperation) hash(entryPoint common.Address, chainID *big.Int) common.Hash {
+	// All of the fields are static, so the ABI encoding is the concatenation of their words.
+	encoded := bytes.Join([][]byte{
+		word(op.Sender.Bytes()),
+		word(op.Nonce.Bytes()),
+		crypto.Keccak256(op.InitCode),
+		crypto.Keccak256(op.CallData),
+		word(op.CallGasLimit.Bytes()),
+		word(op.VerificationGasLimit.Bytes()),
+		word(op.PreVerificationGas.Bytes()),
+		word(op.MaxFeePerGas.Bytes()),
+		word(op.MaxPriorityFeePerGas.Bytes()),
+		crypto.Keccak256(op.PaymasterAndData),
+	}, nil)
+	return crypto.Keccak256Hash(
+		crypto.Keccak256(encoded), word(entryPoint.Bytes()), word(chainID.Bytes()),
+	)
+}
+
+// preVerificationGas returns the gas that the user operation must pay for outside of its
+// validation and execution, when it is bundled on its own.
+func (op *packedUserOperation) preVerificationGas() *big.Int {
+	encoded, err := entryPointABI.Methods["simulateValidation"].Inputs.Pack(*op)
+	if err != nil {
+		// The user operation was packed before, so it can always be packed.
+		panic(err)
+	}
+	gas := userOpFixedGas + userOpOverheadGas
+	for _, b := range encoded {
+		if b == 0 {
+			gas += userOpZeroByteGas
+		} else {
+			gas += userOpNonZeroByteGas
+		}
+	}
+	return new(big.Int).SetUint64(gas)
+}
+
+// unpackValidationResult unpacks the revert data of `simulateValidation`.
+func unpackValidationResult(data []byte) (*validationResult, error) {
+	if len(data) < 4 {
+		return nil, errors.New("simulateValidation did not revert with a result")
+	}
+	selector, args := data[:4], data[4:]
+	if failedOp := entryPointABI.Errors["FailedOp"]; bytes.Equal(selector, failedOp.ID[:4]) {
+		values, err := failedOp.Inputs.Unpack(args)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("user operation validation failed: %v", values[1])
+	}
+	validation := entryPointABI.Errors["ValidationResult"]
+	if !bytes.Equal(selector, validation.ID[:4]) {
+		return nil, fmt.Errorf("unexpected simulateValidation result %s", hexutil.Encode(data))
+	}
+	values, err := validation.Inputs.Unpack(args)
+	if err != nil {
+		return nil, err
+	}
+	res := new(validationResult)
+	if err = validation.Inputs.Copy(res, values); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// word returns the given bytes left padded to an ABI word.
+func word(b []byte) []byte {
+	return common.LeftPadBytes(b, 32) //nolint:gomnd // word size.
+}
+
+// bigOrZero returns the given number, or zero if it is nil.
+func bigOrZero(n *hexutil.Big) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n.ToInt()
+}
+
+// mustParseEntryPointABI parses the ABI of the entry point.
+func mustParseEntryPointABI() abi.ABI {
+	const userOp = `{"name":"%s","type":"%s","components":[` +
+		`{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},` +
+		`{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},` +
+		`{"name":"callGasLimit","type":"uint256"},` +
+		`{"name":"verificationGasLimit","type":"uint256"},` +
+		`{"name":"preVerificationGas","type":"uint256"},` +
+		`{"name":"maxFeePerGas","type":"uint256"},` +
+		`{"name":"maxPriorityFeePerGas","type":"uint256"},` +
+		`{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}]}`
+	const stakeInfo = `{"name":"%s","type":"tuple","components":[` +
+		`{"name":"stake","type":"uint256"},{"name":"unstakeDelaySec","type":"uint256"}]}`
+	parsed, err := abi.JSON(strings.NewReader(`[` +
+		`{"type":"function","name":"simulateValidation","inputs":[` +
+		fmt.Sprintf(userOp, "userOp", "tuple") + `],"outputs":[]},` +
+		`{"type":"function","name":"handleOps","inputs":[` +
+		fmt.Sprintf(userOp, "ops", "tuple[]") +
+		`,{"name":"beneficiary","type":"address"}],"outputs":[]},` +
+		`{"type":"error","name":"FailedOp","inputs":[` +
+		`{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"}]},` +
+		`{"type":"error","name":"ValidationResult","inputs":[` +
+		`{"name":"returnInfo","type":"tuple","components":[` +
+		`{"name":"preOpGas","type":"uint256"},{"name":"prefund","type":"uint256"},` +
+		`{"name":"sigFailed","type":"bool"},{"name":"validAfter","type":"uint48"},` +
+		`{"name":"validUntil","type":"uint48"},{"name":"paymasterContext","type":"bytes"}]},` +
+		fmt.Sprintf(stakeInfo, "senderInfo") + `,` + fmt.Sprintf(stakeInfo, "factoryInfo") + `,` +
+		fmt.Sprintf(stakeInfo, "paymasterInfo") + `]},` +
+		`{"type":"event","name":"UserOperationEvent","anonymous":false,"inputs":[` +
+		`{"name":"userOpHash","type":"bytes32","indexed":true},` +
+		`{"name":"sender","type":"address","indexed":true},` +
+		`{"name":"paymaster","type":"address","indexed":true},` +
+		`{"name":"nonce","type":"uint256","indexed":false},` +
+		`{"name":"success","type":"bool","indexed":false},` +
+		`{"name":"actualGasCost","type":"uint256","indexed":false},` +
+		`{"name":"actualGasUsed","type":"uint256","indexed":false}]}` +
+		`]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/common/hexutil"
+	"pkg.berachain.dev/polaris/eth/core/types"
+	"pkg.berachain.dev/polaris/eth/crypto"
+	"pkg.berachain.dev/polaris/eth/params"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserOperationAPI", func() {
+	var (
+		b          *mockUserOperationBackend
+		sp         *mockTracerStatePlugin
+		api        UserOperationAPI
+		entryPoint = common.Address{0xe}
+		sender     = common.Address{0x5}
+		other      = common.Address{0x7}
+		ctx        = context.Background()
+	)
+
+	// call returns the code that calls the given address without value and input.
+	call := func(addr common.Address) []byte {
+		code := []byte{
+			byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+			byte(vm.PUSH1), 0, byte(vm.PUSH20),
+		}
+		code = append(code, addr.Bytes()...)
+		return append(code, byte(vm.GAS), byte(vm.CALL), byte(vm.POP))
+	}
+
+	// setEntryPoint deploys a stub entry point that calls the sender and reverts with a
+	// `ValidationResult` on `simulateValidation`, and does nothing on any other call.
+	setEntryPoint := func(senderStaked bool) {
+		res := new(validationResult)
+		res.ReturnInfo.PreOpGas, res.ReturnInfo.Prefund = big.NewInt(1e5), new(big.Int)
+		res.ReturnInfo.ValidAfter, res.ReturnInfo.ValidUntil = new(big.Int), new(big.Int)
+		res.ReturnInfo.PaymasterContext = []byte{}
+		unstaked := stakeInfo{Stake: new(big.Int), UnstakeDelaySec: new(big.Int)}
+		res.SenderInfo, res.FactoryInfo, res.PaymasterInfo = unstaked, unstaked, unstaked
+		if senderStaked {
+			res.SenderInfo = stakeInfo{Stake: big.NewInt(1e18), UnstakeDelaySec: big.NewInt(86400)}
+		}
+		validation := entryPointABI.Errors["ValidationResult"]
+		args, err := validation.Inputs.Pack(
+			res.ReturnInfo, res.SenderInfo, res.FactoryInfo, res.PaymasterInfo,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		revert := append(common.CopyBytes(validation.ID[:4]), args...)
+
+		selector := entryPointABI.Methods["simulateValidation"].ID
+		code := []byte{
+			byte(vm.PUSH1), 0, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0xe0, byte(vm.SHR),
+			byte(vm.PUSH4), selector[0], selector[1], selector[2], selector[3], byte(vm.EQ),
+			byte(vm.PUSH1), 16, byte(vm.JUMPI), byte(vm.STOP), byte(vm.JUMPDEST),
+		}
+		code = append(code, call(sender)...)
+		// The revert data is appended to the code, after the copy and revert.
+		size, offset := len(revert), len(code)+15
+		code = append(code,
+			byte(vm.PUSH2), byte(size>>8), byte(size), byte(vm.PUSH2), byte(offset>>8), byte(offset),
+			byte(vm.PUSH1), 0, byte(vm.CODECOPY),
+			byte(vm.PUSH2), byte(size>>8), byte(size), byte(vm.PUSH1), 0, byte(vm.REVERT),
+		)
+		sp.codes[entryPoint] = append(code, revert...)
+	}
+
+	userOp := func(nonce int64) UserOperation {
+		return UserOperation{Sender: sender, Nonce: (*hexutil.Big)(big.NewInt(nonce))}
+	}
+
+	BeforeEach(func() {
+		key, err := crypto.GenerateEthKey()
+		Expect(err).ToNot(HaveOccurred())
+		keyFile := filepath.Join(GinkgoT().TempDir(), "bundler.key")
+		Expect(os.WriteFile(
+			keyFile, []byte(hex.EncodeToString(crypto.FromECDSA(key))), 0o600,
+		)).To(Succeed())
+
+		sp = &mockTracerStatePlugin{codes: map[common.Address][]byte{
+			// the sender reads the storage of another contract.
+			sender: append(call(other), byte(vm.STOP)),
+			other:  {byte(vm.PUSH1), 0, byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP)},
+		}}
+		b = &mockUserOperationBackend{
+			mockSimulateBackend: &mockSimulateBackend{
+				mockTracerBackend: &mockTracerBackend{
+					block: types.NewBlock(&types.Header{
+						Number:     big.NewInt(1),
+						GasLimit:   1e6,
+						BaseFee:    big.NewInt(1),
+						Difficulty: new(big.Int),
+					}, nil, nil, nil, trie.NewStackTrie(nil)),
+					chainConfig: params.DefaultChainConfig,
+					sp:          sp,
+				},
+				gasCap: 1e7,
+			},
+			included: make(map[common.Hash]common.Hash),
+		}
+		setEntryPoint(true)
+		api, err = NewUserOperationAPI(b, &UserOperationConfig{
+			Enabled:         true,
+			EntryPoints:     []common.Address{entryPoint},
+			BundlerKeyFile:  keyFile,
+			MaxOpsPerEntity: 2,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should reject a validation that uses a banned opcode", func() {
+		sp.codes[sender] = []byte{byte(vm.TIMESTAMP), byte(vm.POP), byte(vm.STOP)}
+		_, err := api.SendUserOperation(ctx, userOp(0), entryPoint)
+		Expect(err).To(MatchError(ContainSubstring("banned opcode TIMESTAMP")))
+		Expect(b.txs).To(BeEmpty())
+	})
+
+	It("should only allow a staked entity to read the storage of other contracts", func() {
+		_, err := api.SendUserOperation(ctx, userOp(0), entryPoint)
+		Expect(err).ToNot(HaveOccurred())
+
+		setEntryPoint(false)
+		_, err = api.SendUserOperation(ctx, userOp(1), entryPoint)
+		Expect(err).To(MatchError(ContainSubstring("accesses storage slot")))
+		Expect(b.txs).To(HaveLen(1))
+	})
+
+	It("should allow an unstaked entity to access the storage associated with the sender", func() {
+		setEntryPoint(false)
+		// the other contract reads the slot `keccak(sender||0)`, i.e. `balances[sender]`.
+		code := append([]byte{byte(vm.PUSH20)}, sender.Bytes()...)
+		sp.codes[other] = append(code,
+			byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 0x40, byte(vm.PUSH1), 0,
+			byte(vm.KECCAK256), byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP),
+		)
+		_, err := api.SendUserOperation(ctx, userOp(0), entryPoint)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should bundle the user operation and track its inclusion", func() {
+		op := userOp(0)
+		hash, err := api.SendUserOperation(ctx, op, entryPoint)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(b.txs).To(HaveLen(1))
+		tx := b.txs[0]
+		Expect(*tx.To()).To(Equal(entryPoint))
+		Expect(tx.Data()[:4]).To(Equal(entryPointABI.Methods["handleOps"].ID))
+
+		res, err := api.GetUserOperationByHash(ctx, hash)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*res.UserOperation).To(Equal(op))
+		Expect(res.TransactionHash).To(Equal(tx.Hash()))
+		Expect(res.BlockHash).To(BeNil())
+
+		b.included[tx.Hash()] = common.Hash{1}
+		res, err = api.GetUserOperationByHash(ctx, hash)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*res.BlockHash).To(Equal(common.Hash{1}))
+	})
+
+	It("should throttle the user operations of unstaked entities until they are included", func() {
+		sp.codes[sender] = []byte{byte(vm.STOP)}
+		setEntryPoint(false)
+		for i := int64(0); i < 2; i++ {
+			_, err := api.SendUserOperation(ctx, userOp(i), entryPoint)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		_, err := api.SendUserOperation(ctx, userOp(2), entryPoint)
+		Expect(err).To(MatchError(ContainSubstring("throttled")))
+
+		b.included[b.txs[0].Hash()] = common.Hash{1}
+		_, err = api.SendUserOperation(ctx, userOp(2), entryPoint)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(b.txs).To(HaveLen(3))
+	})
+})
+
+// mockUserOperationBackend records the transactions that are sent to the transaction pool, which
+// are pending until they are marked as included.
+type mockUserOperationBackend struct {
+	*mockSimulateBackend
+	txs      []*types.Transaction
+	included map[common.Hash]common.Hash
+}
+
+func (b *mockUserOperationBackend) SendTx(_ context.Context, tx *types.Transaction) error {
+	b.txs = append(b.txs, tx)
+	return nil
+}
+
+func (b *mockUserOperationBackend) GetPoolNonce(context.Context, common.Address) (uint64, error) {
+	return uint64(len(b.txs)), nil
+}
+
+func (b *mockUserOperationBackend) GetTransaction(
+	_ context.Context, hash common.Hash,
+) (*types.Transaction, common.Hash, uint64, uint64, error) {
+	for _, tx := range b.txs {
+		if tx.Hash() == hash {
+			if blockHash, ok := b.included[hash]; ok {
+				return tx, blockHash, 1, 0, nil
+			}
+			return tx, common.Hash{}, 0, 0, nil
+		}
+	}
+	return nil, common.Hash{}, 0, 0, nil
+}
+
+func (b *mockUserOperationBackend) GetReceipts(
+	context.Context, common.Hash,
+) (types.Receipts, error) {
+	return nil, nil
+}
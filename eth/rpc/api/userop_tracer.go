@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core/vm"
+	"pkg.berachain.dev/polaris/eth/crypto"
+)
+
+// maxAssociatedSlotOffset is the number of slots after `keccak(A||x)` that are associated with
+// the address A, as defined by ERC-7562, so that the fields of a struct in a mapping of A are
+// associated with A.
+const maxAssociatedSlotOffset = 128
+
+// bannedValidationOpcodes are the opcodes that the validation of a user operation may not use, as
+// their result may differ between the simulation and the inclusion of the user operation.
+var bannedValidationOpcodes = map[string]struct{}{
+	"GASPRICE":     {},
+	"GASLIMIT":     {},
+	"DIFFICULTY":   {},
+	"TIMESTAMP":    {},
+	"BASEFEE":      {},
+	"BLOCKHASH":    {},
+	"NUMBER":       {},
+	"SELFBALANCE":  {},
+	"BALANCE":      {},
+	"ORIGIN":       {},
+	"CREATE":       {},
+	"COINBASE":     {},
+	"SELFDESTRUCT": {},
+}
+
+// callOpcodes are the opcodes that the `GAS` opcode may be followed by during validation.
+var callOpcodes = map[string]struct{}{
+	"CALL":         {},
+	"CALLCODE":     {},
+	"DELEGATECALL": {},
+	"STATICCALL":   {},
+}
+
+// Compile-time assertion that `validationTracer` is an `EVMLogger`.
+var _ vm.EVMLogger = (*validationTracer)(nil)
+
+// validationTracer is an `EVMLogger` that records the first banned opcode that is used below the
+// entry point, i.e. by the sender, factory or paymaster of a user operation, during
+// `simulateValidation`, along with the storage that is accessed below the entry point. The
+// storage accesses are checked against the storage rules of ERC-7562 with `checkStorage`, once
+// the stakes of the entities are known.
+type validationTracer struct {
+	// entryPoint, sender, factory and paymaster are the entities of the user operation. The
+	// factory and paymaster are the zero address if the user operation has none.
+	entryPoint, sender, factory, paymaster common.Address
+
+	// err is the first banned opcode that was used.
+	err error
+	// gasPending is set if the last opcode was `GAS`, which must be followed by a call.
+	gasPending bool
+
+	// frames are the contracts of the call stack below the entry point, indexed by depth-2.
+	frames []common.Address
+	// associated holds, for each entity, the hashes `keccak(A||x)` that were computed during the
+	// validation, where A is the address of the entity.
+	associated map[common.Address][]*big.Int
+	// accesses are the storage slots that were accessed below the entry point, in order.
+	accesses []storageAccess
+}
+
+// storageAccess is a storage slot that was accessed during the validation of a user operation.
+type storageAccess struct {
+	// entity is the entity whose validation accessed the slot, i.e. the closest entity in the call
+	// stack, or the sender if there is none.
+	entity common.Address
+	addr   common.Address
+	slot   common.Hash
+	write  bool
+}
+
+// newValidationTracer returns a `validationTracer` for the validation of the given user operation
+// by the given entry point.
+func newValidationTracer(op *packedUserOperation, entryPoint common.Address) *validationTracer {
+	return &validationTracer{
+		entryPoint: entryPoint,
+		sender:     op.Sender,
+		factory:    op.factory(),
+		paymaster:  op.paymaster(),
+		associated: make(map[common.Address][]*big.Int),
+	}
+}
+
+// CaptureState implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureState(
+	_ uint64, op vm.OpCode, _, _ uint64, scope *vm.ScopeContext, _ []byte, depth int, _ error,
+) {
+	// The entry point itself runs at depth 1 and may use any opcode.
+	if t.err != nil || depth <= 1 {
+		return
+	}
+	// Track the contracts of the call stack below the entry point, which starts at depth 2.
+	if len(t.frames) >= depth-1 {
+		t.frames = t.frames[:depth-2]
+	}
+	t.frames = append(t.frames, scope.Contract.Address())
+
+	name := op.String()
+	switch name {
+	case "SHA3", "KECCAK256":
+		t.captureKeccak(scope)
+	case "SLOAD", "SSTORE":
+		t.accesses = append(t.accesses, storageAccess{
+			entity: t.entity(),
+			addr:   scope.Contract.Address(),
+			slot:   common.Hash(scope.Stack.Back(0).Bytes32()),
+			write:  name == "SSTORE",
+		})
+	}
+
+	if t.gasPending {
+		t.gasPending = false
+		if _, ok := callOpcodes[name]; !ok {
+			t.ban("GAS", scope.Contract.Address())
+			return
+		}
+	}
+	if name == "GAS" {
+		t.gasPending = true
+		return
+	}
+	if _, ok := bannedValidationOpcodes[name]; ok {
+		t.ban(name, scope.Contract.Address())
+	}
+}
+
+// captureKeccak records the hash computed by the `KECCAK256` opcode that is about to run, if its
+// input starts with the address of an entity.
+func (t *validationTracer) captureKeccak(scope *vm.ScopeContext) {
+	offset, size := scope.Stack.Back(0), scope.Stack.Back(1)
+	if !offset.IsUint64() || !size.IsUint64() || size.Uint64() < common.HashLength {
+		return
+	}
+	// The memory is expanded after the opcode is traced, so the input is zero padded.
+	input := make([]byte, size.Uint64())
+	if data := scope.Memory.Data(); offset.Uint64() < uint64(len(data)) {
+		copy(input, data[offset.Uint64():])
+	}
+	for _, entity := range []common.Address{t.sender, t.factory, t.paymaster} {
+		if entity != (common.Address{}) &&
+			bytes.Equal(input[:common.HashLength], common.BytesToHash(entity.Bytes()).Bytes()) {
+			t.associated[entity] = append(
+				t.associated[entity], crypto.Keccak256Hash(input).Big(),
+			)
+		}
+	}
+}
+
+// entity returns the closest entity in the call stack, or the sender if there is none.
+func (t *validationTracer) entity() common.Address {
+	for i := len(t.frames) - 1; i >= 0; i-- {
+		switch addr := t.frames[i]; addr {
+		case t.sender, t.factory, t.paymaster:
+			if addr != (common.Address{}) {
+				return addr
+			}
+		}
+	}
+	return t.sender
+}
+
+// checkStorage checks the storage that was accessed during the validation against the storage
+// rules of ERC-7562, given the stakes of the entities:
+//   - the storage of the sender, and the storage that is associated with the sender in any
+//     contract, may be accessed by every entity;
+//   - a staked entity may also access its own storage and the storage that is associated with it,
+//     and read the storage of any other contract.
+func (t *validationTracer) checkStorage(res *validationResult) error {
+	staked := map[common.Address]bool{
+		t.sender:    res.SenderInfo.staked(),
+		t.factory:   res.FactoryInfo.staked(),
+		t.paymaster: res.PaymasterInfo.staked(),
+	}
+	for _, access := range t.accesses {
+		switch {
+		case access.addr == t.sender, t.isAssociated(t.sender, access.slot):
+			continue
+		case staked[access.entity] && (access.addr == access.entity ||
+			t.isAssociated(access.entity, access.slot) || !access.write):
+			continue
+		}
+		return fmt.Errorf(
+			"user operation validation by unstaked entity %s accesses storage slot %s of %s",
+			access.entity.Hex(), access.slot.Hex(), access.addr.Hex(),
+		)
+	}
+	return nil
+}
+
+// isAssociated returns whether the given slot is associated with the given address, i.e. it is the
+// address itself, or it is at most `maxAssociatedSlotOffset` slots after `keccak(A||x)`.
+func (t *validationTracer) isAssociated(addr common.Address, slot common.Hash) bool {
+	if slot == common.BytesToHash(addr.Bytes()) {
+		return true
+	}
+	n := slot.Big()
+	for _, base := range t.associated[addr] {
+		if offset := new(big.Int).Sub(n, base); offset.Sign() >= 0 &&
+			offset.Cmp(big.NewInt(maxAssociatedSlotOffset)) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ban records that the given opcode was used by the given contract.
+func (t *validationTracer) ban(op string, addr common.Address) {
+	t.err = fmt.Errorf("user operation validation uses banned opcode %s in %s", op, addr.Hex())
+}
+
+// CaptureTxStart implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureTxStart(uint64) {}
+
+// CaptureTxEnd implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureTxEnd(uint64) {}
+
+// CaptureStart implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureStart(
+	*vm.GethEVM, common.Address, common.Address, bool, []byte, uint64, *big.Int,
+) {
+}
+
+// CaptureEnd implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureEnd([]byte, uint64, error) {}
+
+// CaptureEnter implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureEnter(
+	vm.OpCode, common.Address, common.Address, []byte, uint64, *big.Int,
+) {
+}
+
+// CaptureExit implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureExit([]byte, uint64, error) {}
+
+// CaptureFault implements `vm.EVMLogger`.
+func (t *validationTracer) CaptureFault(
+	uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, int, error,
+) {
+}
@@ -54,6 +54,10 @@ import (
 type PolarisBackend interface {
 	Backend
 	rpcapi.NetBackend
+	rpcapi.TracerBackend
+	rpcapi.SimulateBackend
+	rpcapi.UserOperationBackend
+	rpcapi.PrivateTransactionBackend
 }
 
 // backend represents the backend for the JSON-RPC service.
@@ -155,10 +159,14 @@ func (b *backend) UnprotectedAllowed() bool {
 // Blockchain API
 // ==============================================================================
 
-// SetHead is used for state sync on ethereum, we leave state sync up to the host
-// chain and thus it is not implemented in Polaris.
+// SetHead rewinds the chain to the given block number. State sync is left up to the host chain,
+// so this is only intended for rolling back devnets and recovering from incidents.
 func (b *backend) SetHead(number uint64) {
-	panic("not implemented")
+	if err := b.chain.SetHead(int64(number)); err != nil {
+		b.logger.Error("eth.rpc.backend.SetHead", "number", number, "err", err)
+		return
+	}
+	b.logger.Info("called eth.rpc.backend.SetHead", "number", number)
 }
 
 // HeaderByNumber returns the block header at the given block number.
@@ -261,6 +269,9 @@ func (b *backend) BlockByNumberOrHash(_ context.Context,
 func (b *backend) StateAndHeaderByNumber(
 	_ context.Context, number BlockNumber,
 ) (vm.GethStateDB, *types.Header, error) {
+	if number == PendingBlockNumber {
+		return b.pendingStateAndHeader()
+	}
 	state, err := b.chain.GetStateByNumber(number.Int64())
 	if err != nil {
 		b.logger.Error("eth.rpc.backend.StateAndHeaderByNumber", "number", number, "err", err)
@@ -284,6 +295,9 @@ func (b *backend) StateAndHeaderByNumberOrHash(
 	var hash common.Hash
 	var block *types.Block
 	if inputNum, ok := blockNrOrHash.Number(); ok {
+		if inputNum == PendingBlockNumber {
+			return b.pendingStateAndHeader()
+		}
 		// Try to resolve by block number first.
 		number = inputNum.Int64()
 		block, err = b.polarisBlockByNumber(inputNum)
@@ -317,10 +331,21 @@ func (b *backend) StateAndHeaderByNumberOrHash(
 	return state, block.Header(), nil
 }
 
-// PendingBlockAndReceipts returns the pending block (equivalent to current block in Polaris)
-// and associated receipts.
+// pendingStateAndHeader returns the state after the pending block and the pending header.
+func (b *backend) pendingStateAndHeader() (vm.GethStateDB, *types.Header, error) {
+	state, header, err := b.chain.PendingStateAndHeader()
+	if err != nil {
+		b.logger.Error("eth.rpc.backend.pendingStateAndHeader", "err", err)
+		return nil, nil, err
+	}
+	b.logger.Info("called eth.rpc.backend.pendingStateAndHeader", "header", header)
+	return state, header, nil
+}
+
+// PendingBlockAndReceipts returns the pending block, which includes the transactions of the tx
+// pool, and associated receipts.
 func (b *backend) PendingBlockAndReceipts() (*types.Block, types.Receipts) {
-	block, receipts, err := b.chain.CurrentBlockAndReceipts()
+	block, receipts, err := b.chain.PendingBlockAndReceipts()
 	if err != nil {
 		b.logger.Error("eth.rpc.backend.PendingBlockAndReceipts", "err", err)
 		return nil, nil
@@ -363,10 +388,48 @@ func (b *backend) GetEVM(ctx context.Context, msg *core.Message, state vm.GethSt
 	}
 	txContext := core.NewEVMTxContext(msg)
 	b.logger.Info("called eth.rpc.backend.GetEVM", "header", header, "txContext", txContext, "vmConfig", vmConfig)
-	gethEVM := b.chain.GetEVM(ctx, txContext, utils.MustGetAs[vm.PolarisStateDB](state), header, vmConfig)
+	gethEVM, err := b.chain.GetEVM(
+		ctx, txContext, utils.MustGetAs[vm.PolarisStateDB](state), header, vmConfig,
+	)
+	if err != nil {
+		b.logger.Error("eth.rpc.backend.GetEVM", "err", err)
+		return nil, nil, err
+	}
 	return gethEVM, state.Error, nil
 }
 
+// StateAtTransaction returns the message of the transaction at the given index in the block, as
+// well as the state right before the transaction is executed. It is used for tracing.
+func (b *backend) StateAtTransaction(
+	ctx context.Context, block *types.Block, txIndex int,
+) (*core.Message, vm.PolarisStateDB, error) {
+	msg, statedb, err := b.chain.StateAtTransaction(ctx, block, txIndex)
+	if err != nil {
+		b.logger.Error("eth.rpc.backend.StateAtTransaction", "block", block.Hash(),
+			"tx_index", txIndex, "err", err)
+		return nil, nil, err
+	}
+	b.logger.Info("called eth.rpc.backend.StateAtTransaction", "block", block.Hash(),
+		"tx_index", txIndex)
+	return msg, statedb, nil
+}
+
+// ReplayAtTransaction returns the message of the transaction at the given index in the block, as
+// well as a replay of the block up to the transaction. It is used for tracing.
+func (b *backend) ReplayAtTransaction(
+	ctx context.Context, block *types.Block, txIndex int,
+) (*core.Message, core.Replay, error) {
+	msg, replay, err := b.chain.ReplayAtTransaction(ctx, block, txIndex)
+	if err != nil {
+		b.logger.Error("eth.rpc.backend.ReplayAtTransaction", "block", block.Hash(),
+			"tx_index", txIndex, "err", err)
+		return nil, nil, err
+	}
+	b.logger.Info("called eth.rpc.backend.ReplayAtTransaction", "block", block.Hash(),
+		"tx_index", txIndex)
+	return msg, replay, nil
+}
+
 func (b *backend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
 	b.logger.Info("called eth.rpc.backend.SubscribeChainEvent", "ch", ch)
 	return b.chain.SubscribeChainEvent(ch)
@@ -390,6 +453,18 @@ func (b *backend) SendTx(ctx context.Context, signedTx *types.Transaction) error
 	return b.chain.SendTx(ctx, signedTx)
 }
 
+func (b *backend) SendPrivTx(
+	ctx context.Context, signedTx *types.Transaction, maxBlockNumber uint64,
+) error {
+	b.logger.Info("called eth.rpc.backend.SendPrivTx", "tx_hash", signedTx.Hash())
+	return b.chain.SendPrivTx(ctx, signedTx, maxBlockNumber)
+}
+
+func (b *backend) CancelPrivTx(ctx context.Context, hash common.Hash) bool {
+	b.logger.Info("called eth.rpc.backend.CancelPrivTx", "tx_hash", hash)
+	return b.chain.CancelPrivTx(ctx, hash)
+}
+
 func (b *backend) GetTransaction(
 	_ context.Context, txHash common.Hash,
 ) (*types.Transaction, common.Hash, uint64, uint64, error) {
@@ -407,33 +482,46 @@ func (b *backend) GetPoolTransaction(txHash common.Hash) *types.Transaction {
 	return b.chain.GetPoolTransaction(txHash)
 }
 
+// GetPoolNonce returns the next nonce of the given address, including its pending transactions
+// in the transaction pool.
 func (b *backend) GetPoolNonce(_ context.Context, addr common.Address) (uint64, error) {
-	// TODO: get pool nonce, then fallback to statedb.
-	return b.chain.GetPoolNonce(addr)
+	nonce, err := b.chain.GetPoolNonce(addr)
+	if err != nil {
+		b.logger.Error("eth.rpc.backend.GetPoolNonce", "addr", addr, "err", err)
+		return 0, err
+	}
+	b.logger.Info("called eth.rpc.backend.GetPoolNonce", "addr", addr, "nonce", nonce)
+	return nonce, nil
 }
 
+// Stats returns the number of pending and queued transactions in the transaction pool.
 func (b *backend) Stats() (int, int) {
-	pending := 0
-	queued := 0
-	// TODO: Implement your code here
+	pending, queued := b.chain.GetPoolStats()
+	b.logger.Info("called eth.rpc.backend.Stats", "pending", pending, "queued", queued)
 	return pending, queued
 }
 
+// TxPoolContent returns the pending and queued transactions of the transaction pool, grouped by
+// sender.
 func (b *backend) TxPoolContent() (map[common.Address]types.Transactions,
 	map[common.Address]types.Transactions) {
-	// TODO: Implement your code here
-	return nil, nil
+	b.logger.Info("called eth.rpc.backend.TxPoolContent")
+	return b.chain.GetPoolContent()
 }
 
+// TxPoolContentFrom returns the pending and queued transactions of the given sender in the
+// transaction pool.
 func (b *backend) TxPoolContentFrom(addr common.Address,
 ) (types.Transactions, types.Transactions) {
-	// TODO: Implement your code here
-	return nil, nil
+	b.logger.Info("called eth.rpc.backend.TxPoolContentFrom", "addr", addr)
+	return b.chain.GetPoolContentFrom(addr)
 }
 
-func (b *backend) SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription {
-	// TODO: Implement your code here
-	return nil
+// SubscribeNewTxsEvent registers a subscription for new transactions entering the transaction
+// pool.
+func (b *backend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
+	b.logger.Info("called eth.rpc.backend.SubscribeNewTxsEvent", "ch", ch)
+	return b.chain.SubscribeNewTxsEvent(ch)
 }
 
 // ChainConfig returns the chain configuration.
@@ -442,6 +530,12 @@ func (b *backend) ChainConfig() *params.ChainConfig {
 	return b.chain.ChainConfig()
 }
 
+// ChainConfigAt returns the chain config that was active at the given block number.
+func (b *backend) ChainConfigAt(number int64) (*params.ChainConfig, error) {
+	b.logger.Info("called eth.rpc.backend.ChainConfigAt", "number", number)
+	return b.chain.ChainConfigAt(number)
+}
+
 func (b *backend) Engine() consensus.Engine {
 	panic("not implemented")
 }
@@ -494,13 +588,17 @@ func (b *backend) SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscr
 	return b.chain.SubscribePendingLogsEvent(ch)
 }
 
+// BloomStatus returns the section size and the number of fully indexed bloom bits sections.
 func (b *backend) BloomStatus() (uint64, uint64) {
-	// TODO: Implement your code here
-	return 0, 0
+	size, sections := b.chain.BloomStatus()
+	b.logger.Info("called eth.rpc.backend.BloomStatus", "size", size, "sections", sections)
+	return size, sections
 }
 
-func (b *backend) ServiceFilter(_ context.Context, session *bloombits.MatcherSession) {
-	// TODO: Implement your code here
+// ServiceFilter services the bloom bits retrievals of the given matcher session.
+func (b *backend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {
+	b.logger.Info("called eth.rpc.backend.ServiceFilter")
+	b.chain.ServiceFilter(ctx, session)
 }
 
 func (b *backend) Version() string {
@@ -567,7 +665,10 @@ func (b *backend) polarisBlockByNumber(number BlockNumber) (*types.Block, error)
 	switch number { //nolint:nolintlint,exhaustive // golangci-lint bug?
 	case SafeBlockNumber, FinalizedBlockNumber:
 		return b.chain.FinalizedBlock()
-	case PendingBlockNumber, LatestBlockNumber:
+	case PendingBlockNumber:
+		block, _, err := b.chain.PendingBlockAndReceipts()
+		return block, err
+	case LatestBlockNumber:
 		return b.chain.CurrentBlock()
 	default:
 		// CONTRACT: GetPolarisBlockByNumber receives number >=0
@@ -25,19 +25,29 @@ import (
 	"pkg.berachain.dev/polaris/playground/pkg/plugins"
 )
 
-// The playground chain implements the polaris host chain interface.
-var _ core.PolarisHostChain = (*Playground)(nil)
+// The playground chain implements the polaris host chain interface and can be rewound for
+// snapshot/revert style testing.
+var (
+	_ core.PolarisHostChain    = (*Playground)(nil)
+	_ core.RewindableHostChain = (*Playground)(nil)
+)
 
 // Playground is the playground chain.
 type Playground struct {
 	blockProducer *blockProducer
 	mempool       MempoolReader
+
+	sp  core.StatePlugin
+	txp core.TxPoolPlugin
 }
 
 // NewPlayground creates a new playground chain.
 func NewPlayground(mempool MempoolReader) *Playground {
+	sp := plugins.NewStatePlugin()
 	playground := &Playground{
 		mempool: mempool,
+		sp:      sp,
+		txp:     plugins.NewTxPoolPlugin(sp),
 	}
 	playground.blockProducer = &blockProducer{
 		polaris: core.NewChain(playground),
@@ -49,6 +59,18 @@ func (p *Playground) ProduceBlock() error {
 	return p.blockProducer.ProduceBlock()
 }
 
+// SetHead rewinds the playground chain to the given block number, reverting all of the blocks
+// produced after it.
+func (p *Playground) SetHead(number int64) error {
+	return p.blockProducer.polaris.SetHead(number)
+}
+
+// Rollback implements `core.RewindableHostChain`.
+func (p *Playground) Rollback(number int64) error {
+	p.blockProducer.currentBlockNum = number
+	return nil
+}
+
 // GetBlockPlugin implements `core.PolarisHostChain`.
 func (p *Playground) GetBlockPlugin() core.BlockPlugin {
 	return plugins.NewBlockPlugin()
@@ -66,12 +88,12 @@ func (p *Playground) GetGasPlugin() core.GasPlugin {
 
 // GetStatePlugin implements `core.PolarisHostChain`.
 func (p *Playground) GetStatePlugin() core.StatePlugin {
-	return plugins.NewStatePlugin()
+	return p.sp
 }
 
 // GetTxPoolPlugin implements `core.PolarisHostChain`.
 func (p *Playground) GetTxPoolPlugin() core.TxPoolPlugin {
-	return plugins.NewTxPoolPlugin()
+	return p.txp
 }
 
 // The Playground Host Chain does not support stateful precompiles.
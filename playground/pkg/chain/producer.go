@@ -24,7 +24,6 @@ import (
 	"context"
 
 	"pkg.berachain.dev/polaris/eth/api"
-	"pkg.berachain.dev/polaris/eth/core/types"
 )
 
 // blockProducer is the block producer.
@@ -33,28 +32,15 @@ type blockProducer struct {
 	currentBlockNum int64
 }
 
-// ProduceBlock produces a block from the mempool and returns it.
+// ProduceBlock produces a block from the transactions of the tx pool. Transactions that are
+// rejected by the block builder are left out of the block.
 func (bp *blockProducer) ProduceBlock() error {
 	bp.currentBlockNum++
 
 	ctx := context.Background()
-	// Prepare Polaris for a new block.
-	bp.polaris.Prepare(ctx, bp.currentBlockNum)
-
-	// TODO: get from mempool.
-	txs := make(types.Transactions, 0)
-
-	// Iterate through all the transactions in the mempool.
-	for _, txn := range txs {
-		_, err := bp.polaris.ProcessTransaction(context.Background(), txn)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Finalize the block.
-	err := bp.polaris.Finalize(ctx)
-	if err != nil {
+	if _, _, err := bp.polaris.BuildBlock(ctx, bp.currentBlockNum); err != nil {
+		// The block is not produced, so that it can be produced again.
+		bp.currentBlockNum--
 		return err
 	}
 
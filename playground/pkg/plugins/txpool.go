@@ -20,12 +20,228 @@
 
 package plugins
 
-import "pkg.berachain.dev/polaris/eth/core"
+import (
+	"errors"
+	"sort"
+	"sync"
 
+	"github.com/ethereum/go-ethereum/event"
+
+	"pkg.berachain.dev/polaris/eth/common"
+	"pkg.berachain.dev/polaris/eth/core"
+	"pkg.berachain.dev/polaris/eth/core/types"
+)
+
+// errAlreadyKnown is returned when a transaction is already in the pool.
+var errAlreadyKnown = errors.New("already known")
+
+// txPoolPlugin is a simple in-memory transaction pool for the playground chain.
 type txPoolPlugin struct {
-	core.TxPoolPlugin
+	sp core.StatePlugin
+
+	txs     map[common.Hash]*types.Transaction
+	senders map[common.Hash]common.Address
+	// private holds the private transactions, which are only exposed by `Executable`
+	private map[common.Hash]*types.Transaction
+
+	txFeed event.Feed
+	scope  event.SubscriptionScope
+
+	mu sync.RWMutex
+}
+
+// NewTxPoolPlugin returns a new in-memory transaction pool, which reads state nonces from the
+// given state plugin.
+func NewTxPoolPlugin(sp core.StatePlugin) core.TxPoolPlugin {
+	return &txPoolPlugin{
+		sp:      sp,
+		txs:     make(map[common.Hash]*types.Transaction),
+		senders: make(map[common.Hash]common.Address),
+		private: make(map[common.Hash]*types.Transaction),
+	}
+}
+
+// SendTx implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) SendTx(tx *types.Transaction) error {
+	sender, err := types.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+	if err != nil {
+		return err
+	}
+
+	tp.mu.Lock()
+	if _, ok := tp.txs[tx.Hash()]; ok {
+		tp.mu.Unlock()
+		return errAlreadyKnown
+	}
+	tp.txs[tx.Hash()] = tx
+	tp.senders[tx.Hash()] = sender
+	tp.mu.Unlock()
+
+	tp.txFeed.Send(core.NewTxsEvent{Txs: types.Transactions{tx}})
+	return nil
+}
+
+// SendPrivTx implements `core.TxPoolPlugin`. The private transaction is kept apart from the
+// other transactions of the pool and is not announced on the feed. Its max block number is not
+// enforced.
+func (tp *txPoolPlugin) SendPrivTx(tx *types.Transaction, _ uint64) error {
+	sender, err := types.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+	if err != nil {
+		return err
+	}
+
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if _, ok := tp.txs[tx.Hash()]; ok {
+		return errAlreadyKnown
+	}
+	if _, ok := tp.private[tx.Hash()]; ok {
+		return errAlreadyKnown
+	}
+	tp.private[tx.Hash()] = tx
+	tp.senders[tx.Hash()] = sender
+	return nil
 }
 
-func NewTxPoolPlugin() core.TxPoolPlugin {
-	return &txPoolPlugin{}
+// CancelPrivTx implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) CancelPrivTx(hash common.Hash) bool {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if _, ok := tp.private[hash]; !ok {
+		return false
+	}
+	delete(tp.private, hash)
+	delete(tp.senders, hash)
+	return true
+}
+
+// GetAllTransactions implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) GetAllTransactions() (types.Transactions, error) {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	txs := make(types.Transactions, 0, len(tp.txs))
+	for _, tx := range tp.txs {
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// GetTransaction implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) GetTransaction(hash common.Hash) *types.Transaction {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	return tp.txs[hash]
+}
+
+// GetNonce implements `core.TxPoolPlugin`. The next nonce of an address is its state nonce plus
+// the number of its pending transactions, as pending transactions are contiguous from the state
+// nonce.
+func (tp *txPoolPlugin) GetNonce(addr common.Address) (uint64, error) {
+	pending, _ := tp.ContentFrom(addr)
+	return tp.sp.GetNonce(addr) + uint64(len(pending)), nil
+}
+
+// Content implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) Content() (
+	map[common.Address]types.Transactions, map[common.Address]types.Transactions,
+) {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	pending := make(map[common.Address]types.Transactions)
+	queued := make(map[common.Address]types.Transactions)
+	for addr, txs := range tp.txsBySender() {
+		p, q := tp.splitPendingQueued(addr, txs)
+		if len(p) > 0 {
+			pending[addr] = p
+		}
+		if len(q) > 0 {
+			queued[addr] = q
+		}
+	}
+	return pending, queued
+}
+
+// Executable implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) Executable() map[common.Address]types.Transactions {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	txs := tp.txsBySender()
+	for hash, tx := range tp.private {
+		sender := tp.senders[hash]
+		txs[sender] = append(txs[sender], tx)
+	}
+	executable := make(map[common.Address]types.Transactions)
+	for addr, txs := range txs {
+		if p, _ := tp.splitPendingQueued(addr, txs); len(p) > 0 {
+			executable[addr] = p
+		}
+	}
+	return executable
+}
+
+// ContentFrom implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) ContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	return tp.splitPendingQueued(addr, tp.txsBySender()[addr])
+}
+
+// Stats implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) Stats() (int, int) {
+	pending, queued := tp.Content()
+	var numPending, numQueued int
+	for _, txs := range pending {
+		numPending += len(txs)
+	}
+	for _, txs := range queued {
+		numQueued += len(txs)
+	}
+	return numPending, numQueued
+}
+
+// SubscribeNewTxsEvent implements `core.TxPoolPlugin`.
+func (tp *txPoolPlugin) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
+	return tp.scope.Track(tp.txFeed.Subscribe(ch))
+}
+
+// txsBySender groups the pooled transactions by their sender. It assumes that the caller holds
+// the read lock.
+func (tp *txPoolPlugin) txsBySender() map[common.Address]types.Transactions {
+	txs := make(map[common.Address]types.Transactions)
+	for hash, tx := range tp.txs {
+		sender := tp.senders[hash]
+		txs[sender] = append(txs[sender], tx)
+	}
+	return txs
+}
+
+// splitPendingQueued splits the transactions of a sender into the ones that are executable
+// against its state nonce (pending) and the ones that are waiting on a nonce gap (queued).
+func (tp *txPoolPlugin) splitPendingQueued(
+	addr common.Address, txs types.Transactions,
+) (types.Transactions, types.Transactions) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	sorted := make(types.Transactions, len(txs))
+	copy(sorted, txs)
+	sort.Sort(types.TxByNonce(sorted))
+
+	var pending, queued types.Transactions
+	next := tp.sp.GetNonce(addr)
+	for _, tx := range sorted {
+		switch {
+		case tx.Nonce() < next:
+			continue
+		case tx.Nonce() == next:
+			pending = append(pending, tx)
+			next++
+		default:
+			queued = append(queued, tx)
+		}
+	}
+	return pending, queued
 }